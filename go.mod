@@ -4,6 +4,7 @@ go 1.22.7
 
 require (
 	github.com/consensys/gnark-crypto v0.14.0
+	github.com/klauspost/compress v1.18.0
 	github.com/pkg/errors v0.9.1
 	github.com/sirupsen/logrus v1.9.3
 	github.com/spf13/cobra v1.8.1
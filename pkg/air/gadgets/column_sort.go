@@ -6,6 +6,7 @@ import (
 	"github.com/consensys/go-corset/pkg/air"
 	sc "github.com/consensys/go-corset/pkg/schema"
 	"github.com/consensys/go-corset/pkg/schema/assignment"
+	"github.com/consensys/go-corset/pkg/trace"
 	"github.com/consensys/go-corset/pkg/util"
 )
 
@@ -43,11 +44,12 @@ func ApplyColumnSortGadget(col uint, sign bool, bitwidth uint, schema *air.Schem
 	// Add new column (if it does not already exist)
 	if !ok {
 		deltaIndex = schema.AddAssignment(
-			assignment.NewComputedColumn[air.Expr](column.Context, deltaName, Xdiff))
+			assignment.NewComputedColumn[air.Expr](column.Context, deltaName, &sc.FieldType{},
+				trace.NewDisplay(trace.DISPLAY_HEX), Xdiff))
 	}
 	// Add necessary bitwidth constraints
 	ApplyBitwidthGadget(deltaIndex, bitwidth, schema)
 	// Configure constraint: Delta[k] = X[k] - X[k-1]
 	Dk := air.NewColumnAccess(deltaIndex, 0)
-	schema.AddVanishingConstraint(deltaName, column.Context, util.None[int](), Dk.Equate(Xdiff))
+	schema.AddVanishingConstraint(deltaName, column.Context, util.None[[]int](), Dk.Equate(Xdiff))
 }
@@ -29,13 +29,14 @@ func Expand(ctx trace.Context, e air.Expr, schema *air.Schema) uint {
 	// Add new column (if it does not already exist)
 	if !ok {
 		// Add computed column
-		index = schema.AddAssignment(assignment.NewComputedColumn[air.Expr](ctx, name, e))
+		index = schema.AddAssignment(assignment.NewComputedColumn[air.Expr](ctx, name, &sc.FieldType{},
+			trace.NewDisplay(trace.DISPLAY_HEX), e))
 		// Construct v == [e]
 		v := air.NewColumnAccess(index, 0)
 		// Construct 1 == e/e
 		eq_e_v := v.Equate(e)
 		// Ensure (e - v) == 0, where v is value of computed column.
-		schema.AddVanishingConstraint(name, ctx, util.None[int](), eq_e_v)
+		schema.AddVanishingConstraint(name, ctx, util.None[[]int](), eq_e_v)
 	}
 	//
 	return index
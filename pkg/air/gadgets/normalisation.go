@@ -44,7 +44,8 @@ func ApplyPseudoInverseGadget(e air.Expr, schema *air.Schema) air.Expr {
 	// Add new column (if it does not already exist)
 	if !ok {
 		// Add computed column
-		index = schema.AddAssignment(assignment.NewComputedColumn[air.Expr](ctx, name, ie))
+		index = schema.AddAssignment(assignment.NewComputedColumn[air.Expr](ctx, name, &sc.FieldType{},
+			tr.NewDisplay(tr.DISPLAY_HEX), ie))
 		// Construct 1/e
 		inv_e := air.NewColumnAccess(index, 0)
 		// Construct e/e
@@ -57,10 +58,10 @@ func ApplyPseudoInverseGadget(e air.Expr, schema *air.Schema) air.Expr {
 		inv_e_implies_one_e_e := inv_e.Mul(one_e_e)
 		// Ensure (e != 0) ==> (1 == e/e)
 		l_name := fmt.Sprintf("%s <=", name)
-		schema.AddVanishingConstraint(l_name, ctx, util.None[int](), e_implies_one_e_e)
+		schema.AddVanishingConstraint(l_name, ctx, util.None[[]int](), e_implies_one_e_e)
 		// Ensure (e/e != 0) ==> (1 == e/e)
 		r_name := fmt.Sprintf("%s =>", name)
-		schema.AddVanishingConstraint(r_name, ctx, util.None[int](), inv_e_implies_one_e_e)
+		schema.AddVanishingConstraint(r_name, ctx, util.None[[]int](), inv_e_implies_one_e_e)
 	}
 	// Done
 	return air.NewColumnAccess(index, 0)
@@ -78,12 +78,12 @@ func (p *Schema) AddModule(name string) uint {
 
 // AddColumn appends a new data column whose values must be provided by the
 // user.
-func (p *Schema) AddColumn(context trace.Context, name string, datatype schema.Type) uint {
+func (p *Schema) AddColumn(context trace.Context, name string, datatype schema.Type, display trace.Display) uint {
 	if context.Module() >= uint(len(p.modules)) {
 		panic(fmt.Sprintf("invalid module index (%d)", context.Module()))
 	}
 
-	col := assignment.NewDataColumn(context, name, datatype)
+	col := assignment.NewDataColumn(context, name, datatype, display)
 	// NOTE: the air level has no ability to enforce the type specified for a
 	// given column.
 	p.inputs = append(p.inputs, col)
@@ -109,9 +109,11 @@ func (p *Schema) AddAssignment(c schema.Assignment) uint {
 	return index
 }
 
-// AddLookupConstraint appends a new lookup constraint.
+// AddLookupConstraint appends a new lookup constraint.  Either selector can be
+// empty (i.e. no selector column), in which case all rows are considered.
 func (p *Schema) AddLookupConstraint(handle string, source trace.Context,
-	target trace.Context, sources []uint, targets []uint) {
+	target trace.Context, sources []uint, targets []uint, sourceSelector util.Option[uint],
+	targetSelector util.Option[uint]) {
 	if len(targets) != len(sources) {
 		panic("differeng number of target / source lookup columns")
 	}
@@ -125,11 +127,21 @@ func (p *Schema) AddLookupConstraint(handle string, source trace.Context,
 		into[i] = NewColumnAccess(targets[i], 0)
 	}
 	// Construct lookup constraint
-	var lookup LookupConstraint = constraint.NewLookupConstraint(handle, source, target, from, into)
+	var lookup LookupConstraint = constraint.NewLookupConstraint(handle, source, target, from, into,
+		selectorAccess(sourceSelector), selectorAccess(targetSelector))
 	// Add
 	p.constraints = append(p.constraints, lookup)
 }
 
+// Construct a column access for an optional selector column.
+func selectorAccess(selector util.Option[uint]) util.Option[*ColumnAccess] {
+	if selector.HasValue() {
+		return util.Some(NewColumnAccess(selector.Unwrap(), 0))
+	}
+	//
+	return util.None[*ColumnAccess]()
+}
+
 // AddPermutationConstraint appends a new permutation constraint which
 // ensures that one column is a permutation of another.
 func (p *Schema) AddPermutationConstraint(targets []uint, sources []uint) {
@@ -143,7 +155,7 @@ func (p *Schema) AddPropertyAssertion(handle string, context trace.Context, asse
 }
 
 // AddVanishingConstraint appends a new vanishing constraint.
-func (p *Schema) AddVanishingConstraint(handle string, context trace.Context, domain util.Option[int], expr Expr) {
+func (p *Schema) AddVanishingConstraint(handle string, context trace.Context, domain util.Option[[]int], expr Expr) {
 	if context.Module() >= uint(len(p.modules)) {
 		panic(fmt.Sprintf("invalid module index (%d)", context.Module()))
 	}
@@ -81,7 +81,8 @@ func (e jsonConstraint) addToSchema(colmap map[uint]uint, schema *hir.Schema) {
 		// Normalise handle
 		handle := asHandle(e.Lookup.Handle)
 		// Add constraint
-		schema.AddLookupConstraint(handle.column, sourceCtx, targetCtx, sources, targets)
+		schema.AddLookupConstraint(handle.column, sourceCtx, targetCtx, sources, targets, util.None[hir.UnitExpr](),
+			util.None[hir.UnitExpr]())
 	} else if e.InRange != nil {
 		// Translate the vanishing expression
 		expr := e.InRange.Expr.ToHir(colmap, schema)
@@ -98,13 +99,12 @@ func (e jsonConstraint) addToSchema(colmap map[uint]uint, schema *hir.Schema) {
 	}
 }
 
-func (e jsonDomain) toHir() util.Option[int] {
-	if len(e.Set) == 1 {
-		domain := e.Set[0]
-		return util.Some(domain)
+func (e jsonDomain) toHir() util.Option[[]int] {
+	if len(e.Set) > 0 {
+		return util.Some(e.Set)
 	} else if e.Set != nil {
 		panic("Unknown domain")
 	}
 	// Default
-	return util.None[int]()
+	return util.None[[]int]()
 }
@@ -86,6 +86,11 @@ type register struct {
 	// must be a factor of the number of rows in the column.  For example, a
 	// column with length multiplier of 2 must have an even number of rows, etc.
 	LengthMultiplier uint `json:"length_multiplier"`
+	// Display determines how values of this register should be displayed.
+	// Observe this field is not present in the original binfile format.
+	// Instead, this field is determined from the display base of the columns
+	// allocated to this register.
+	Display trace.Display
 }
 
 type columnSet struct {
@@ -157,6 +162,24 @@ func transferColumnInfo(cs *columnSet) {
 			// Copy over must-prove info.
 			cs.Registers[c.Register].MustProve = true
 		}
+		// Copy over display info.
+		cs.Registers[c.Register].Display = asDisplay(c.Base)
+	}
+}
+
+// Convert the display base of a column (e.g. "Hex", "Dec", etc) into the
+// corresponding display mode.  Any base which is not recognised is displayed
+// using the default (i.e. hexadecimal).
+func asDisplay(base string) trace.Display {
+	switch base {
+	case "Dec":
+		return trace.NewDisplay(trace.DISPLAY_DEC)
+	case "Bytes":
+		return trace.NewDisplay(trace.DISPLAY_BYTES)
+	case "OpCode":
+		return trace.NewDisplay(trace.DISPLAY_OPCODE)
+	default:
+		return trace.NewDisplay(trace.DISPLAY_HEX)
 	}
 }
 
@@ -175,7 +198,7 @@ func allocateRegisters(cs *constraintSet, schema *hir.Schema) map[uint]uint {
 			ctx := trace.NewContext(mid, c.LengthMultiplier)
 			col_type := c.Type.toHir()
 			// Add column for this
-			cid := schema.AddDataColumn(ctx, handle.column, col_type)
+			cid := schema.AddDataColumn(ctx, handle.column, col_type, c.Display)
 			// Check whether a type constraint required or not.
 			if c.MustProve && col_type.AsUint() != nil {
 				bound := col_type.AsUint().Bound()
@@ -7,14 +7,19 @@ import (
 	"os"
 
 	"github.com/consensys/go-corset/pkg/hir"
+	"github.com/consensys/go-corset/pkg/report"
 	sc "github.com/consensys/go-corset/pkg/schema"
 	"github.com/consensys/go-corset/pkg/schema/constraint"
 	tr "github.com/consensys/go-corset/pkg/trace"
+	"github.com/consensys/go-corset/pkg/trace/lt"
 	"github.com/consensys/go-corset/pkg/util"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+// REPORT_TEXT indicates failures should be reported as human-readable text.
+const REPORT_TEXT = "text"
+
 // computeCmd represents the compute command
 var checkCmd = &cobra.Command{
 	Use:   "check [flags] trace_file constraint_file",
@@ -52,8 +57,28 @@ var checkCmd = &cobra.Command{
 		cfg.parallelExpansion = !GetFlag(cmd, "sequential")
 		cfg.batchSize = GetUint(cmd, "batch")
 		cfg.ansiEscapes = GetFlag(cmd, "ansi-escapes")
+		cfg.format = GetString(cmd, "format")
+		cfg.allFailures = GetFlag(cmd, "all-failures")
+		cfg.failureLimit = 1
+		// Determine how many failures to report per constraint
+		if cfg.allFailures {
+			if cfg.failureLimit = GetUint(cmd, "max-failures"); cfg.failureLimit == 0 {
+				cfg.failureLimit = math.MaxUint
+			}
+		}
 		// TODO: support true ranges
 		cfg.padding.Left = cfg.padding.Right
+		// Configure structured output (if applicable)
+		switch cfg.format {
+		case REPORT_TEXT:
+			// default
+		case report.FORMAT_JSON, report.FORMAT_JUNIT:
+			cfg.output = report.NewReport()
+		default:
+			fmt.Printf("unknown output format \"%s\"\n", cfg.format)
+			os.Exit(1)
+		}
+		//
 		if !cfg.hir && !cfg.mir && !cfg.air {
 			// If IR not specified default to running all.
 			cfg.hir, cfg.mir, cfg.air = true, true, true
@@ -64,12 +89,32 @@ var checkCmd = &cobra.Command{
 		hirSchema = readSchema(cfg.stdlib, cfg.debug, legacy, args[1:])
 		//
 		stats.Log("Reading constraints file")
-		// Parse trace file
-		columns := readTraceFile(args[0])
+		// Determine which trace columns to load
+		var filter lt.ColumnFilter
+		//
+		if GetFlag(cmd, "schema-columns-only") {
+			filter = schemaColumnFilter(hirSchema)
+		}
+		// Parse trace file (where column data is read on demand)
+		columns, closer := openTraceFile(args[0], filter)
 		//
 		stats.Log("Reading trace file")
 		// Go!
-		if !checkTraceWithLowering(columns, hirSchema, cfg) {
+		ok := checkTraceWithLowering(columns, hirSchema, cfg)
+		// Column data no longer required
+		if err := closer.Close(); err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		// Write structured output (if applicable)
+		if cfg.output != nil {
+			if err := cfg.output.Write(os.Stdout, cfg.format); err != nil {
+				fmt.Println(err)
+				os.Exit(2)
+			}
+		}
+		//
+		if !ok {
 			os.Exit(1)
 		}
 	},
@@ -119,6 +164,19 @@ type checkConfig struct {
 	batchSize uint
 	// Enable ansi escape codes in reports
 	ansiEscapes bool
+	// Specifies whether or not to report all failing rows for each constraint,
+	// rather than just the first.  When enabled, failures are summarised by
+	// constraint.
+	allFailures bool
+	// Specifies the maximum number of failures to report for any given
+	// constraint.
+	failureLimit uint
+	// Specifies the format in which failures are reported (e.g. text, json,
+	// etc).
+	format string
+	// Collects failures for structured output (e.g. json).  When nil, failures
+	// are reported as human-readable text instead.
+	output *report.Report
 }
 
 // Check a given trace is consistently accepted (or rejected) at the different
@@ -142,6 +200,11 @@ func checkTraceWithLowering(cols []tr.RawColumn, schema *hir.Schema, cfg checkCo
 }
 
 func checkTrace(ir string, cols []tr.RawColumn, schema sc.Schema, cfg checkConfig) bool {
+	// Record level for structured output (if applicable)
+	if cfg.output != nil {
+		cfg.output.AddLevel(ir, schema)
+	}
+	//
 	builder := sc.NewTraceBuilder(schema).Expand(cfg.expand).Parallel(cfg.parallelExpansion).BatchSize(cfg.batchSize)
 	//
 	for n := cfg.padding.Left; n <= cfg.padding.Right; n++ {
@@ -150,7 +213,7 @@ func checkTrace(ir string, cols []tr.RawColumn, schema sc.Schema, cfg checkConfi
 		// Log cost of expansion
 		stats.Log("Expanding trace columns")
 		// Report any errors
-		reportErrors(cfg.strict, ir, errs)
+		reportCheckErrors(cfg.strict, ir, errs, cfg)
 		// Check whether considered unrecoverable
 		if trace == nil || (cfg.strict && len(errs) > 0) {
 			return false
@@ -159,20 +222,26 @@ func checkTrace(ir string, cols []tr.RawColumn, schema sc.Schema, cfg checkConfi
 		stats = util.NewPerfStats()
 		//
 		if err := validationCheck(trace, schema); err != nil {
-			reportErrors(true, ir, []error{err})
+			reportCheckErrors(true, ir, []error{err}, cfg)
 			return false
 		}
 		// Check trace
 		stats.Log("Validating trace")
 		stats = util.NewPerfStats()
 		// Check constraints
-		if errs := sc.Accepts(cfg.batchSize, schema, trace); len(errs) > 0 {
-			reportFailures(ir, errs, trace, cfg)
+		failures := sc.Accepts(cfg.batchSize, cfg.failureLimit, schema, trace)
+		reportChecked(ir, schema.Constraints(), cfg)
+		//
+		if len(failures) > 0 {
+			reportFailures(ir, failures, trace, schema, cfg)
 			return false
 		}
 		// Check assertions
-		if errs := sc.Asserts(cfg.batchSize, schema, trace); len(errs) > 0 {
-			reportFailures(ir, errs, trace, cfg)
+		failures = sc.Asserts(cfg.batchSize, cfg.failureLimit, schema, trace)
+		reportChecked(ir, schema.Assertions(), cfg)
+		//
+		if len(failures) > 0 {
+			reportFailures(ir, failures, trace, schema, cfg)
 			return false
 		}
 
@@ -231,7 +300,17 @@ func validateColumn(colType sc.Type, col tr.Column, mod sc.Module) error {
 }
 
 // Report constraint failures, whilst providing contextual information (when requested).
-func reportFailures(ir string, failures []sc.Failure, trace tr.Trace, cfg checkConfig) {
+func reportFailures(ir string, failures []sc.Failure, trace tr.Trace, schema sc.Schema, cfg checkConfig) {
+	// When producing structured output, just record the failures.
+	if cfg.output != nil {
+		cfg.output.AddFailures(ir, failures, trace, schema)
+		return
+	} else if cfg.allFailures {
+		// When reporting all failures, summarise them instead.
+		reportFailureSummaries(ir, failures, trace, schema, cfg)
+		return
+	}
+	//
 	errs := make([]error, len(failures))
 	for i, f := range failures {
 		errs[i] = errors.New(f.Message())
@@ -240,26 +319,87 @@ func reportFailures(ir string, failures []sc.Failure, trace tr.Trace, cfg checkC
 	reportErrors(true, ir, errs)
 	// Second, produce report (if requested)
 	if cfg.report {
+		displays := columnDisplays(schema)
+		//
 		for _, f := range failures {
-			reportFailure(f, trace, cfg)
+			reportFailure(f, trace, displays, cfg)
+		}
+	}
+}
+
+// Report constraint failures grouped by constraint, such that the failing rows
+// of each constraint are summarised as contiguous spans.  When a report is
+// requested, only the first failure of each constraint is detailed.
+func reportFailureSummaries(ir string, failures []sc.Failure, trace tr.Trace, schema sc.Schema, cfg checkConfig) {
+	summaries, others := sc.SummariseFailures(schema, failures)
+	errs := make([]error, 0, len(failures))
+	//
+	for _, s := range summaries {
+		errs = append(errs, errors.New(s.Message(MAX_FAILURE_SPANS)))
+	}
+	//
+	for _, f := range others {
+		errs = append(errs, errors.New(f.Message()))
+	}
+	// First, log errors
+	reportErrors(true, ir, errs)
+	// Second, produce report (if requested)
+	if cfg.report {
+		displays := columnDisplays(schema)
+		//
+		for _, s := range summaries {
+			reportFailure(s.Failures[0], trace, displays, cfg)
+		}
+		//
+		for _, f := range others {
+			reportFailure(f, trace, displays, cfg)
 		}
 	}
 }
 
+// MAX_FAILURE_SPANS determines the maximum number of spans of failing rows
+// shown for any one constraint when summarising failures.
+const MAX_FAILURE_SPANS = 16
+
 // Print a human-readable report detailing the given failure
-func reportFailure(failure sc.Failure, trace tr.Trace, cfg checkConfig) {
+func reportFailure(failure sc.Failure, trace tr.Trace, displays []tr.Display, cfg checkConfig) {
 	if f, ok := failure.(*constraint.VanishingFailure); ok {
 		cells := f.RequiredCells(trace)
-		reportConstraintFailure("constraint", f.Handle, cells, trace, cfg)
+		reportConstraintFailure("constraint", f.Handle, cells, trace, displays, cfg)
+	} else if f, ok := failure.(*constraint.LookupFailure); ok {
+		cells := f.RequiredCells(trace)
+		reportConstraintFailure("lookup", f.Handle, cells, trace, displays, cfg)
+		// Show the closest matching target row (if any)
+		if row, matches, ok := f.Closest(); ok {
+			cells = f.TargetCells(row, trace)
+			handle := fmt.Sprintf("%s (closest target row %d matches %d of %d columns)", f.Handle, row, matches,
+				len(f.Targets))
+			reportConstraintFailure("lookup", handle, cells, trace, displays, cfg)
+		}
+	} else if f, ok := failure.(*constraint.RangeFailure); ok {
+		cells := f.RequiredCells(trace)
+		reportConstraintFailure("range constraint", f.Handle, cells, trace, displays, cfg)
 	} else if f, ok := failure.(*sc.AssertionFailure); ok {
 		cells := f.RequiredCells(trace)
-		reportConstraintFailure("assertion", f.Handle, cells, trace, cfg)
+		reportConstraintFailure("assertion", f.Handle, cells, trace, displays, cfg)
 	}
 }
 
+// Determine the display mode for each column in the given schema.  Observe
+// that columns in the trace are assumed to line up with those in the schema.
+func columnDisplays(schema sc.Schema) []tr.Display {
+	var displays []tr.Display
+	//
+	for iter := schema.Columns(); iter.HasNext(); {
+		displays = append(displays, iter.Next().Display)
+	}
+	//
+	return displays
+}
+
 // Print a human-readable report detailing the given failure with a vanishing constraint.
 func reportConstraintFailure(kind string, handle string, cells *util.AnySortedSet[tr.CellRef],
-	trace tr.Trace, cfg checkConfig) {
+	trace tr.Trace, displays []tr.Display, cfg checkConfig) {
 	var start uint = math.MaxUint
 	// Determine all (input) cells involved in evaluating the given constraint
 	end := uint(0)
@@ -285,12 +425,38 @@ func reportConstraintFailure(kind string, handle string, cells *util.AnySortedSe
 	tp = tp.Highlight(func(cell tr.CellRef, trace tr.Trace) bool {
 		return cells.Contains(cell)
 	})
+	// Render cells according to their column's display mode
+	tp = tp.Displays(func(col uint, trace tr.Trace) tr.Display {
+		if col < uint(len(displays)) {
+			return displays[col]
+		}
+		//
+		return tr.NewDisplay(tr.DISPLAY_HEX)
+	})
 	// Print out report
 	fmt.Printf("failing %s %s:\n", kind, handle)
 	tp.Print(trace)
 	fmt.Println()
 }
 
+// Record that the given constraints (or assertions) have been checked, for
+// structured output (if applicable).
+func reportChecked(ir string, constraints util.Iterator[sc.Constraint], cfg checkConfig) {
+	if cfg.output != nil {
+		cfg.output.AddChecked(ir, constraints)
+	}
+}
+
+// Report errors arising from checking a trace, either by logging them or by
+// recording them for structured output (as appropriate).
+func reportCheckErrors(error bool, ir string, errs []error, cfg checkConfig) {
+	if cfg.output != nil {
+		cfg.output.AddErrors(error, ir, errs)
+	} else {
+		reportErrors(error, ir, errs)
+	}
+}
+
 func reportErrors(error bool, ir string, errs []error) {
 	// Construct set to ensure deduplicate errors
 	set := make(map[string]bool, len(errs))
@@ -314,6 +480,10 @@ func init() {
 	checkCmd.Flags().Bool("report", false, "report details of failure for debugging")
 	checkCmd.Flags().Uint("report-context", 2, "specify number of rows to show eitherside of failure in report")
 	checkCmd.Flags().Uint("report-cellwidth", 32, "specify max number of bytes to show in a given cell in the report")
+	checkCmd.Flags().Bool("schema-columns-only", false, "only load those trace columns named in the constraints")
+	checkCmd.Flags().String("format", REPORT_TEXT, "specify output format for failures (text, json or junit)")
+	checkCmd.Flags().Bool("all-failures", false, "report all failing rows of each constraint, rather than just the first")
+	checkCmd.Flags().Uint("max-failures", 0, "specify max number of failing rows to report per constraint (0 for no limit)")
 	checkCmd.Flags().Bool("raw", false, "assume input trace already expanded")
 	checkCmd.Flags().Bool("hir", false, "check at HIR level")
 	checkCmd.Flags().Bool("mir", false, "check at MIR level")
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"reflect"
@@ -11,6 +12,7 @@ import (
 	"github.com/consensys/go-corset/pkg/schema"
 	sc "github.com/consensys/go-corset/pkg/schema"
 	"github.com/consensys/go-corset/pkg/schema/assignment"
+	"github.com/consensys/go-corset/pkg/schema/graph"
 	"github.com/consensys/go-corset/pkg/util"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -36,6 +38,8 @@ var debugCmd = &cobra.Command{
 		mir := GetFlag(cmd, "mir")
 		air := GetFlag(cmd, "air")
 		stats := GetFlag(cmd, "stats")
+		graph := GetFlag(cmd, "graph")
+		graphFormat := GetString(cmd, "graph-format")
 		stdlib := !GetFlag(cmd, "no-stdlib")
 		debug := GetFlag(cmd, "debug")
 		legacy := GetFlag(cmd, "legacy")
@@ -44,6 +48,8 @@ var debugCmd = &cobra.Command{
 		// Print constraints
 		if stats {
 			printStats(hirSchema, hir, mir, air)
+		} else if graph {
+			printGraphs(hirSchema, hir, mir, air, graphFormat)
 		} else {
 			printSchemas(hirSchema, hir, mir, air)
 		}
@@ -56,6 +62,8 @@ func init() {
 	debugCmd.Flags().Bool("mir", false, "Print constraints at MIR level")
 	debugCmd.Flags().Bool("air", false, "Print constraints at AIR level")
 	debugCmd.Flags().Bool("stats", false, "Print summary information")
+	debugCmd.Flags().Bool("graph", false, "Print module and column dependency graphs")
+	debugCmd.Flags().String("graph-format", GRAPH_DOT, "Format for dependency graphs (dot or json)")
 	debugCmd.Flags().Bool("debug", false, "enable debugging constraints")
 }
 
@@ -86,6 +94,57 @@ func printSchema(schema schema.Schema) {
 	}
 }
 
+// GRAPH_DOT indicates dependency graphs should be printed in Graphviz DOT.
+const GRAPH_DOT = "dot"
+
+// GRAPH_JSON indicates dependency graphs should be printed as a JSON document.
+const GRAPH_JSON = "json"
+
+// Print the module and column dependency graphs at each of the requested
+// levels in the given format.
+func printGraphs(hirSchema *hir.Schema, hir bool, mir bool, air bool, format string) {
+	var (
+		mirSchema = hirSchema.LowerToMir()
+		airSchema = mirSchema.LowerToAir()
+		names     []string
+		graphs    = make(map[string]*graph.Graph)
+	)
+	//
+	if hir {
+		names = append(names, "hir")
+		graphs["hir"] = graph.Build(hirSchema)
+	}
+	//
+	if mir {
+		names = append(names, "mir")
+		graphs["mir"] = graph.Build(mirSchema)
+	}
+	//
+	if air {
+		names = append(names, "air")
+		graphs["air"] = graph.Build(airSchema)
+	}
+	//
+	switch format {
+	case GRAPH_DOT:
+		for _, name := range names {
+			fmt.Print(graph.Dot(name, graphs[name]))
+		}
+	case GRAPH_JSON:
+		bytes, err := json.MarshalIndent(graphs, "", "  ")
+		//
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		//
+		fmt.Println(string(bytes))
+	default:
+		fmt.Printf("unknown graph format \"%s\"\n", format)
+		os.Exit(2)
+	}
+}
+
 func printStats(hirSchema *hir.Schema, hir bool, mir bool, air bool) {
 	schemas := make([]schema.Schema, 0)
 	mirSchema := hirSchema.LowerToMir()
@@ -144,6 +203,7 @@ var schemaSummarisers []schemaSummariser = []schemaSummariser{
 	assignmentCounter("Committed Columns", reflect.TypeOf((*assignment.DataColumn)(nil))),
 	assignmentCounter("Computed Columns", computedColumns...),
 	assignmentCounter("Computation Columns", reflect.TypeOf((*assignment.Computation)(nil))),
+	assignmentCounter("Fixed Columns", reflect.TypeOf((*assignment.FixedColumn)(nil))),
 	assignmentCounter("Interleavings", reflect.TypeOf((*assignment.Interleaving)(nil))),
 	assignmentCounter("Lexicographic Orderings", reflect.TypeOf((*assignment.LexicographicSort)(nil))),
 	assignmentCounter("Sorted Permutations", reflect.TypeOf((*assignment.SortedPermutation)(nil))),
@@ -179,7 +239,7 @@ var permutationConstraints = []reflect.Type{
 	reflect.TypeOf((air.PermutationConstraint)(nil))}
 
 var computedColumns = []reflect.Type{
-	// permutation constraints only exist at AIR level
+	reflect.TypeOf((hir.ComputedColumn)(nil)),
 	reflect.TypeOf((*assignment.ComputedColumn[air.Expr])(nil))}
 
 func constraintCounter(title string, types ...reflect.Type) schemaSummariser {
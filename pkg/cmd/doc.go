@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/consensys/go-corset/pkg/corset/doc"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+	"github.com/spf13/cobra"
+)
+
+var docCmd = &cobra.Command{
+	Use:   "doc [flags] constraint_file(s)",
+	Short: "generate documentation for constraint files.",
+	Long: `Generate documentation describing each module declared in one or more
+	constraint files.  This lists the columns, perspectives, constraints and
+	lookups of each module, along with any comments immediately preceding
+	them.  One file is generated for each module, along with an index of all
+	modules, in either Markdown or HTML format.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			ext           string
+			render        func(*doc.Module) string
+			renderIndex   func([]*doc.Module) string
+			outputDirName = GetString(cmd, "output")
+		)
+		//
+		if len(args) == 0 {
+			fmt.Println(cmd.UsageString())
+			os.Exit(1)
+		}
+		//
+		switch format := GetString(cmd, "format"); format {
+		case "markdown":
+			ext, render, renderIndex = doc.MARKDOWN_EXT, doc.Markdown, doc.MarkdownIndex
+		case "html":
+			ext, render, renderIndex = doc.HTML_EXT, doc.HTML, doc.HTMLIndex
+		default:
+			fmt.Printf("unknown documentation format \"%s\"\n", format)
+			os.Exit(2)
+		}
+		//
+		stdlib := !GetFlag(cmd, "no-stdlib")
+		srcfiles := make([]*sexp.SourceFile, len(args))
+		// Read each file
+		for i, n := range args {
+			bytes, err := os.ReadFile(n)
+			// Sanity check for errors
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(3)
+			}
+			//
+			srcfiles[i] = sexp.NewSourceFile(n, bytes)
+		}
+		// Extract documentation
+		modules, errs := doc.Extract(stdlib, srcfiles)
+		//
+		for _, err := range errs {
+			printSyntaxError(&err)
+		}
+		//
+		if len(errs) > 0 {
+			os.Exit(4)
+		}
+		// Write documentation
+		if err := os.MkdirAll(outputDirName, 0755); err != nil {
+			fmt.Println(err)
+			os.Exit(3)
+		}
+		//
+		writeDocFile(filepath.Join(outputDirName, doc.INDEX+ext), renderIndex(modules))
+		//
+		for _, m := range modules {
+			writeDocFile(filepath.Join(outputDirName, doc.Filename(m.Name)+ext), render(m))
+		}
+	},
+}
+
+func writeDocFile(filename string, contents string) {
+	if err := os.WriteFile(filename, []byte(contents), 0644); err != nil {
+		fmt.Println(err)
+		os.Exit(3)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(docCmd)
+	docCmd.Flags().String("format", "markdown", "specify output format (markdown or html)")
+	docCmd.Flags().StringP("output", "o", "doc", "specify directory into which documentation is written")
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/consensys/go-corset/pkg/corset"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+	"github.com/spf13/cobra"
+)
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [flags] constraint_file(s)",
+	Short: "format constraint files.",
+	Long: `Format one or more constraint files according to a canonical layout,
+	whilst preserving comments.  By default, formatted files are printed to
+	stdout.  Alternatively, files can be rewritten in place, or checked to
+	determine whether they are already formatted (e.g. as part of CI).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var unformatted = false
+		//
+		if len(args) == 0 {
+			fmt.Println(cmd.UsageString())
+			os.Exit(1)
+		}
+		//
+		write := GetFlag(cmd, "write")
+		check := GetFlag(cmd, "check")
+		width := GetUint(cmd, "width")
+		//
+		filenames, err := expandSourceFiles(args)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		//
+		for _, filename := range filenames {
+			bytes, err := os.ReadFile(filename)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(2)
+			}
+			//
+			srcfile := sexp.NewSourceFile(filename, bytes)
+			formatted, serr := corset.FormatSourceFile(srcfile, width)
+			//
+			if serr != nil {
+				printSyntaxError(serr)
+				os.Exit(4)
+			} else if check && formatted != string(bytes) {
+				// Report file as unformatted
+				fmt.Println(filename)
+				//
+				unformatted = true
+			} else if write && !check && formatted != string(bytes) {
+				if err := os.WriteFile(filename, []byte(formatted), 0644); err != nil {
+					fmt.Println(err)
+					os.Exit(2)
+				}
+			} else if !write && !check {
+				fmt.Print(formatted)
+			}
+		}
+		//
+		if unformatted {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(fmtCmd)
+	fmtCmd.Flags().BoolP("write", "w", false, "rewrite files in place, rather than printing them")
+	fmtCmd.Flags().Bool("check", false, "list files which are not formatted, and fail if there are any")
+	fmtCmd.Flags().Uint("width", 80, "specify the maximum width of a line")
+}
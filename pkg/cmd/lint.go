@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/consensys/go-corset/pkg/corset"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+	"github.com/spf13/cobra"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [flags] constraint_file(s)",
+	Short: "check constraint files for common problems.",
+	Long: `Check one or more constraint files for common problems which do not
+	prevent compilation, but which may indicate mistakes.  For example, columns
+	which are never used, or which are not used in any constraint.  A warning
+	can be suppressed using a comment such as ";; lint:ignore unused-column"
+	on the same line, or the line immediately before.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println(cmd.UsageString())
+			os.Exit(1)
+		}
+		//
+		stdlib := !GetFlag(cmd, "no-stdlib")
+		srcfiles := make([]*sexp.SourceFile, len(args))
+		// Read each file
+		for i, n := range args {
+			bytes, err := os.ReadFile(n)
+			// Sanity check for errors
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(3)
+			}
+			//
+			srcfiles[i] = sexp.NewSourceFile(n, bytes)
+		}
+		// Lint source files
+		warnings, errs := corset.LintSourceFiles(stdlib, srcfiles)
+		// Report errors
+		for _, err := range errs {
+			printSyntaxError(&err)
+		}
+		//
+		if len(errs) > 0 {
+			os.Exit(4)
+		}
+		// Report warnings
+		for _, warning := range warnings {
+			err := warning.Error.SourceFile().SyntaxError(warning.Error.Span(),
+				fmt.Sprintf("warning: %s [%s]", warning.Error.Message(), warning.Kind))
+			printSyntaxError(err)
+		}
+		//
+		if len(warnings) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+}
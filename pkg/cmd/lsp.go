@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/consensys/go-corset/pkg/corset/lsp"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp [flags]",
+	Short: "run a language server for constraint files.",
+	Long: `Run a language server which communicates over stdio using the
+	Language Server Protocol.  This provides diagnostics, go-to-definition,
+	hover and completion for constraint files opened in an editor.  Each
+	file is compiled independently, along with any files it includes.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 0 {
+			fmt.Println(cmd.UsageString())
+			os.Exit(1)
+		}
+		// Configure log level.  Observe that logging goes to stderr, and hence
+		// does not interfere with the protocol.
+		if GetFlag(cmd, "verbose") {
+			log.SetLevel(log.DebugLevel)
+		}
+		//
+		stdlib := !GetFlag(cmd, "no-stdlib")
+		// Run server until client exits
+		if err := lsp.NewServer(stdlib, os.Stdin, os.Stdout).Run(); err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+}
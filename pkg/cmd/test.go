@@ -87,7 +87,7 @@ func runTests(nrows uint, cfg checkConfig, hirSchema *hir.Schema) bool {
 func testTraceWithLowering(trace tr.Trace, schema *hir.Schema, cfg checkConfig) bool {
 	ok := true
 	// Check whether assertions hold for this trace
-	asserts := sc.Asserts(cfg.batchSize, schema, trace)
+	asserts := sc.Asserts(cfg.batchSize, 1, schema, trace)
 	// Process individually
 	if cfg.hir {
 		ok = testTrace("HIR", asserts, trace, schema, cfg) && ok
@@ -109,9 +109,9 @@ func testTrace(ir string, asserts []sc.Failure, trace tr.Trace, schema sc.Schema
 	//
 	for n := cfg.padding.Left; n <= cfg.padding.Right; n++ {
 		// Check constraints
-		if errs := sc.Accepts(cfg.batchSize, schema, trace); len(asserts) > 0 && len(errs) == 0 {
+		if errs := sc.Accepts(cfg.batchSize, 1, schema, trace); len(asserts) > 0 && len(errs) == 0 {
 			// Trace accepts, but at least one assertion has failed.
-			reportFailures(ir, asserts, trace, cfg)
+			reportFailures(ir, asserts, trace, schema, cfg)
 			// Indicate all is not well
 			ok = false
 		}
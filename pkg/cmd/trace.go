@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"path"
 	"regexp"
 	"strings"
 
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/go-corset/pkg/hir"
 	"github.com/consensys/go-corset/pkg/trace"
 	"github.com/consensys/go-corset/pkg/util"
 	"github.com/spf13/cobra"
@@ -14,18 +17,22 @@ import (
 
 // traceCmd represents the trace command for manipulating traces.
 var traceCmd = &cobra.Command{
-	Use:   "trace [flags] trace_file",
+	Use:   "trace [flags] trace_file [constraint_file(s)]",
 	Short: "Operate on a trace file.",
 	Long: `Operate on a trace file, such as converting
 	it from one format (e.g. lt) to another (e.g. json),
-	or filtering out modules, or listing columns, etc.`,
+	or filtering out modules, or listing columns, etc.
+	When constraint file(s) are given, these are used to
+	determine how column values should be displayed.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) != 1 {
+		var displays map[string]trace.Display
+		//
+		if len(args) < 1 {
 			fmt.Println(cmd.UsageString())
 			os.Exit(1)
 		}
 		// Parse trace
-		cols := readTraceFile(args[0])
+		cols := readTraceFile(args[0], nil)
 		list := GetFlag(cmd, "list")
 		stats := GetFlag(cmd, "stats")
 		includes := GetStringArray(cmd, "include")
@@ -35,6 +42,11 @@ var traceCmd = &cobra.Command{
 		max_width := GetUint(cmd, "max-width")
 		filter := GetString(cmd, "filter")
 		output := GetString(cmd, "out")
+		ltVersion := GetUint(cmd, "lt-version")
+		// Read constraints (if provided)
+		if len(args) > 1 {
+			displays = columnDisplayMap(readSchema(true, false, false, args[1:]))
+		}
 		// construct filters
 		if filter != "" {
 			cols = filterColumns(cols, filter)
@@ -50,17 +62,118 @@ var traceCmd = &cobra.Command{
 		}
 		//
 		if output != "" {
-			writeTraceFile(output, cols)
+			writeTraceFile(output, cols, ltVersion)
 		}
 
 		if print {
-			printTrace(start, max_width, cols)
+			printTrace(start, max_width, displays, cols)
+		}
+	},
+}
+
+// traceConvertCmd represents the trace convert command for converting traces
+// between formats.
+var traceConvertCmd = &cobra.Command{
+	Use:   "convert [flags] input_file output_file",
+	Short: "Convert a trace file from one format to another.",
+	Long: `Convert a trace file from one format to another,
+	where the format of each file is determined by its
+	extension (e.g. json, lt or csv).  Columns can be
+	selected using a regex, and rows using a range.  When
+	splitting by module, one output file is written for
+	each module (e.g. "out.csv" becomes "out.mod.csv").`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 2 {
+			fmt.Println(cmd.UsageString())
+			os.Exit(1)
+		}
+		//
+		cols := readTraceFile(args[0], nil)
+		start := GetUint(cmd, "start")
+		end := GetUint(cmd, "end")
+		filter := GetString(cmd, "filter")
+		split := GetFlag(cmd, "split-modules")
+		ltVersion := GetUint(cmd, "lt-version")
+		//
+		if filter != "" {
+			cols = filterColumns(cols, filter)
+		}
+		//
+		if start != 0 || end != math.MaxUint {
+			sliceColumns(cols, start, end)
+		}
+		//
+		if !split {
+			writeTraceFile(args[1], cols, ltVersion)
+			return
+		}
+		// Write one file per module
+		for _, mod := range splitModules(cols) {
+			writeTraceFile(moduleFileName(args[1], mod[0].Module), mod, ltVersion)
+		}
+	},
+}
+
+// traceDiffCmd represents the trace diff command for comparing traces.
+var traceDiffCmd = &cobra.Command{
+	Use:   "diff [flags] old_trace_file new_trace_file",
+	Short: "Compare two trace files column-by-column.",
+	Long: `Compare two trace files column-by-column, reporting
+	those columns which were added or removed, along with
+	those whose height or contents differ.  For the latter,
+	the first few differing rows are reported and, optionally,
+	the rows around each can be printed side-by-side.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 2 {
+			fmt.Println(cmd.UsageString())
+			os.Exit(1)
+		}
+		//
+		oldCols := readTraceFile(args[0], nil)
+		newCols := readTraceFile(args[1], nil)
+		filter := GetString(cmd, "filter")
+		limit := GetUint(cmd, "max-rows")
+		print := GetFlag(cmd, "print")
+		padding := GetUint(cmd, "padding")
+		maxWidth := GetUint(cmd, "max-width")
+		ansiEscapes := GetFlag(cmd, "ansi-escapes")
+		//
+		if filter != "" {
+			oldCols = filterColumns(oldCols, filter)
+			newCols = filterColumns(newCols, filter)
+		}
+		//
+		diffs := trace.DiffColumns(oldCols, newCols, limit)
+		//
+		for _, diff := range diffs {
+			reportColumnDiff(diff)
+			//
+			if print && diff.Kind == trace.DIFF_CHANGED {
+				printColumnDiff(diff, padding, maxWidth, ansiEscapes)
+			}
+		}
+		// Following diff, exit with non-zero status when differences found.
+		if len(diffs) > 0 {
+			os.Exit(1)
 		}
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(traceCmd)
+	traceCmd.AddCommand(traceConvertCmd)
+	traceCmd.AddCommand(traceDiffCmd)
+	traceDiffCmd.Flags().StringP("filter", "f", "", "Filter columns matching regex")
+	traceDiffCmd.Flags().Uint("max-rows", 10, "maximum number of differing rows to report per column")
+	traceDiffCmd.Flags().BoolP("print", "p", false, "print rows around each difference side-by-side")
+	traceDiffCmd.Flags().Uint("padding", 2, "specify number of rows to show either side of each difference")
+	traceDiffCmd.Flags().Uint("max-width", 32, "specify maximum display width for a column")
+	traceDiffCmd.Flags().Bool("ansi-escapes", true, "specify whether to allow ANSI escapes or not (e.g. for colour)")
+	traceConvertCmd.Flags().Uint("start", 0, "filter out rows below this")
+	traceConvertCmd.Flags().Uint("end", math.MaxUint, "filter out this and all following rows")
+	traceConvertCmd.Flags().StringP("filter", "f", "", "Filter columns matching regex")
+	traceConvertCmd.Flags().Bool("split-modules", false, "write one output file per module")
+	traceConvertCmd.Flags().Uint("lt-version", 1, "specify version of lt format to write (1 or 2)")
 	traceCmd.Flags().BoolP("list", "l", false, "list only the columns in the trace file")
 	traceCmd.Flags().StringArrayP("include", "i", []string{"lines", "bitwidth", "bytes", "elements"},
 		fmt.Sprintf("specify information to include in column listing: %s", summariserOptions()))
@@ -70,6 +183,7 @@ func init() {
 	traceCmd.Flags().Uint("end", math.MaxUint, "filter out this and all following rows")
 	traceCmd.Flags().Uint("max-width", 32, "specify maximum display width for a column")
 	traceCmd.Flags().StringP("out", "o", "", "Specify output file to write trace")
+	traceCmd.Flags().Uint("lt-version", 1, "specify version of lt format to write (1 or 2)")
 	traceCmd.Flags().StringP("filter", "f", "", "Filter columns matching regex")
 }
 
@@ -110,7 +224,139 @@ func sliceColumns(cols []trace.RawColumn, start uint, end uint) {
 	}
 }
 
-func printTrace(start uint, max_width uint, cols []trace.RawColumn) {
+// Report a single column difference in a human-readable form.
+func reportColumnDiff(diff trace.ColumnDiff) {
+	switch diff.Kind {
+	case trace.DIFF_ADDED:
+		fmt.Printf("+ %s (added, %d rows)\n", diff.Name(), diff.New.Data.Len())
+	case trace.DIFF_REMOVED:
+		fmt.Printf("- %s (removed, %d rows)\n", diff.Name(), diff.Old.Data.Len())
+	default:
+		var changes []string
+		//
+		if diff.HeightChanged() {
+			changes = append(changes, fmt.Sprintf("height %d => %d", diff.Old.Data.Len(), diff.New.Data.Len()))
+		}
+		//
+		if diff.Count > 0 {
+			rows := make([]string, len(diff.Rows))
+			//
+			for i, row := range diff.Rows {
+				rows[i] = fmt.Sprintf("%d", row)
+			}
+			//
+			if diff.Count > uint(len(diff.Rows)) {
+				rows = append(rows, "...")
+			}
+			//
+			changes = append(changes, fmt.Sprintf("%d differing rows (%s)", diff.Count, strings.Join(rows, ", ")))
+		}
+		//
+		fmt.Printf("~ %s: %s\n", diff.Name(), strings.Join(changes, "; "))
+	}
+}
+
+// Print the differing rows of a column side-by-side, where differing cells are
+// highlighted.  A padded window is printed around each reported differing row
+// (unless that row is already shown in the previous window) or, if there are
+// none, around the first row where the heights differ.  Since the number of
+// reported rows is limited, so too is the output.
+func printColumnDiff(diff trace.ColumnDiff, padding uint, maxWidth uint, ansiEscapes bool) {
+	var (
+		height  = min(diff.Old.Data.Len(), diff.New.Data.Len())
+		rows    = make(map[uint]bool)
+		anchors []uint
+	)
+	//
+	for _, row := range diff.Rows {
+		rows[row] = true
+		// Skip rows already shown in the previous window
+		if len(anchors) == 0 || row > anchors[len(anchors)-1]+padding {
+			anchors = append(anchors, row)
+		}
+	}
+	//
+	if len(anchors) == 0 {
+		anchors = append(anchors, height)
+	}
+	// Construct a trace containing old and new columns
+	modules := []trace.ArrayModule{trace.EmptyArrayModule("old"), trace.EmptyArrayModule("new")}
+	columns := []trace.ArrayColumn{
+		trace.EmptyArrayColumn(trace.NewContext[uint](0, 1), fmt.Sprintf("%s (old)", diff.Name())),
+		trace.EmptyArrayColumn(trace.NewContext[uint](1, 1), fmt.Sprintf("%s (new)", diff.Name())),
+	}
+	tr := trace.NewArrayTrace(modules, columns)
+	tr.FillColumn(0, diff.Old.Data, fr.NewElement(0))
+	tr.FillColumn(1, diff.New.Data, fr.NewElement(0))
+	//
+	for _, anchor := range anchors {
+		// Configure printer
+		tp := trace.NewPrinter().Start(anchor).Padding(padding).MaxCellWidth(maxWidth).AnsiEscapes(ansiEscapes)
+		// Highlight differing cells, including those beyond the end of either
+		// column.
+		tp = tp.Highlight(func(cell trace.CellRef, tr trace.Trace) bool {
+			row := uint(cell.Row)
+			return rows[row] || row >= height
+		})
+		//
+		tp.Print(tr)
+		fmt.Println()
+	}
+}
+
+// Group columns by their enclosing module, preserving the order in which modules
+// (and columns) first appear.
+func splitModules(cols []trace.RawColumn) [][]trace.RawColumn {
+	var (
+		modules [][]trace.RawColumn
+		indices = make(map[string]int)
+	)
+	//
+	for _, col := range cols {
+		index, ok := indices[col.Module]
+		//
+		if !ok {
+			index = len(modules)
+			indices[col.Module] = index
+			modules = append(modules, nil)
+		}
+		//
+		modules[index] = append(modules[index], col)
+	}
+	//
+	return modules
+}
+
+// Determine the name of the file to which a given module is written, by
+// inserting the module name before the file's extension (e.g. "out.csv" becomes
+// "out.mod.csv", or "out.lt.gz" becomes "out.mod.lt.gz").  The root module is
+// written to the original file.
+func moduleFileName(filename string, module string) string {
+	if module == "" {
+		return filename
+	}
+	//
+	basename, _ := util.SplitCompressionExt(filename)
+	ext := path.Ext(basename)
+	//
+	return fmt.Sprintf("%s.%s%s%s", strings.TrimSuffix(basename, ext), module, ext, filename[len(basename):])
+}
+
+// Construct a mapping from the qualified names of all columns in the given
+// schema to their corresponding display modes.
+func columnDisplayMap(schema *hir.Schema) map[string]trace.Display {
+	displays := make(map[string]trace.Display)
+	//
+	for iter := schema.Columns(); iter.HasNext(); {
+		col := iter.Next()
+		mod := schema.Modules().Nth(col.Context.Module())
+		displays[trace.QualifiedColumnName(mod.Name, col.Name)] = col.Display
+	}
+	//
+	return displays
+}
+
+func printTrace(start uint, max_width uint, displays map[string]trace.Display, cols []trace.RawColumn) {
 	n := uint(len(cols))
 	height := maxHeightColumns(cols)
 	tbl := util.NewTablePrinter(1+height, 1+n)
@@ -121,12 +367,23 @@ func printTrace(start uint, max_width uint, cols []trace.RawColumn) {
 
 	for i := uint(0); i < n; i++ {
 		ith := cols[i].Data
-		tbl.Set(0, i+1, cols[i].QualifiedName())
+		name := cols[i].QualifiedName()
+		// Determine display mode.  Columns using the default display (e.g.
+		// because they have no display attribute) are rendered in plain
+		// hexadecimal, as before display attributes were supported.
+		display, ok := displays[name]
+		plain := !ok || display == trace.NewDisplay(trace.DISPLAY_HEX)
+		//
+		tbl.Set(0, i+1, name)
 
 		for j := uint(0); j < ith.Len(); j++ {
 			jth := ith.Get(j)
 
-			tbl.Set(j+1, i+1, jth.Text(16))
+			if plain {
+				tbl.Set(j+1, i+1, jth.Text(16))
+			} else {
+				tbl.Set(j+1, i+1, display.Format(jth))
+			}
 		}
 	}
 	//
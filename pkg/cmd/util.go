@@ -6,6 +6,7 @@ import (
 	"encoding/gob"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -14,9 +15,13 @@ import (
 	"github.com/consensys/go-corset/pkg/binfile"
 	"github.com/consensys/go-corset/pkg/corset"
 	"github.com/consensys/go-corset/pkg/hir"
+	sc "github.com/consensys/go-corset/pkg/schema"
 	"github.com/consensys/go-corset/pkg/trace"
+	"github.com/consensys/go-corset/pkg/trace/csv"
 	"github.com/consensys/go-corset/pkg/trace/json"
 	"github.com/consensys/go-corset/pkg/trace/lt"
+	"github.com/consensys/go-corset/pkg/tracefile"
+	"github.com/consensys/go-corset/pkg/util"
 	"github.com/consensys/go-corset/pkg/util/sexp"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -77,67 +82,108 @@ func GetStringArray(cmd *cobra.Command, flag string) []string {
 	return r
 }
 
-// Write a given trace file to disk
-func writeTraceFile(filename string, columns []trace.RawColumn) {
-	var err error
-
-	var bytes []byte
+// Write a given trace file to disk.  The trace format is determined by the file
+// extension, which can be followed by an extension indicating the trace should
+// be compressed (e.g. "trace.lt.gz").  For lt files, the version of the format
+// to use is also given (where the original v1 format is the default).
+func writeTraceFile(filename string, columns []trace.RawColumn, ltVersion uint) {
+	var (
+		err   error
+		bytes []byte
+	)
 	// Check file extension
-	ext := path.Ext(filename)
+	basename, compression := util.SplitCompressionExt(filename)
+	ext := path.Ext(basename)
 	//
 	switch ext {
+	case ".csv":
+		bytes, err = csv.ToBytes(columns)
 	case ".json":
-		js := json.ToJsonString(columns)
-		//
-		if err = os.WriteFile(filename, []byte(js), 0644); err == nil {
-			return
-		}
+		bytes = []byte(json.ToJsonString(columns))
 	case ".lt":
-		bytes, err = lt.ToBytes(columns)
-		//
-		if err == nil {
-			if err = os.WriteFile(filename, bytes, 0644); err == nil {
-				return
-			}
-		}
+		bytes, err = ltToBytes(columns, ltVersion)
 	default:
 		err = fmt.Errorf("Unknown trace file format: %s", ext)
 	}
+	// Compress (if applicable)
+	if err == nil {
+		bytes, err = util.Compress(bytes, compression)
+	}
+	// Write file
+	if err == nil {
+		if err = os.WriteFile(filename, bytes, 0644); err == nil {
+			return
+		}
+	}
 	// Handle error
 	fmt.Println(err)
 	os.Exit(4)
 }
 
-// Parse a trace file using a parser based on the extension of the filename.
-func readTraceFile(filename string) []trace.RawColumn {
-	var tr []trace.RawColumn
-	// Read data file
-	bytes, err := os.ReadFile(filename)
-	// Check success
-	if err == nil {
-		// Check file extension
-		ext := path.Ext(filename)
-		//
-		switch ext {
-		case ".json":
-			tr, err = json.FromBytes(bytes)
-			if err == nil {
-				return tr
-			}
-		case ".lt":
-			tr, err = lt.FromBytes(bytes)
-			if err == nil {
-				return tr
-			}
-		default:
-			err = fmt.Errorf("Unknown trace file format: %s", ext)
+// Encode a given set of columns using a given version of the lt format.
+func ltToBytes(columns []trace.RawColumn, version uint) ([]byte, error) {
+	var buf bytes.Buffer
+	//
+	switch version {
+	case 1:
+		if err := lt.WriteBytesV1(columns, &buf); err != nil {
+			return nil, err
 		}
+	case 2:
+		if err := lt.WriteBytesV2(columns, false, &buf); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown lt format version %d", version)
 	}
+	//
+	return buf.Bytes(), nil
+}
+
+// Parse a trace file using a parser based on the extension of the filename.
+// Compressed trace files are identified by either their extension (e.g.
+// "trace.lt.gz") or their magic bytes, and are transparently decompressed.
+// Furthermore, an optional filter can be given to identify which columns should
+// be loaded (where nil indicates all columns are loaded).
+func readTraceFile(filename string, filter lt.ColumnFilter) []trace.RawColumn {
+	tr, err := tracefile.Read(filename, filter)
 	// Handle error
-	fmt.Println(err)
-	os.Exit(2)
-	// unreachable
-	return nil
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	//
+	return tr
+}
+
+// Open a trace file, such that column data is read only when it is first
+// required (where possible).  Hence, the returned closer should be closed only
+// once the columns are no longer required.
+func openTraceFile(filename string, filter lt.ColumnFilter) ([]trace.RawColumn, io.Closer) {
+	tr, closer, err := tracefile.Open(filename, filter)
+	// Handle error
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	//
+	return tr, closer
+}
+
+// Construct a filter which accepts only those columns which are input columns
+// of the given schema.
+func schemaColumnFilter(schema sc.Schema) lt.ColumnFilter {
+	names := make(map[string]bool)
+	//
+	for iter := schema.InputColumns(); iter.HasNext(); {
+		col := iter.Next()
+		mod := schema.Modules().Nth(col.Context.Module())
+		names[trace.QualifiedColumnName(mod.Name, col.Name)] = true
+	}
+	//
+	return func(module string, column string) bool {
+		return names[trace.QualifiedColumnName(module, column)]
+	}
 }
 
 // Read the constraints file, whilst optionally including the standard library.
@@ -373,7 +419,7 @@ func (p *BinaryFile) isCompatible() bool {
 // matter what version, we should always have the ZKBINARY identifier first,
 // followed by a GOB encoding of the header.  What follows after that, however,
 // is determined by the major version.
-const BINFILE_MAJOR_VERSION uint16 = 1
+const BINFILE_MAJOR_VERSION uint16 = 3
 
 // BINFILE_MINOR_VERSION gives the minor version of the binary file format.  The
 // expected interpretation is that older versions are compatible with newer
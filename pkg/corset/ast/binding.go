@@ -160,6 +160,9 @@ type ColumnBinding struct {
 	Multiplier uint
 	// Determines whether this is a Computed column, or not.
 	Computed bool
+	// Determines how values of this column should be rendered (e.g. when
+	// printing a trace).
+	Display tr.Display
 }
 
 // AbsolutePath returns the fully resolved (absolute) path of the column in question.
@@ -282,6 +285,11 @@ func (p *OverloadedBinding) IsFinalised() bool {
 	return true
 }
 
+// Overloads returns the specialisations available for this function.
+func (p *OverloadedBinding) Overloads() []*DefunBinding {
+	return p.overloads
+}
+
 // HasArity checks whether this function accepts a given number of arguments (or
 // not).
 func (p *OverloadedBinding) HasArity(arity uint) bool {
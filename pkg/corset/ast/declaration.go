@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	tr "github.com/consensys/go-corset/pkg/trace"
 	"github.com/consensys/go-corset/pkg/util"
 	"github.com/consensys/go-corset/pkg/util/sexp"
 )
@@ -208,8 +209,8 @@ var _ SymbolDefinition = &DefColumn{}
 // column is automatically finalised, since all information is provided at the
 // point of creation.
 func NewDefColumn(context util.Path, name util.Path, datatype Type, mustProve bool, multiplier uint,
-	computed bool) *DefColumn {
-	binding := ColumnBinding{context, name, datatype, mustProve, multiplier, computed}
+	computed bool, display tr.Display) *DefColumn {
+	binding := ColumnBinding{context, name, datatype, mustProve, multiplier, computed, display}
 	return &DefColumn{binding}
 }
 
@@ -217,7 +218,7 @@ func NewDefColumn(context util.Path, name util.Path, datatype Type, mustProve bo
 // column.  Such a column cannot be finalised yet, since its type and multiplier
 // remains to be determined, etc.
 func NewDefComputedColumn(context util.Path, name util.Path) *DefColumn {
-	binding := ColumnBinding{context, name, nil, false, 0, true}
+	binding := ColumnBinding{context, name, nil, false, 0, true, tr.NewDisplay(tr.DISPLAY_HEX)}
 	return &DefColumn{binding}
 }
 
@@ -276,6 +277,12 @@ func (e *DefColumn) MustProve() bool {
 	return e.binding.MustProve
 }
 
+// Display returns the display mode of this column, which determines how its
+// values should be rendered (e.g. when printing a trace).
+func (e *DefColumn) Display() tr.Display {
+	return e.binding.Display
+}
+
 // Lisp converts this node into its lisp representation.  This is primarily used
 // for debugging purposes.
 func (e *DefColumn) Lisp() sexp.SExp {
@@ -296,6 +303,11 @@ func (e *DefColumn) Lisp() sexp.SExp {
 		list.Append(sexp.NewSymbol(fmt.Sprintf("%d", e.binding.Multiplier)))
 	}
 	//
+	if e.binding.Display.Kind != tr.DISPLAY_HEX {
+		list.Append(sexp.NewSymbol(":display"))
+		list.Append(sexp.NewSymbol(fmt.Sprintf(":%s", e.binding.Display.String())))
+	}
+	//
 	if list.Len() == 1 {
 		return list.Get(0)
 	}
@@ -382,6 +394,54 @@ func (p *DefComputed) Lisp() sexp.SExp {
 		sexp.NewList(sources)})
 }
 
+// ============================================================================
+// defcomputedcolumn
+// ============================================================================
+
+// DefComputedColumn is an assignment which computes the values for a single
+// column based on an arbitrary expression over other columns.  For example,
+// (defcomputedcolumn (Z :i32) (+ X Y)) defines a column Z whose value on each
+// row is the sum of columns X and Y on that row.  A constraint is generated
+// automatically to enforce this (i.e. Z - (X + Y) == 0).
+type DefComputedColumn struct {
+	// Column being assigned by this computation
+	Target *DefColumn
+	// Expression determining the value of the target column on each row.
+	Computation Expr
+}
+
+// Definitions returns the set of symbols defined by this declaration.  Observe
+// that these may not yet have been finalised.
+func (p *DefComputedColumn) Definitions() util.Iterator[SymbolDefinition] {
+	return util.NewUnitIterator[SymbolDefinition](p.Target)
+}
+
+// Dependencies needed to signal declaration.
+func (p *DefComputedColumn) Dependencies() util.Iterator[Symbol] {
+	return util.NewArrayIterator(p.Computation.Dependencies())
+}
+
+// Defines checks whether this declaration defines the given symbol.  The symbol
+// in question needs to have been resolved already for this to make sense.
+func (p *DefComputedColumn) Defines(symbol Symbol) bool {
+	return &p.Target.binding == symbol.Binding()
+}
+
+// IsFinalised checks whether this declaration has already been finalised.  If
+// so, then we don't need to finalise it again.
+func (p *DefComputedColumn) IsFinalised() bool {
+	return p.Target.binding.IsFinalised()
+}
+
+// Lisp converts this node into its lisp representation.  This is primarily used
+// for debugging purposes.
+func (p *DefComputedColumn) Lisp() sexp.SExp {
+	return sexp.NewList([]sexp.SExp{
+		sexp.NewSymbol("defcomputedcolumn"),
+		p.Target.Lisp(),
+		p.Computation.Lisp()})
+}
+
 // ============================================================================
 // defconst
 // ============================================================================
@@ -393,6 +453,13 @@ type DefConst struct {
 	// be constant (i.e. it cannot refer to column values or call impure
 	// functions, etc).
 	Constants []*DefConstUnit
+	// Indicates whether these constants bind the parameters of a module
+	// template instance.  If so, their values are resolved in the scope where
+	// the module is instantiated, rather than in the module itself.
+	Parameters bool
+	// Indicates whether these constants are the members of an enumeration.
+	// Such constants are typically used only for displaying values.
+	Enumeration bool
 }
 
 // Definitions returns the set of symbols defined by this declaration.  Observe
@@ -491,6 +558,63 @@ func (e *DefConstUnit) Lisp() sexp.SExp {
 		e.ConstBinding.Value.Lisp()})
 }
 
+// ============================================================================
+// defconstassert
+// ============================================================================
+
+// DefConstAssert represents an assertion over constants which is checked at
+// compile time.  For example, this can be used to check that the values of two
+// constants are distinct, or that a set of bitwidths sum to a given total.  The
+// assertion is expected to evaluate to a constant, and holds when this is
+// non-zero.  Unlike other declarations, nothing is generated for an assertion.
+type DefConstAssert struct {
+	// The assertion itself, which should evaluate to a non-zero constant.
+	Assertion Expr
+	// Indicates whether or not the assertion has been resolved.
+	finalised bool
+}
+
+// NewDefConstAssert constructs a new (unfinalised) constant assertion.
+func NewDefConstAssert(assertion Expr) *DefConstAssert {
+	return &DefConstAssert{assertion, false}
+}
+
+// Definitions returns the set of symbols defined by this declaration.  Observe that
+// these may not yet have been finalised.
+func (p *DefConstAssert) Definitions() util.Iterator[SymbolDefinition] {
+	return util.NewArrayIterator[SymbolDefinition](nil)
+}
+
+// Dependencies needed to signal declaration.
+func (p *DefConstAssert) Dependencies() util.Iterator[Symbol] {
+	return util.NewArrayIterator(p.Assertion.Dependencies())
+}
+
+// Defines checks whether this declaration defines the given symbol.  The symbol
+// in question needs to have been resolved already for this to make sense.
+func (p *DefConstAssert) Defines(symbol Symbol) bool {
+	return false
+}
+
+// IsFinalised checks whether this declaration has already been finalised.  If
+// so, then we don't need to finalise it again.
+func (p *DefConstAssert) IsFinalised() bool {
+	return p.finalised
+}
+
+// Finalise this assertion, meaning that its expression has been resolved.
+func (p *DefConstAssert) Finalise() {
+	p.finalised = true
+}
+
+// Lisp converts this node into its lisp representation.  This is primarily used
+// for debugging purposes.
+func (p *DefConstAssert) Lisp() sexp.SExp {
+	return sexp.NewList([]sexp.SExp{
+		sexp.NewSymbol("defconstassert"),
+		p.Assertion.Lisp()})
+}
+
 // ============================================================================
 // defconstraint
 // ============================================================================
@@ -510,10 +634,10 @@ type DefConstraint struct {
 	// debugging (i.e. so we know which constaint failed, etc).
 	Handle string
 	// Domain of this constraint which, if empty, indicates a global constraint.
-	// Otherwise, a given value indicates a single row on which this constraint
+	// Otherwise, the given values indicate the rows on which this constraint
 	// should apply (where negative values are taken from the end, meaning that
 	// -1 represents the last row of a given module).
-	Domain util.Option[int]
+	Domain util.Option[[]int]
 	// A selector which determines for which rows this constraint is active.
 	// Specifically, when the expression evaluates to a non-zero value then the
 	// constraint is active; otherwiser, its inactive. Nil is permitted to
@@ -530,7 +654,7 @@ type DefConstraint struct {
 }
 
 // NewDefConstraint constructs a new (unfinalised) constraint.
-func NewDefConstraint(handle string, domain util.Option[int], guard Expr, perspective *PerspectiveName,
+func NewDefConstraint(handle string, domain util.Option[[]int], guard Expr, perspective *PerspectiveName,
 	constraint Expr) *DefConstraint {
 	return &DefConstraint{handle, domain, guard, perspective, constraint, false}
 }
@@ -582,10 +706,15 @@ func (p *DefConstraint) Lisp() sexp.SExp {
 	modifiers := sexp.EmptyList()
 	// domain
 	if p.Domain.HasValue() {
-		domain := fmt.Sprintf("{%d}", p.Domain.Unwrap())
+		rows := p.Domain.Unwrap()
+		domain := make([]sexp.SExp, len(rows))
+		//
+		for i, row := range rows {
+			domain[i] = sexp.NewSymbol(fmt.Sprintf("%d", row))
+		}
 		//
 		modifiers.Append(sexp.NewSymbol(":domain"))
-		modifiers.Append(sexp.NewSymbol(domain))
+		modifiers.Append(sexp.NewSet(domain))
 	}
 	//
 	if p.Guard != nil {
@@ -658,6 +787,61 @@ func (p *DefInRange) Lisp() sexp.SExp {
 	})
 }
 
+// ============================================================================
+// in-enum
+// ============================================================================
+
+// DefInEnum restricts all values for a given expression to be members of a
+// given enumeration.  When the values of the enumeration are contiguous, this
+// is implemented as a range constraint.  Otherwise, it is implemented as a
+// lookup into a fixed table holding the members of the enumeration.
+type DefInEnum struct {
+	// The enumeration whose members the expression is constrained to.
+	Enum *tr.Enumeration
+	// The expression whose values are being constrained.
+	Expr Expr
+	// Indicates whether or not the expression has been resolved.
+	finalised bool
+}
+
+// Definitions returns the set of symbols defined by this declaration.  Observe
+// that these may not yet have been finalised.
+func (p *DefInEnum) Definitions() util.Iterator[SymbolDefinition] {
+	return util.NewArrayIterator[SymbolDefinition](nil)
+}
+
+// Dependencies needed to signal declaration.
+func (p *DefInEnum) Dependencies() util.Iterator[Symbol] {
+	return util.NewArrayIterator[Symbol](p.Expr.Dependencies())
+}
+
+// Defines checks whether this declaration defines the given symbol.  The symbol
+// in question needs to have been resolved already for this to make sense.
+func (p *DefInEnum) Defines(symbol Symbol) bool {
+	return false
+}
+
+// IsFinalised checks whether this declaration has already been finalised.  If
+// so, then we don't need to finalise it again.
+func (p *DefInEnum) IsFinalised() bool {
+	return p.finalised
+}
+
+// Finalise this declaration, meaning that the expression has been resolved.
+func (p *DefInEnum) Finalise() {
+	p.finalised = true
+}
+
+// Lisp converts this node into its lisp representation.  This is primarily used
+// for debugging purposes.
+func (p *DefInEnum) Lisp() sexp.SExp {
+	return sexp.NewList([]sexp.SExp{
+		sexp.NewSymbol("in-enum"),
+		sexp.NewSymbol(p.Enum.Name),
+		p.Expr.Lisp(),
+	})
+}
+
 // ============================================================================
 // definterleaved
 // ============================================================================
@@ -744,13 +928,22 @@ type DefLookup struct {
 	// Target expressions for lookup (i.e. these values must contain all of the
 	// source values, but may contain more).
 	Targets []Expr
+	// Optional selector for sources.  When present, only those source rows
+	// where the selector is non-zero must be contained within the targets.
+	SourceSelector Expr
+	// Optional selector for targets.  When present, only those target rows
+	// where the selector is non-zero are considered part of the target set.
+	TargetSelector Expr
 	// Indicates whether or not target and source expressions have been resolved.
 	finalised bool
 }
 
-// NewDefLookup creates a new (unfinalised) lookup constraint.
-func NewDefLookup(handle string, sources []Expr, targets []Expr) *DefLookup {
-	return &DefLookup{handle, sources, targets, false}
+// NewDefLookup creates a new (unfinalised) lookup constraint.  Either selector
+// can be nil, indicating that all rows of the corresponding expressions are
+// considered.
+func NewDefLookup(handle string, sourceSelector Expr, sources []Expr, targetSelector Expr,
+	targets []Expr) *DefLookup {
+	return &DefLookup{handle, sources, targets, sourceSelector, targetSelector, false}
 }
 
 // Definitions returns the set of symbols defined by this declaration.  Observe
@@ -763,8 +956,11 @@ func (p *DefLookup) Definitions() util.Iterator[SymbolDefinition] {
 func (p *DefLookup) Dependencies() util.Iterator[Symbol] {
 	sourceDeps := DependenciesOfExpressions(p.Sources)
 	targetDeps := DependenciesOfExpressions(p.Targets)
+	selectorDeps := DependenciesOfExpressions([]Expr{p.SourceSelector, p.TargetSelector})
 	// Combine deps
-	return util.NewArrayIterator(append(sourceDeps, targetDeps...))
+	deps := append(sourceDeps, targetDeps...)
+	//
+	return util.NewArrayIterator(append(deps, selectorDeps...))
 }
 
 // Defines checks whether this declaration defines the given symbol.  The symbol
@@ -799,12 +995,25 @@ func (p *DefLookup) Lisp() sexp.SExp {
 		sources[i] = t.Lisp()
 	}
 	//
-	return sexp.NewList([]sexp.SExp{
+	list := sexp.NewList([]sexp.SExp{
 		sexp.NewSymbol("deflookup"),
 		sexp.NewSymbol(p.Handle),
-		sexp.NewList(targets),
-		sexp.NewList(sources),
 	})
+	// Selectors (if applicable)
+	if p.SourceSelector != nil {
+		list.Append(sexp.NewSymbol(":guard"))
+		list.Append(p.SourceSelector.Lisp())
+	}
+	//
+	if p.TargetSelector != nil {
+		list.Append(sexp.NewSymbol(":target-guard"))
+		list.Append(p.TargetSelector.Lisp())
+	}
+	//
+	list.Append(sexp.NewList(targets))
+	list.Append(sexp.NewList(sources))
+	//
+	return list
 }
 
 // ============================================================================
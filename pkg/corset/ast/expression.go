@@ -211,6 +211,153 @@ func (e *Constant) Dependencies() []Symbol {
 	return nil
 }
 
+// ============================================================================
+// Constant Operations
+// ============================================================================
+
+// CONST_DIV represents integer division (rounding towards zero).
+const CONST_DIV uint8 = 0
+
+// CONST_REM represents the remainder of integer division (whose sign matches
+// that of the dividend).
+const CONST_REM uint8 = 1
+
+// CONST_SHL represents a left shift.
+const CONST_SHL uint8 = 2
+
+// CONST_SHR represents an (arithmetic) right shift.
+const CONST_SHR uint8 = 3
+
+// CONST_BAND represents bitwise and.
+const CONST_BAND uint8 = 4
+
+// CONST_BOR represents bitwise or.
+const CONST_BOR uint8 = 5
+
+// CONST_BXOR represents bitwise exclusive or.
+const CONST_BXOR uint8 = 6
+
+// MAX_CONST_SHIFT is the largest shift supported by a constant operation.  This
+// is intended only to prevent excessive computation, and is well above the
+// size of any field element.
+const MAX_CONST_SHIFT = 65536
+
+// ConstOp represents an integer operation (e.g. division, bitwise and, etc)
+// which has no direct counterpart at the constraint level and, hence, is only
+// permitted on constant arguments.  The shift and division operations are
+// binary, whilst the bitwise operations are n-ary.
+type ConstOp struct {
+	Kind uint8
+	Args []Expr
+}
+
+// AsConstant attempts to evaluate this expression as a constant (signed) value.
+// If this expression is not constant, or is undefined (e.g. division by zero),
+// then nil is returned.
+func (e *ConstOp) AsConstant() *big.Int {
+	var (
+		undefined bool
+		val       *big.Int
+	)
+	//
+	switch e.Kind {
+	case CONST_DIV, CONST_REM:
+		val = AsConstantOfExpressions(e.Args, func(l *big.Int, r *big.Int) {
+			if r.Sign() == 0 {
+				undefined = true
+			} else if e.Kind == CONST_DIV {
+				l.Quo(l, r)
+			} else {
+				l.Rem(l, r)
+			}
+		})
+	case CONST_SHL, CONST_SHR:
+		val = AsConstantOfExpressions(e.Args, func(l *big.Int, r *big.Int) {
+			if r.Sign() < 0 || r.Cmp(big.NewInt(MAX_CONST_SHIFT)) > 0 {
+				undefined = true
+			} else if e.Kind == CONST_SHL {
+				l.Lsh(l, uint(r.Uint64()))
+			} else {
+				l.Rsh(l, uint(r.Uint64()))
+			}
+		})
+	case CONST_BAND:
+		val = AsConstantOfExpressions(e.Args, func(l *big.Int, r *big.Int) { l.And(l, r) })
+	case CONST_BOR:
+		val = AsConstantOfExpressions(e.Args, func(l *big.Int, r *big.Int) { l.Or(l, r) })
+	case CONST_BXOR:
+		val = AsConstantOfExpressions(e.Args, func(l *big.Int, r *big.Int) { l.Xor(l, r) })
+	default:
+		panic(fmt.Sprintf("unknown constant operation (%d)", e.Kind))
+	}
+	//
+	if undefined {
+		return nil
+	}
+	//
+	return val
+}
+
+// IsUndefined checks whether this operation is undefined for its (constant)
+// arguments, such as for a division by zero.  Observe that this returns false
+// when one or more arguments are not constant.
+func (e *ConstOp) IsUndefined() bool {
+	for _, arg := range e.Args {
+		if arg.AsConstant() == nil {
+			return false
+		}
+	}
+	//
+	return e.AsConstant() == nil
+}
+
+// Multiplicity determines the number of values that evaluating this expression
+// can generate.
+func (e *ConstOp) Multiplicity() uint {
+	return determineMultiplicity(e.Args)
+}
+
+// Context returns the context for this expression.  Observe that the
+// expression must have been resolved for this to be defined (i.e. it may
+// panic if it has not been resolved yet).
+func (e *ConstOp) Context() Context {
+	return ContextOfExpressions(e.Args)
+}
+
+// Lisp converts this schema element into a simple S-Expression, for example
+// so it can be printed.
+func (e *ConstOp) Lisp() sexp.SExp {
+	return ListOfExpressions(sexp.NewSymbol(ConstOpName(e.Kind)), e.Args)
+}
+
+// Dependencies needed to signal declaration.
+func (e *ConstOp) Dependencies() []Symbol {
+	return DependenciesOfExpressions(e.Args)
+}
+
+// ConstOpName returns the name of a given kind of constant operation, as used
+// in source files (e.g. "shl").
+func ConstOpName(kind uint8) string {
+	switch kind {
+	case CONST_DIV:
+		return "/"
+	case CONST_REM:
+		return "%"
+	case CONST_SHL:
+		return "shl"
+	case CONST_SHR:
+		return "shr"
+	case CONST_BAND:
+		return "band"
+	case CONST_BOR:
+		return "bor"
+	case CONST_BXOR:
+		return "bxor"
+	default:
+		panic(fmt.Sprintf("unknown constant operation (%d)", kind))
+	}
+}
+
 // ============================================================================
 // Normalise
 // ============================================================================
@@ -559,9 +706,11 @@ func NewLet(bindings []util.Pair[string, Expr], body Expr) *Let {
 }
 
 // AsConstant attempts to evaluate this expression as a constant (signed) value.
-// If this expression is not constant, then nil is returned.
+// If this expression is not constant, then nil is returned.  Observe that the
+// body is not considered constant if it refers to any of the variables defined
+// here.
 func (e *Let) AsConstant() *big.Int {
-	panic("todo")
+	return e.Body.AsConstant()
 }
 
 // Multiplicity determines the number of values that evaluating this expression
@@ -1029,6 +1178,9 @@ func Substitute(expr Expr, mapping map[uint]Expr, srcmap *sexp.SourceMaps[Node])
 		nexpr = &Add{args}
 	case *Constant:
 		return e
+	case *ConstOp:
+		args := SubstituteAll(e.Args, mapping, srcmap)
+		nexpr = &ConstOp{e.Kind, args}
 	case *Debug:
 		arg := Substitute(e.Arg, mapping, srcmap)
 		nexpr = &Debug{arg}
@@ -1129,6 +1281,8 @@ func ShallowCopy(expr Expr) Expr {
 		return &Add{e.Args}
 	case *Constant:
 		return &Constant{e.Val}
+	case *ConstOp:
+		return &ConstOp{e.Kind, e.Args}
 	case *Debug:
 		return &Debug{e.Arg}
 	case *Exp:
@@ -1139,6 +1293,8 @@ func ShallowCopy(expr Expr) Expr {
 		return &If{e.Kind, e.Condition, e.TrueBranch, e.FalseBranch}
 	case *Invoke:
 		return &Invoke{e.Name, e.Signature, e.Args}
+	case *Let:
+		return &Let{e.Vars, e.Args, e.Body}
 	case *List:
 		return &List{e.Args}
 	case *Mul:
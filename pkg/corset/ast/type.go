@@ -52,6 +52,12 @@ func NewUintType(nbits uint) Type {
 	return &NativeType{sc.NewUintType(nbits), false, false}
 }
 
+// NewIntType constructs a native (signed) int type of the given width which,
+// initially, has no semantic specified.
+func NewIntType(nbits uint) Type {
+	return &NativeType{sc.NewIntType(nbits), false, false}
+}
+
 // GreatestLowerBoundAll joins zero or more types together using the GLB
 // operator.
 func GreatestLowerBoundAll(types []Type) Type {
@@ -98,6 +98,10 @@ func (p *Compiler) Compile() (*hir.Schema, []SyntaxError) {
 	if len(res_errs) > 0 || len(type_errs) > 0 {
 		return nil, append(res_errs, type_errs...)
 	}
+	// Check constant assertions hold.
+	if errs := compiler.CheckConstantAssertions(p.srcmap, &p.circuit); len(errs) > 0 {
+		return nil, errs
+	}
 	// Preprocess circuit to remove invocations, reductions, etc.
 	if errs := compiler.PreprocessCircuit(p.debug, p.srcmap, &p.circuit); len(errs) > 0 {
 		return nil, errs
@@ -53,6 +53,21 @@ func (r *Register) IsInput() bool {
 	return !computed
 }
 
+// Display determines the display mode for this register.  When all source
+// columns agree on their display mode, then that is used.  Otherwise, the
+// default (hexadecimal) is used.
+func (r *Register) Display() tr.Display {
+	display := r.Sources[0].display
+	//
+	for _, ith := range r.Sources {
+		if ith.display != display {
+			return tr.NewDisplay(tr.DISPLAY_HEX)
+		}
+	}
+	//
+	return display
+}
+
 // Merge two registers together.  This means the source-level columns will be
 // allocated to the same underlying HIR column (i.e. register).
 func (r *Register) Merge(other *Register) {
@@ -129,6 +144,8 @@ type RegisterSource struct {
 	mustProve bool
 	// Determines whether this is a computed column.
 	computed bool
+	// Display mode of the source-level column.
+	display tr.Display
 }
 
 // IsVirtual indicates whether or not this is a "virtual" column.  That is,
@@ -237,14 +254,18 @@ func originalAllocator(allocation RegisterAllocation) {
 }
 
 func identicalType(lhs *RegisterGroup, rhs *RegisterGroup) bool {
-	lIntType := lhs.dataType.AsUint()
-	rIntType := rhs.dataType.AsUint()
-	// Check whether both are int types, or not.
-	if lIntType != nil && rIntType != nil {
+	lUintType := lhs.dataType.AsUint()
+	rUintType := rhs.dataType.AsUint()
+	lIntType := lhs.dataType.AsInt()
+	rIntType := rhs.dataType.AsInt()
+	// Check whether both are uint (or int) types, or not.
+	if lUintType != nil && rUintType != nil {
+		return lUintType.BitWidth() == rUintType.BitWidth()
+	} else if lIntType != nil && rIntType != nil {
 		return lIntType.BitWidth() == rIntType.BitWidth()
 	}
 	//
-	return lIntType == rIntType
+	return lUintType == rUintType && lIntType == rIntType
 }
 
 // Sort the registers into alphabetical order.
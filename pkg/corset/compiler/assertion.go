@@ -0,0 +1,39 @@
+package compiler
+
+import (
+	"github.com/consensys/go-corset/pkg/corset/ast"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+// CheckConstantAssertions evaluates all constant assertions (i.e.
+// "defconstassert" declarations) within a given circuit, reporting an error for
+// any which do not hold.  An assertion holds when it evaluates to a non-zero
+// constant.  This requires that the circuit has already been resolved, such
+// that the value of any constant referred to by an assertion is known.
+func CheckConstantAssertions(srcmap *sexp.SourceMaps[ast.Node], circuit *ast.Circuit) []SyntaxError {
+	errors := checkConstantAssertionsInModule(srcmap, circuit.Declarations)
+	// Check each module
+	for _, m := range circuit.Modules {
+		errs := checkConstantAssertionsInModule(srcmap, m.Declarations)
+		errors = append(errors, errs...)
+	}
+	// Done
+	return errors
+}
+
+// Check all constant assertions within a given set of declarations.
+func checkConstantAssertionsInModule(srcmap *sexp.SourceMaps[ast.Node], decls []ast.Declaration) []SyntaxError {
+	var errors []SyntaxError
+	//
+	for _, d := range decls {
+		if decl, ok := d.(*ast.DefConstAssert); ok {
+			if val := decl.Assertion.AsConstant(); val == nil {
+				errors = append(errors, *srcmap.SyntaxError(decl.Assertion, "expected constant assertion"))
+			} else if val.Sign() == 0 {
+				errors = append(errors, *srcmap.SyntaxError(decl.Assertion, "constant assertion failed"))
+			}
+		}
+	}
+	//
+	return errors
+}
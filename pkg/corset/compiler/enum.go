@@ -0,0 +1,150 @@
+package compiler
+
+import (
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/go-corset/pkg/corset/ast"
+	tr "github.com/consensys/go-corset/pkg/trace"
+	"github.com/consensys/go-corset/pkg/util"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+// enumeration represents an enumeration definition of the form "(defenum NAME
+// :type (MEMBER value) ...)".  The members of an enumeration are declared as
+// constants of the enclosing module.  Furthermore, the enumeration can be used
+// as the type of a column (e.g. "(X :NAME)"), in which case the column has the
+// underlying type of the enumeration and its values are rendered using the
+// member names.
+type enumeration struct {
+	// Underlying type of this enumeration.
+	datatype ast.Type
+	// Names and values of members of this enumeration.
+	values *tr.Enumeration
+}
+
+// Parse an enumeration definition, and register it with the parse environment.
+// This produces a constant declaration for the members of the enumeration.
+func (p *Parser) parseDefEnum(path util.Path, s *sexp.List) ([]ast.Declaration, []SyntaxError) {
+	var (
+		errors   []SyntaxError
+		datatype ast.Type = ast.NewFieldType()
+		members  []sexp.SExp
+		elements = []sexp.SExp{p.copyTerm(sexp.NewSymbol("defconst"), p.translator.SpanOf(s))}
+	)
+	//
+	if s.Len() < 3 {
+		return nil, p.translator.SyntaxErrors(s, "malformed enumeration")
+	} else if !isIdentifier(s.Get(1)) {
+		return nil, p.translator.SyntaxErrors(s.Get(1), "invalid enumeration name")
+	} else if _, ok := p.env.enums[s.Get(1).AsSymbol().Value]; ok {
+		return nil, p.translator.SyntaxErrors(s.Get(1), "enumeration already defined")
+	}
+	// Parse (optional) type
+	if members = s.Elements[2:]; s.Get(2).AsSymbol() != nil {
+		var err *SyntaxError
+		//
+		if datatype, _, err = p.parseType(s.Get(2)); err != nil {
+			return nil, []SyntaxError{*err}
+		}
+		//
+		members = s.Elements[3:]
+	}
+	//
+	enum := &tr.Enumeration{Name: s.Get(1).AsSymbol().Value}
+	// Parse members
+	for _, ith := range members {
+		if l := ith.AsList(); l == nil || l.Len() != 2 {
+			errors = append(errors, *p.translator.SyntaxError(ith, "malformed enumeration member"))
+		} else if !isIdentifier(l.Get(0)) {
+			errors = append(errors, *p.translator.SyntaxError(l.Get(0), "invalid enumeration member"))
+		} else if slices.Contains(enum.Members, l.Get(0).AsSymbol().Value) {
+			errors = append(errors, *p.translator.SyntaxError(l.Get(0), "duplicate enumeration member"))
+		} else if val, err := p.parseEnumValue(datatype, l.Get(1)); err != nil {
+			errors = append(errors, *err)
+		} else if _, ok := enum.NameOf(*val); ok {
+			errors = append(errors, *p.translator.SyntaxError(l.Get(1), "duplicate enumeration value"))
+		} else {
+			enum.Members = append(enum.Members, l.Get(0).AsSymbol().Value)
+			enum.Values = append(enum.Values, *val)
+			elements = append(elements, l.Get(0), l.Get(1))
+		}
+	}
+	//
+	if len(errors) == 0 && len(enum.Members) == 0 {
+		errors = append(errors, *p.translator.SyntaxError(s, "empty enumeration"))
+	}
+	//
+	if len(errors) > 0 {
+		return nil, errors
+	}
+	// Declare members as constants
+	decl, errs := p.parseDefConst(path, elements)
+	//
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	// Constants originate from this enumeration
+	decl.(*ast.DefConst).Enumeration = true
+	//
+	p.mapSourceNode(s, decl)
+	p.env.enums[enum.Name] = &enumeration{datatype, enum}
+	//
+	return []ast.Declaration{decl}, nil
+}
+
+// Parse the value of an enumeration member, which must be a numeric literal
+// accepted by the underlying type of the enumeration.
+func (p *Parser) parseEnumValue(datatype ast.Type, value sexp.SExp) (*fr.Element, *SyntaxError) {
+	var (
+		val    fr.Element
+		num    big.Int
+		symbol = value.AsSymbol()
+	)
+	//
+	if symbol == nil {
+		return nil, p.translator.SyntaxError(value, "invalid enumeration value")
+	} else if _, ok := num.SetString(symbol.Value, 0); !ok || num.Sign() < 0 {
+		return nil, p.translator.SyntaxError(value, "invalid enumeration value")
+	} else if val.SetBigInt(&num); num.Cmp(val.BigInt(new(big.Int))) != 0 {
+		return nil, p.translator.SyntaxError(value, "enumeration value out of range")
+	} else if !datatype.AsUnderlying().Accept(val) {
+		return nil, p.translator.SyntaxError(value, "enumeration value out of range")
+	}
+	//
+	return &val, nil
+}
+
+// Parse an enumeration membership constraint of the form "(in-enum NAME
+// expr)".
+func (p *Parser) parseDefInEnum(elements []sexp.SExp) (ast.Declaration, []SyntaxError) {
+	var enum *enumeration
+	// Check enumeration
+	if elements[1].AsSymbol() == nil {
+		return nil, p.translator.SyntaxErrors(elements[1], "invalid enumeration name")
+	} else if e, ok := p.env.enums[elements[1].AsSymbol().Value]; !ok {
+		return nil, p.translator.SyntaxErrors(elements[1], "unknown enumeration")
+	} else {
+		enum = e
+	}
+	// Translate expression
+	expr, errors := p.translator.Translate(elements[2])
+	// Error check
+	if len(errors) != 0 {
+		return nil, errors
+	}
+	// Done
+	return &ast.DefInEnum{Enum: enum.values, Expr: expr}, nil
+}
+
+// Determine whether a given type symbol (e.g. ":OPCODE") refers to a known
+// enumeration or not.  If not, nil is returned.
+func (p *Parser) enumType(symbol *sexp.Symbol) *enumeration {
+	if name, ok := strings.CutPrefix(symbol.Value, ":"); ok {
+		return p.env.enums[name]
+	}
+	//
+	return nil
+}
@@ -238,7 +238,8 @@ func (p *GlobalEnvironment) allocateUnit(column *ast.ColumnBinding, ctx util.Pat
 		column.Multiplier,
 		datatype,
 		column.MustProve,
-		column.Computed}
+		column.Computed,
+		column.Display}
 	// Allocate register
 	p.registers = append(p.registers, Register{
 		tr.NewContext(moduleId, column.Multiplier),
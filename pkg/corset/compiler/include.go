@@ -0,0 +1,116 @@
+package compiler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/consensys/go-corset/pkg/corset/ast"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+// parsedSourceFile captures the result of parsing a single source file, namely
+// the (partial) circuit it declares along with the source map for its nodes.
+type parsedSourceFile struct {
+	circuit ast.Circuit
+	srcmap  *sexp.SourceMap[ast.Node]
+}
+
+// parseIncludedSourceFiles parses zero or more source files, along with any
+// files they (transitively) include.  Included files are resolved relative to
+// the directory of the including file, and are parsed at the point they are
+// included (e.g. such that macros they define are available thereafter).  Any
+// file is parsed at most once, regardless of how many times it is included (or
+// given).  An include which refers (directly or indirectly) back to the file
+// containing it is reported as an error.
+func parseIncludedSourceFiles(files []*sexp.SourceFile) ([]parsedSourceFile, []SyntaxError) {
+	resolver := includeResolver{
+		visited: make(map[string]bool),
+		active:  make(map[string]bool),
+		env:     newParseEnvironment(),
+	}
+	//
+	for _, file := range files {
+		resolver.parse(file)
+	}
+	//
+	return resolver.parsed, resolver.errors
+}
+
+// The include resolver is responsible for parsing source files, and following
+// any include directives they contain.
+type includeResolver struct {
+	// Identifies files which have been (or are being) parsed, indexed by their
+	// canonical filename.
+	visited map[string]bool
+	// Identifies files whose includes are currently being resolved.  Including
+	// any such file indicates a cycle.
+	active map[string]bool
+	// Files parsed so far (in order).
+	parsed []parsedSourceFile
+	// Errors reported so far.
+	errors []SyntaxError
+	// Macros, module templates and enumerations defined so far (which are
+	// shared across all files).
+	env *parseEnvironment
+}
+
+func (p *includeResolver) parse(file *sexp.SourceFile) {
+	name := canonicalFilename(file.Filename())
+	// Check whether already parsed
+	if p.visited[name] {
+		return
+	}
+	//
+	p.visited[name] = true
+	p.active[name] = true
+	// Parse file itself, resolving includes as they are encountered.
+	circuit, srcmap, errs := parseSourceFile(file, p.env, func(path string) error {
+		return p.include(file, path)
+	})
+	//
+	p.active[name] = false
+	// Handle errors
+	if len(errs) > 0 {
+		p.errors = append(p.errors, errs...)
+		return
+	}
+	//
+	p.parsed = append(p.parsed, parsedSourceFile{circuit, srcmap})
+}
+
+// Resolve a given include path found in a given source file.
+func (p *includeResolver) include(file *sexp.SourceFile, path string) error {
+	filename := path
+	// Paths are relative to the including file
+	if !filepath.IsAbs(filename) {
+		filename = filepath.Join(filepath.Dir(file.Filename()), filename)
+	}
+	//
+	name := canonicalFilename(filename)
+	//
+	if p.active[name] {
+		return fmt.Errorf("cyclic include of \"%s\"", path)
+	} else if !p.visited[name] {
+		// Read included file
+		bytes, err := os.ReadFile(filename)
+		//
+		if err != nil {
+			return fmt.Errorf("unable to read included file \"%s\"", path)
+		}
+		//
+		p.parse(sexp.NewSourceFile(filename, bytes))
+	}
+	//
+	return nil
+}
+
+// Determine a canonical name for a given file, such that different paths
+// referring to the same file are identified.
+func canonicalFilename(filename string) string {
+	if abs, err := filepath.Abs(filename); err == nil {
+		return abs
+	}
+	//
+	return filepath.Clean(filename)
+}
@@ -116,6 +116,20 @@ var INTRINSICS []IntrinsicDefinition = []IntrinsicDefinition{
 	{"-", 1, math.MaxUint, intrinsicSub},
 	// Multiplication
 	{"*", 1, math.MaxUint, intrinsicMul},
+	// Division (constants only)
+	{"/", 2, 2, intrinsicConstOp(ast.CONST_DIV)},
+	// Remainder (constants only)
+	{"%", 2, 2, intrinsicConstOp(ast.CONST_REM)},
+	// Shift left (constants only)
+	{"shl", 2, 2, intrinsicConstOp(ast.CONST_SHL)},
+	// Shift right (constants only)
+	{"shr", 2, 2, intrinsicConstOp(ast.CONST_SHR)},
+	// Bitwise and (constants only)
+	{"band", 1, math.MaxUint, intrinsicConstOp(ast.CONST_BAND)},
+	// Bitwise or (constants only)
+	{"bor", 1, math.MaxUint, intrinsicConstOp(ast.CONST_BOR)},
+	// Bitwise xor (constants only)
+	{"bxor", 1, math.MaxUint, intrinsicConstOp(ast.CONST_BXOR)},
 }
 
 func intrinsicAdd(arity uint) ast.Expr {
@@ -130,6 +144,12 @@ func intrinsicMul(arity uint) ast.Expr {
 	return &ast.Mul{Args: intrinsicNaryBody(arity)}
 }
 
+func intrinsicConstOp(kind uint8) func(uint) ast.Expr {
+	return func(arity uint) ast.Expr {
+		return &ast.ConstOp{Kind: kind, Args: intrinsicNaryBody(arity)}
+	}
+}
+
 func intrinsicNaryBody(arity uint) []ast.Expr {
 	args := make([]ast.Expr, arity)
 	//
@@ -0,0 +1,352 @@
+package compiler
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/consensys/go-corset/pkg/corset/ast"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+// Kinds of warning reported by the linter.
+const (
+	// UNUSED_COLUMN indicates an input column which is never referenced.
+	UNUSED_COLUMN = "unused-column"
+	// UNUSED_CONSTANT indicates a constant which is never referenced.
+	UNUSED_CONSTANT = "unused-constant"
+	// UNUSED_FUNCTION indicates a function which is never invoked.
+	UNUSED_FUNCTION = "unused-function"
+	// UNCONSTRAINED_COLUMN indicates an input column which is referenced, but
+	// not by any constraint (and, hence, is likely to be under-constrained).
+	UNCONSTRAINED_COLUMN = "unconstrained-column"
+	// SHADOWED_BINDING indicates a local variable (e.g. from a let) which hides
+	// another variable, column or constant of the same name.
+	SHADOWED_BINDING = "shadowed-binding"
+	// TRIVIAL_CONSTRAINT indicates a constraint which always holds, since it
+	// evaluates to zero.
+	TRIVIAL_CONSTRAINT = "trivial-constraint"
+	// IDENTICAL_BRANCHES indicates an if expression whose branches are
+	// identical.
+	IDENTICAL_BRANCHES = "identical-branches"
+)
+
+// Warning identifies a (potential) problem within a circuit which, unlike a
+// syntax error, does not prevent it from being compiled.  For example, a column
+// which is declared but never used.
+type Warning struct {
+	// Kind of warning (e.g. "unused-column"), which allows warnings to be
+	// suppressed selectively.
+	Kind string
+	// Location and description of the warning.
+	Error SyntaxError
+}
+
+// LintCircuit checks a given circuit for a range of common problems which do
+// not prevent it from being compiled, but which may indicate mistakes.  For
+// example, columns or constants which are never used, constraints which always
+// hold, etc.  This requires that the circuit has already been resolved and type
+// checked.
+func LintCircuit(srcmap *sexp.SourceMaps[ast.Node], circuit *ast.Circuit) []Warning {
+	linter := linter{srcmap, nil, make(map[ast.Binding]bool), make(map[ast.Binding]bool),
+		make(map[ast.Binding]*ast.DefFun), nil}
+	modules := []ast.Module{{Name: "", Declarations: circuit.Declarations}}
+	modules = append(modules, circuit.Modules...)
+	// Determine which symbols are used, and which columns are constrained.
+	for _, m := range modules {
+		linter.indexFunctions(m.Declarations)
+	}
+	//
+	for _, m := range modules {
+		linter.markUsed(m.Declarations)
+	}
+	//
+	for _, m := range modules {
+		linter.markConstrained(m.Declarations)
+	}
+	// Check each module
+	for _, m := range modules {
+		linter.globals = linter.namesOf(circuit.Declarations, false)
+		//
+		for name, kind := range linter.namesOf(m.Declarations, true) {
+			linter.globals[name] = kind
+		}
+		//
+		for _, decl := range m.Declarations {
+			linter.lintDeclaration(decl)
+		}
+	}
+	//
+	return linter.warnings
+}
+
+// linter holds the state needed for checking a circuit.
+type linter struct {
+	srcmap *sexp.SourceMaps[ast.Node]
+	// Names of columns and constants visible in the module being checked,
+	// mapped to a description of what they are.
+	globals map[string]string
+	// Bindings referenced anywhere within the circuit.
+	used map[ast.Binding]bool
+	// Column bindings referenced (directly or indirectly) by constraints.
+	constrained map[ast.Binding]bool
+	// Function declarations for each function binding.
+	functions map[ast.Binding]*ast.DefFun
+	// Warnings reported so far.
+	warnings []Warning
+}
+
+func (p *linter) indexFunctions(decls []ast.Declaration) {
+	for _, d := range decls {
+		if decl, ok := d.(*ast.DefFun); ok {
+			p.functions[decl.Binding()] = decl
+		}
+	}
+}
+
+// Mark all bindings referenced by the given declarations as being used.
+// Observe that invoking an overloaded function counts as using every overload.
+func (p *linter) markUsed(decls []ast.Declaration) {
+	for _, decl := range decls {
+		for iter := decl.Dependencies(); iter.HasNext(); {
+			symbol := iter.Next()
+			//
+			if !symbol.IsResolved() {
+				continue
+			} else if binding, ok := symbol.Binding().(*ast.OverloadedBinding); ok {
+				for _, overload := range binding.Overloads() {
+					p.used[overload] = true
+				}
+			} else {
+				p.used[symbol.Binding()] = true
+			}
+		}
+	}
+}
+
+// Mark all columns referenced by constraints within the given declarations as
+// being constrained.  This includes columns referenced indirectly through the
+// bodies of functions invoked by a constraint.  Observe that computations
+// (e.g. "defcomputedcolumn") and properties are not considered constraints.
+func (p *linter) markConstrained(decls []ast.Declaration) {
+	var visited = make(map[ast.Binding]bool)
+	//
+	for _, decl := range decls {
+		switch decl.(type) {
+		case *ast.DefConstraint, *ast.DefLookup, *ast.DefInRange, *ast.DefInEnum, *ast.DefPermutation,
+			*ast.DefInterleaved, *ast.DefPerspective:
+			p.markConstrainedSymbols(decl.Dependencies().Collect(), visited)
+		}
+	}
+}
+
+func (p *linter) markConstrainedSymbols(symbols []ast.Symbol, visited map[ast.Binding]bool) {
+	for _, symbol := range symbols {
+		if !symbol.IsResolved() {
+			continue
+		}
+		//
+		var bindings = []ast.Binding{symbol.Binding()}
+		//
+		if binding, ok := symbol.Binding().(*ast.OverloadedBinding); ok {
+			bindings = nil
+			//
+			for _, overload := range binding.Overloads() {
+				bindings = append(bindings, overload)
+			}
+		}
+		//
+		for _, binding := range bindings {
+			if fun, ok := p.functions[binding]; ok && !visited[binding] {
+				// Include columns accessed via invoked functions
+				visited[binding] = true
+				p.markConstrainedSymbols(fun.Dependencies().Collect(), visited)
+			} else if _, ok := binding.(*ast.ColumnBinding); ok {
+				p.constrained[binding] = true
+			}
+		}
+	}
+}
+
+// Determine the names of all columns and constants defined within the given
+// declarations.  Columns are only included if requested, since columns of the
+// root module are not visible within other modules.
+func (p *linter) namesOf(decls []ast.Declaration, columns bool) map[string]string {
+	var names = make(map[string]string)
+	//
+	for _, decl := range decls {
+		for iter := decl.Definitions(); iter.HasNext(); {
+			def := iter.Next()
+			//
+			switch def.Binding().(type) {
+			case *ast.ColumnBinding:
+				if columns {
+					names[def.Name()] = "column"
+				}
+			case *ast.ConstantBinding:
+				names[def.Name()] = "constant"
+			}
+		}
+	}
+	//
+	return names
+}
+
+func (p *linter) lintDeclaration(decl ast.Declaration) {
+	switch d := decl.(type) {
+	case *ast.DefColumns:
+		p.lintColumns(d.Columns)
+	case *ast.DefPerspective:
+		p.lintColumns(d.Columns)
+		p.lintExpression(d.Selector, nil)
+	case *ast.DefConst:
+		p.lintConstants(d)
+	case *ast.DefFun:
+		p.lintFunction(d)
+	case *ast.DefConstraint:
+		p.lintConstraint(d)
+	case *ast.DefComputedColumn:
+		p.lintExpression(d.Computation, nil)
+	case *ast.DefConstAssert:
+		p.lintExpression(d.Assertion, nil)
+	case *ast.DefInRange:
+		p.lintExpression(d.Expr, nil)
+	case *ast.DefInEnum:
+		p.lintExpression(d.Expr, nil)
+	case *ast.DefLookup:
+		p.lintExpressions(d.Sources, nil)
+		p.lintExpressions(d.Targets, nil)
+		p.lintExpression(d.SourceSelector, nil)
+		p.lintExpression(d.TargetSelector, nil)
+	case *ast.DefProperty:
+		p.lintExpression(d.Assertion, nil)
+	}
+}
+
+// Check for input columns which are either unused, or not used by any
+// constraint.
+func (p *linter) lintColumns(columns []*ast.DefColumn) {
+	for _, column := range columns {
+		binding := column.Binding()
+		//
+		if !p.used[binding] {
+			p.warn(UNUSED_COLUMN, column, fmt.Sprintf("unused column %s", column.Name()))
+		} else if !p.constrained[binding] {
+			p.warn(UNCONSTRAINED_COLUMN, column, fmt.Sprintf("column %s not used in any constraint", column.Name()))
+		}
+	}
+}
+
+// Check for unused constants.  Observe that members of an enumeration are
+// excluded, since these are typically used only for displaying values.
+// Likewise, the parameters of a module template instance are excluded, since
+// whether or not they are used is determined by the template itself.
+func (p *linter) lintConstants(decl *ast.DefConst) {
+	for _, constant := range decl.Constants {
+		if !p.used[constant.Binding()] && !decl.Enumeration && !decl.Parameters {
+			p.warn(UNUSED_CONSTANT, constant, fmt.Sprintf("unused constant %s", constant.Name()))
+		}
+		//
+		p.lintExpression(constant.ConstBinding.Value, nil)
+	}
+}
+
+func (p *linter) lintFunction(decl *ast.DefFun) {
+	var params []string
+	//
+	if !p.used[decl.Binding()] {
+		p.warn(UNUSED_FUNCTION, decl, fmt.Sprintf("unused function %s", decl.Name()))
+	}
+	//
+	for _, param := range decl.Parameters() {
+		params = append(params, param.Binding.Name)
+	}
+	//
+	p.lintExpression(decl.Body(), params)
+}
+
+func (p *linter) lintConstraint(decl *ast.DefConstraint) {
+	if val := decl.Constraint.AsConstant(); val != nil && val.Sign() == 0 {
+		p.warn(TRIVIAL_CONSTRAINT, decl.Constraint, "constraint always holds")
+	}
+	//
+	p.lintExpression(decl.Guard, nil)
+	p.lintExpression(decl.Constraint, nil)
+}
+
+func (p *linter) lintExpressions(exprs []ast.Expr, locals []string) {
+	for _, expr := range exprs {
+		p.lintExpression(expr, locals)
+	}
+}
+
+// Check an expression for shadowed bindings and identical branches, where
+// locals identifies the local variables in scope.
+func (p *linter) lintExpression(expr ast.Expr, locals []string) {
+	switch e := expr.(type) {
+	case *ast.Add:
+		p.lintExpressions(e.Args, locals)
+	case *ast.ArrayAccess:
+		p.lintExpression(e.Arg, locals)
+	case *ast.ConstOp:
+		p.lintExpressions(e.Args, locals)
+	case *ast.Debug:
+		p.lintExpression(e.Arg, locals)
+	case *ast.Exp:
+		p.lintExpression(e.Arg, locals)
+		p.lintExpression(e.Pow, locals)
+	case *ast.For:
+		p.lintBinding(e, e.Binding.Name, locals)
+		p.lintExpression(e.Body, append(locals, e.Binding.Name))
+	case *ast.If:
+		if e.TrueBranch != nil && e.FalseBranch != nil && reflect.DeepEqual(e.TrueBranch, e.FalseBranch) {
+			p.warn(IDENTICAL_BRANCHES, e, "if branches are identical")
+		}
+		//
+		p.lintExpression(e.Condition, locals)
+		p.lintExpression(e.TrueBranch, locals)
+		p.lintExpression(e.FalseBranch, locals)
+	case *ast.Invoke:
+		p.lintExpressions(e.Args, locals)
+	case *ast.Let:
+		p.lintExpressions(e.Args, locals)
+		//
+		for _, v := range e.Vars {
+			p.lintBinding(e, v.Name, locals)
+			locals = append(locals, v.Name)
+		}
+		//
+		p.lintExpression(e.Body, locals)
+	case *ast.List:
+		p.lintExpressions(e.Args, locals)
+	case *ast.Mul:
+		p.lintExpressions(e.Args, locals)
+	case *ast.Normalise:
+		p.lintExpression(e.Arg, locals)
+	case *ast.Reduce:
+		p.lintExpression(e.Arg, locals)
+	case *ast.Shift:
+		p.lintExpression(e.Arg, locals)
+		p.lintExpression(e.Shift, locals)
+	case *ast.Sub:
+		p.lintExpressions(e.Args, locals)
+	}
+}
+
+// Check whether a local variable introduced by a given expression shadows
+// either another local variable, or a column or constant.
+func (p *linter) lintBinding(expr ast.Expr, name string, locals []string) {
+	for _, local := range locals {
+		if local == name {
+			p.warn(SHADOWED_BINDING, expr, fmt.Sprintf("%s shadows local variable", name))
+			return
+		}
+	}
+	//
+	if kind, ok := p.globals[name]; ok {
+		p.warn(SHADOWED_BINDING, expr, fmt.Sprintf("%s shadows %s", name, kind))
+	}
+}
+
+func (p *linter) warn(kind string, node ast.Node, msg string) {
+	p.warnings = append(p.warnings, Warning{kind, *p.srcmap.SyntaxError(node, msg)})
+}
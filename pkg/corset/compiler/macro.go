@@ -0,0 +1,647 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+// MAX_MACRO_DEPTH determines the maximum depth of nested macro expansions.  This
+// is necessary to catch macros which (directly or indirectly) expand into
+// themselves.
+const MAX_MACRO_DEPTH = 256
+
+// MAX_MACRO_NODES determines the maximum number of nodes which can be produced
+// by expanding the macros within a single top-level term.  This is necessary to
+// catch macros whose expansion grows exponentially, even though their depth is
+// bounded (e.g. a macro which duplicates its argument).
+const MAX_MACRO_NODES = 1 << 20
+
+// macro represents a macro definition of the form "(defmacro (name params...)
+// body...)".  Each element of the body is either a quasi-quoted template, or a
+// parameter.  The final parameter can be preceded by "&rest", in which case it
+// captures all remaining arguments (as a list).
+type macro struct {
+	// Name of this macro.
+	name string
+	// Parameters of this macro.
+	params []string
+	// Indicates whether the final parameter captures all remaining arguments.
+	rest bool
+	// Body of this macro.
+	body []sexp.SExp
+}
+
+// Determine the index of a given parameter, or -1 if no such parameter exists.
+func (p *macro) paramIndex(name string) int {
+	for i, param := range p.params {
+		if param == name {
+			return i
+		}
+	}
+	//
+	return -1
+}
+
+// parseEnvironment holds the set of macros (and module templates and
+// enumerations) defined so far.  These are global and, hence, this is shared
+// across all source files being parsed.  Macros, module templates and
+// enumerations must be defined before they are used.
+type parseEnvironment struct {
+	// Macros defined so far.
+	macros map[string]*macro
+	// Module templates defined so far.
+	templates map[string]*moduleTemplate
+	// Enumerations defined so far.
+	enums map[string]*enumeration
+	// Counter used for generating fresh symbols.
+	fresh uint
+	// Number of nodes produced so far by expanding the macros within the
+	// current top-level term.
+	expanded uint
+}
+
+func newParseEnvironment() *parseEnvironment {
+	return &parseEnvironment{make(map[string]*macro), make(map[string]*moduleTemplate),
+		make(map[string]*enumeration), 0, 0}
+}
+
+// Parse a macro definition of the form "(defmacro (name params...) body...)",
+// and register it with the parse environment.  Templates within the body are
+// checked to ensure they only unquote parameters of the macro.
+func (p *Parser) parseDefMacro(s *sexp.List) []SyntaxError {
+	var (
+		errors    []SyntaxError
+		signature *sexp.List
+		m         = &macro{}
+	)
+	//
+	if s.Len() >= 3 {
+		signature = s.Elements[1].AsList()
+	}
+	//
+	if signature == nil || signature.Len() == 0 {
+		return p.translator.SyntaxErrors(s, "malformed macro")
+	} else if !isFunIdentifier(signature.Get(0)) || strings.Contains(signature.Get(0).AsSymbol().Value, "#") {
+		return p.translator.SyntaxErrors(signature.Get(0), "invalid macro name")
+	} else if _, ok := p.env.macros[signature.Get(0).AsSymbol().Value]; ok {
+		return p.translator.SyntaxErrors(signature.Get(0), "macro already defined")
+	}
+	//
+	m.name = signature.Get(0).AsSymbol().Value
+	// Parse parameters
+	for i := 1; i < signature.Len(); i++ {
+		ith := signature.Get(i)
+		//
+		if ith.AsSymbol() != nil && ith.AsSymbol().Value == "&rest" && i+2 == signature.Len() && !m.rest {
+			m.rest = true
+		} else if !isIdentifier(ith) || strings.Contains(ith.AsSymbol().Value, "#") {
+			errors = append(errors, *p.translator.SyntaxError(ith, "invalid macro parameter"))
+		} else if m.paramIndex(ith.AsSymbol().Value) >= 0 {
+			errors = append(errors, *p.translator.SyntaxError(ith, "duplicate macro parameter"))
+		} else {
+			m.params = append(m.params, ith.AsSymbol().Value)
+		}
+	}
+	// Check body
+	for _, ith := range s.Elements[2:] {
+		if symbol := ith.AsSymbol(); symbol != nil && m.paramIndex(symbol.Value) >= 0 {
+			continue
+		} else if l := ith.AsList(); l != nil && l.Len() == 2 && l.MatchSymbols(1, "quasiquote") {
+			errors = append(errors, p.checkMacroTemplate(m, l.Get(1), true)...)
+		} else {
+			errors = append(errors, *p.translator.SyntaxError(ith, "malformed macro body"))
+		}
+	}
+	//
+	if len(errors) == 0 {
+		m.body = s.Elements[2:]
+		p.env.macros[m.name] = m
+	}
+	//
+	return errors
+}
+
+// Check that a given macro template only unquotes parameters of the enclosing
+// macro, and does not attempt to splice at the root.
+func (p *Parser) checkMacroTemplate(m *macro, template sexp.SExp, root bool) []SyntaxError {
+	var errors []SyntaxError
+	//
+	switch t := template.(type) {
+	case *sexp.List:
+		if t.MatchSymbols(1, "quasiquote") {
+			return p.translator.SyntaxErrors(t, "nested quasiquote not supported")
+		} else if t.MatchSymbols(1, "unquote") || t.MatchSymbols(1, "unquote-splicing") {
+			if t.Len() != 2 || t.Get(1).AsSymbol() == nil {
+				return p.translator.SyntaxErrors(t, "malformed unquote")
+			} else if m.paramIndex(t.Get(1).AsSymbol().Value) < 0 {
+				return p.translator.SyntaxErrors(t.Get(1), "unknown macro parameter")
+			} else if root && t.MatchSymbols(1, "unquote-splicing") {
+				return p.translator.SyntaxErrors(t, "invalid splice")
+			}
+			//
+			return nil
+		}
+		//
+		for _, e := range t.Elements {
+			errors = append(errors, p.checkMacroTemplate(m, e, false)...)
+		}
+	case *sexp.Set:
+		for _, e := range t.Elements {
+			errors = append(errors, p.checkMacroTemplate(m, e, false)...)
+		}
+	case *sexp.Array:
+		for _, e := range t.Elements {
+			errors = append(errors, p.checkMacroTemplate(m, e, false)...)
+		}
+	}
+	//
+	return errors
+}
+
+// Check that no symbol within a given term contains "#", since such symbols are
+// reserved for those generated when instantiating a macro body (see rename).
+// Macro definitions are exempt, since their templates use "#" to mark those
+// symbols which should be generated.  Likewise, strings are exempt.
+func (p *Parser) checkReservedSymbols(term sexp.SExp) []SyntaxError {
+	var (
+		elements []sexp.SExp
+		errors   []SyntaxError
+	)
+	//
+	switch t := term.(type) {
+	case *sexp.Symbol:
+		if !strings.HasPrefix(t.Value, "\"") && strings.Contains(t.Value, "#") {
+			return p.translator.SyntaxErrors(t, "'#' only permitted in macro templates")
+		}
+	case *sexp.List:
+		if !t.MatchSymbols(1, "defmacro") {
+			elements = t.Elements
+		}
+	case *sexp.Set:
+		elements = t.Elements
+	case *sexp.Array:
+		elements = t.Elements
+	}
+	//
+	for _, e := range elements {
+		errors = append(errors, p.checkReservedSymbols(e)...)
+	}
+	//
+	return errors
+}
+
+// Expand all macro invocations within a given term.  Since a macro can expand
+// into multiple terms, this returns zero or more terms.  Where a macro
+// invocation is nested within a list (or set, or array), its expansion is
+// spliced into the enclosing list.  Macro definitions themselves are left
+// untouched.
+func (p *Parser) expandMacros(term sexp.SExp, depth uint) ([]sexp.SExp, []SyntaxError) {
+	var (
+		original = term
+		elements []sexp.SExp
+		changed  bool
+		errors   []SyntaxError
+	)
+	//
+	// Once the expansion is too large, no further expansion is performed (since
+	// this error has already been reported).
+	if p.env.expanded > MAX_MACRO_NODES {
+		return []sexp.SExp{term}, nil
+	}
+	//
+	switch t := term.(type) {
+	case *sexp.List:
+		if t.MatchSymbols(1, "defmacro") {
+			return []sexp.SExp{t}, nil
+		} else if m := p.macroOf(t); m != nil {
+			return p.expandMacro(m, t, depth)
+		}
+		//
+		if elements, changed, errors = p.expandMacroElements(t.Elements, depth); changed && len(errors) == 0 {
+			term = sexp.NewList(elements)
+		}
+	case *sexp.Set:
+		if elements, changed, errors = p.expandMacroElements(t.Elements, depth); changed && len(errors) == 0 {
+			term = sexp.NewSet(elements)
+		}
+	case *sexp.Array:
+		if elements, changed, errors = p.expandMacroElements(t.Elements, depth); changed && len(errors) == 0 {
+			term = sexp.NewArray(elements)
+		}
+	}
+	// Check for errors
+	if len(errors) > 0 {
+		return nil, errors
+	} else if changed {
+		// Newly constructed term inherits span of original
+		p.srcmap.Put(term, p.translator.SpanOf(original))
+	}
+	//
+	return []sexp.SExp{term}, nil
+}
+
+// Expand all macro invocations within a given sequence of elements, indicating
+// whether any expansion occurred.
+func (p *Parser) expandMacroElements(elements []sexp.SExp, depth uint) ([]sexp.SExp, bool, []SyntaxError) {
+	var (
+		nelements []sexp.SExp
+		changed   bool
+		errors    []SyntaxError
+	)
+	//
+	for _, e := range elements {
+		terms, errs := p.expandMacros(e, depth)
+		errors = append(errors, errs...)
+		//
+		if len(terms) != 1 || terms[0] != e {
+			changed = true
+		}
+		//
+		nelements = append(nelements, terms...)
+	}
+	//
+	return nelements, changed, errors
+}
+
+// Expand a given macro invocation, and then expand any macro invocations within
+// the result.
+func (p *Parser) expandMacro(m *macro, call *sexp.List, depth uint) ([]sexp.SExp, []SyntaxError) {
+	var (
+		terms  []sexp.SExp
+		errors []SyntaxError
+	)
+	//
+	if depth >= MAX_MACRO_DEPTH {
+		return nil, p.translator.SyntaxErrors(call, "macro expansion too deep")
+	}
+	//
+	expansion, errs := p.instantiateMacro(m, call)
+	//
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	// Check expansion size
+	for _, e := range expansion {
+		p.env.expanded += sizeOfTerm(e)
+	}
+	//
+	if p.env.expanded > MAX_MACRO_NODES {
+		return nil, p.translator.SyntaxErrors(call, "macro expansion too large")
+	}
+	//
+	for _, e := range expansion {
+		ts, errs := p.expandMacros(e, depth+1)
+		errors = append(errors, errs...)
+		terms = append(terms, ts...)
+	}
+	//
+	return terms, errors
+}
+
+// Determine whether a given list is an invocation of a known macro and, if so,
+// return that macro.
+func (p *Parser) macroOf(l *sexp.List) *macro {
+	if l.Len() > 0 && l.Get(0).AsSymbol() != nil {
+		return p.env.macros[l.Get(0).AsSymbol().Value]
+	}
+	//
+	return nil
+}
+
+// Instantiate the body of a given macro for a given invocation.  All nodes
+// originating from the macro body are mapped to the span of the invocation,
+// whilst nodes originating from arguments retain their original spans.
+func (p *Parser) instantiateMacro(m *macro, call *sexp.List) ([]sexp.SExp, []SyntaxError) {
+	var (
+		args  = call.Elements[1:]
+		nargs = len(m.params)
+		terms []sexp.SExp
+		inst  = macroInstance{p, m, nil, make(map[string]string), nil, make(map[*sexp.Symbol]string),
+			p.translator.SpanOf(call)}
+	)
+	// Check arity
+	if (!m.rest && len(args) != nargs) || (m.rest && len(args) < nargs-1) {
+		return nil, p.translator.SyntaxErrors(call, "incorrect number of arguments")
+	} else if m.rest {
+		// Package up remaining arguments
+		rest := sexp.NewList(args[nargs-1:])
+		p.srcmap.Put(rest, inst.span)
+		args = append(args[:nargs-1:nargs-1], rest)
+	}
+	//
+	inst.args = args
+	//
+	for _, ith := range m.body {
+		if symbol := ith.AsSymbol(); symbol != nil {
+			terms = append(terms, inst.copy(args[m.paramIndex(symbol.Value)]))
+		} else if ts, err := inst.substitute(ith.AsList().Get(1)); err != nil {
+			return nil, p.translator.SyntaxErrors(call, err.Error())
+		} else {
+			terms = append(terms, ts...)
+		}
+	}
+	//
+	return terms, nil
+}
+
+// macroInstance captures the information needed to instantiate the body of a
+// macro for a given invocation.
+type macroInstance struct {
+	parser *Parser
+	macro  *macro
+	// Arguments for each parameter
+	args []sexp.SExp
+	// Fresh names allocated for this instance
+	fresh map[string]string
+	// Fresh names for variables bound by the template which are in scope.
+	scope map[string]string
+	// Fresh names for the binders (e.g. let variables) within the template.
+	binders map[*sexp.Symbol]string
+	// Span of the invocation
+	span sexp.Span
+}
+
+// Substitute arguments for (unquoted) parameters through a given template.
+// This is hygienic in the sense that every variable bound by the template
+// itself (e.g. by a let or for expression, or as a function parameter) is
+// replaced with a fresh symbol which is unique to this instance.  Likewise, a
+// symbol ending in "#" is replaced with a fresh (i.e. gensym-style) symbol.
+// Since "#" cannot appear in symbols outside macro templates, names introduced
+// in these ways cannot capture (or clash with) names used at the invocation
+// site.  All other symbols in the template are resolved at the invocation site
+// (e.g. a template referring to column X refers to whichever X is visible
+// there).
+func (p *macroInstance) substitute(template sexp.SExp) ([]sexp.SExp, error) {
+	var (
+		elements []sexp.SExp
+		err      error
+	)
+	//
+	switch t := template.(type) {
+	case *sexp.Symbol:
+		return []sexp.SExp{p.put(sexp.NewSymbol(p.rename(t)))}, nil
+	case *sexp.List:
+		if t.MatchSymbols(1, "unquote") {
+			return []sexp.SExp{p.copy(p.argument(t))}, nil
+		} else if t.MatchSymbols(1, "unquote-splicing") {
+			return p.splice(t)
+		} else if binders, body := bindersOf(t); len(binders) > 0 {
+			return p.substituteBinding(t, binders, body)
+		} else if elements, err = p.substituteElements(t.Elements); err == nil {
+			return []sexp.SExp{p.put(sexp.NewList(elements))}, nil
+		}
+	case *sexp.Set:
+		if elements, err = p.substituteElements(t.Elements); err == nil {
+			return []sexp.SExp{p.put(sexp.NewSet(elements))}, nil
+		}
+	case *sexp.Array:
+		if elements, err = p.substituteElements(t.Elements); err == nil {
+			return []sexp.SExp{p.put(sexp.NewArray(elements))}, nil
+		}
+	}
+	//
+	return nil, err
+}
+
+func (p *macroInstance) substituteElements(elements []sexp.SExp) ([]sexp.SExp, error) {
+	var nelements []sexp.SExp
+	//
+	for _, e := range elements {
+		terms, err := p.substitute(e)
+		if err != nil {
+			return nil, err
+		}
+		//
+		nelements = append(nelements, terms...)
+	}
+	//
+	return nelements, nil
+}
+
+// Splice the elements of the argument for an unquoted parameter.
+func (p *macroInstance) splice(unquote *sexp.List) ([]sexp.SExp, error) {
+	var (
+		arg      = p.argument(unquote)
+		elements []sexp.SExp
+	)
+	//
+	switch t := arg.(type) {
+	case *sexp.List:
+		elements = t.Elements
+	case *sexp.Set:
+		elements = t.Elements
+	case *sexp.Array:
+		elements = t.Elements
+	default:
+		return nil, fmt.Errorf("cannot splice non-list argument %s", arg.String(false))
+	}
+	//
+	return p.copyElements(elements), nil
+}
+
+// Determine the argument for the parameter of a given unquote.
+func (p *macroInstance) argument(unquote *sexp.List) sexp.SExp {
+	return p.args[p.macro.paramIndex(unquote.Get(1).AsSymbol().Value)]
+}
+
+// Substitute through a binding form (e.g. a let expression) whose given binders
+// scope over the element at the given (body) index.  Each binder is given a
+// fresh name, and occurrences of that name within the body are renamed
+// accordingly.  Observe that elements other than the body (e.g. the bound
+// expressions of a let) are substituted in the enclosing scope.
+func (p *macroInstance) substituteBinding(binding *sexp.List, binders []*sexp.Symbol,
+	body int) ([]sexp.SExp, error) {
+	var (
+		outer    = p.scope
+		inner    = make(map[string]string)
+		elements []sexp.SExp
+	)
+	// Construct inner scope
+	for name, fresh := range outer {
+		inner[name] = fresh
+	}
+	//
+	for _, binder := range binders {
+		fresh := p.freshName(binder.Value)
+		inner[binder.Value] = fresh
+		p.binders[binder] = fresh
+	}
+	// Substitute elements (where only the body uses the inner scope)
+	for i, e := range binding.Elements {
+		if i == body {
+			p.scope = inner
+		}
+		//
+		terms, err := p.substitute(e)
+		p.scope = outer
+		//
+		if err != nil {
+			return nil, err
+		}
+		//
+		elements = append(elements, terms...)
+	}
+	//
+	return []sexp.SExp{p.put(sexp.NewList(elements))}, nil
+}
+
+// Rename a given symbol (if applicable) by generating a fresh symbol.  This
+// applies to variables bound by the template (e.g. "x" might be renamed as
+// "x#2"), and to symbols ending in "#" (e.g. "tmp#" might be renamed as
+// "tmp#3").
+func (p *macroInstance) rename(symbol *sexp.Symbol) string {
+	name := symbol.Value
+	//
+	if fresh, ok := p.binders[symbol]; ok {
+		return fresh
+	} else if fresh, ok := p.scope[name]; ok {
+		return fresh
+	} else if len(name) <= 1 || !strings.HasSuffix(name, "#") {
+		return name
+	} else if fresh, ok := p.fresh[name]; ok {
+		return fresh
+	}
+	//
+	fresh := p.freshName(name)
+	p.fresh[name] = fresh
+	//
+	return fresh
+}
+
+// Generate a fresh name based on a given name.  Since generated names always
+// contain "#", they cannot clash with names used outside of macro templates.
+func (p *macroInstance) freshName(name string) string {
+	p.parser.env.fresh++
+	//
+	if strings.HasSuffix(name, "#") {
+		return fmt.Sprintf("%s%d", name, p.parser.env.fresh)
+	}
+	//
+	return fmt.Sprintf("%s#%d", name, p.parser.env.fresh)
+}
+
+// Determine the variables bound by a given template term (if any), along with
+// the index of the element over which they scope.  Specifically, this
+// identifies the variables of let and for expressions, and the parameters of
+// function declarations.  Only binders which appear literally in the template
+// are considered, since those provided as arguments (e.g. "(let ((,x 1))
+// ...)") are intended to be visible at the invocation site.
+func bindersOf(term *sexp.List) ([]*sexp.Symbol, int) {
+	var binders []*sexp.Symbol
+	//
+	switch {
+	case term.Len() == 3 && term.MatchSymbols(1, "let") && term.Get(1).AsList() != nil:
+		for _, e := range term.Get(1).AsList().Elements {
+			if ith := e.AsList(); ith != nil && ith.Len() == 2 {
+				binders = appendBinder(binders, ith.Get(0))
+			}
+		}
+		//
+		return binders, 2
+	case term.Len() == 4 && term.MatchSymbols(1, "for"):
+		return appendBinder(binders, term.Get(1)), 3
+	case term.Len() == 3 && (term.MatchSymbols(1, "defun") || term.MatchSymbols(1, "defpurefun")) &&
+		term.Get(1).AsList() != nil:
+		for _, e := range term.Get(1).AsList().Elements[1:] {
+			if ith := e.AsList(); ith != nil && ith.Len() == 2 {
+				binders = appendBinder(binders, ith.Get(0))
+			} else {
+				binders = appendBinder(binders, e)
+			}
+		}
+		//
+		return binders, 2
+	}
+	//
+	return nil, 0
+}
+
+// Append a given term to a set of binders, provided it is a symbol which is not
+// already renamed by other means (i.e. it does not end in "#").
+func appendBinder(binders []*sexp.Symbol, term sexp.SExp) []*sexp.Symbol {
+	if symbol := term.AsSymbol(); symbol != nil && !strings.HasSuffix(symbol.Value, "#") {
+		return append(binders, symbol)
+	}
+	//
+	return binders
+}
+
+// Construct a (deep) copy of a given term for this instance.
+func (p *macroInstance) copy(term sexp.SExp) sexp.SExp {
+	return p.parser.copyTerm(term, p.span)
+}
+
+func (p *macroInstance) copyElements(elements []sexp.SExp) []sexp.SExp {
+	return p.parser.copyTerms(elements, p.span)
+}
+
+// Register a node constructed from the macro body with the span of the
+// invocation.
+func (p *macroInstance) put(term sexp.SExp) sexp.SExp {
+	p.parser.srcmap.Put(term, p.span)
+	return term
+}
+
+// Construct a (deep) copy of a given term, such that every node retains its
+// original span.  This ensures that every node in an expanded tree is unique.
+// Nodes whose original span is unknown (e.g. because they originate from a
+// different source file) are given a default span.
+func (p *Parser) copyTerm(term sexp.SExp, span sexp.Span) sexp.SExp {
+	var nterm sexp.SExp
+	//
+	switch t := term.(type) {
+	case *sexp.Symbol:
+		nterm = sexp.NewSymbol(t.Value)
+	case *sexp.List:
+		nterm = sexp.NewList(p.copyTerms(t.Elements, span))
+	case *sexp.Set:
+		nterm = sexp.NewSet(p.copyTerms(t.Elements, span))
+	case *sexp.Array:
+		nterm = sexp.NewArray(p.copyTerms(t.Elements, span))
+	default:
+		return term
+	}
+	//
+	if p.srcmap.Has(term) {
+		p.srcmap.Put(nterm, p.srcmap.Get(term))
+	} else {
+		p.srcmap.Put(nterm, span)
+	}
+	//
+	return nterm
+}
+
+func (p *Parser) copyTerms(terms []sexp.SExp, span sexp.Span) []sexp.SExp {
+	nterms := make([]sexp.SExp, len(terms))
+	//
+	for i, e := range terms {
+		nterms[i] = p.copyTerm(e, span)
+	}
+	//
+	return nterms
+}
+
+// Determine the number of nodes in a given term.
+func sizeOfTerm(term sexp.SExp) uint {
+	var (
+		size     uint = 1
+		elements []sexp.SExp
+	)
+	//
+	switch t := term.(type) {
+	case *sexp.List:
+		elements = t.Elements
+	case *sexp.Set:
+		elements = t.Elements
+	case *sexp.Array:
+		elements = t.Elements
+	}
+	//
+	for _, e := range elements {
+		size += sizeOfTerm(e)
+	}
+	//
+	return size
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/go-corset/pkg/corset/ast"
+	tr "github.com/consensys/go-corset/pkg/trace"
 	"github.com/consensys/go-corset/pkg/util"
 	"github.com/consensys/go-corset/pkg/util/sexp"
 )
@@ -25,7 +27,8 @@ import (
 // function does more than just parse the individual files, because it
 // additional combines all fragments of the same module together into one place.
 // Thus, you should never expect to see duplicate module names in the returned
-// array.
+// array.  Likewise, any files included from those given are parsed as well,
+// such that every file is parsed exactly once.
 func ParseSourceFiles(files []*sexp.SourceFile) (ast.Circuit, *sexp.SourceMaps[ast.Node], []SyntaxError) {
 	var circuit ast.Circuit
 	// (for now) at most one error per source file is supported.
@@ -38,18 +41,19 @@ func ParseSourceFiles(files []*sexp.SourceFile) (ast.Circuit, *sexp.SourceMaps[a
 	contents := make(map[string]ast.Module, 0)
 	// Names identifies the names of each unique module.
 	names := make([]string, 0)
+	// Parse all files, including any files they include.
+	parsed, errs := parseIncludedSourceFiles(files)
+	// Handle errors
+	if len(errs) > 0 {
+		num_errs += uint(len(errs))
+		// Report any errors encountered
+		errors = append(errors, errs...)
+	}
 	//
-	for _, file := range files {
-		c, srcmap, errs := ParseSourceFile(file)
-		// Handle errors
-		if len(errs) > 0 {
-			num_errs += uint(len(errs))
-			// Report any errors encountered
-			errors = append(errors, errs...)
-		} else {
-			// Combine source maps
-			srcmaps.Join(srcmap)
-		}
+	for _, file := range parsed {
+		c := file.circuit
+		// Combine source maps
+		srcmaps.Join(file.srcmap)
 		// Update top-level declarations
 		circuit.Declarations = append(circuit.Declarations, c.Declarations...)
 		// Allocate any module fragments
@@ -85,6 +89,16 @@ func ParseSourceFiles(files []*sexp.SourceFile) (ast.Circuit, *sexp.SourceMaps[a
 // modules.  Observe that every lisp file starts in the "prelude" or "root"
 // module, and may declare items for additional modules as necessary.
 func ParseSourceFile(srcfile *sexp.SourceFile) (ast.Circuit, *sexp.SourceMap[ast.Node], []SyntaxError) {
+	return parseSourceFile(srcfile, newParseEnvironment(), nil)
+}
+
+// Parse the contents of a single lisp file using a given environment of
+// (previously defined) macros, module templates and enumerations.  Any defined
+// within the file are added to this environment.
+// Include directives are passed to the given includer (if any) as they are
+// encountered.
+func parseSourceFile(srcfile *sexp.SourceFile, env *parseEnvironment, includer func(string) error) (ast.Circuit,
+	*sexp.SourceMap[ast.Node], []SyntaxError) {
 	var (
 		circuit ast.Circuit
 		errors  []SyntaxError
@@ -98,6 +112,8 @@ func ParseSourceFile(srcfile *sexp.SourceFile) (ast.Circuit, *sexp.SourceMap[ast
 	}
 	// Construct parser for corset syntax
 	p := NewParser(srcfile, srcmap)
+	p.env = env
+	p.includer = includer
 	// Parse whatever is declared at the beginning of the file before the first
 	// module declaration.  These declarations form part of the "prelude".
 	if circuit.Declarations, terms, errors = p.parseModuleContents(path, terms); len(errors) > 0 {
@@ -106,18 +122,19 @@ func ParseSourceFile(srcfile *sexp.SourceFile) (ast.Circuit, *sexp.SourceMap[ast
 	// Continue parsing string until nothing remains.
 	for len(terms) != 0 {
 		var (
-			name  string
-			decls []ast.Declaration
+			name     string
+			decls    []ast.Declaration
+			instance []ast.Declaration
 		)
-		// Extract module name
-		if name, errors = p.parseModuleStart(terms[0]); len(errors) > 0 {
+		// Extract module name (and instantiate template, if applicable)
+		if name, instance, errors = p.parseModuleStart(terms[0]); len(errors) > 0 {
 			return circuit, nil, errors
 		}
 		// Parse module contents
 		path = util.NewAbsolutePath(name)
 		if decls, terms, errors = p.parseModuleContents(path, terms[1:]); len(errors) > 0 {
 			return circuit, nil, errors
-		} else if len(decls) != 0 {
+		} else if decls = append(instance, decls...); len(decls) != 0 {
 			circuit.Modules = append(circuit.Modules, ast.Module{Name: name, Declarations: decls})
 		}
 	}
@@ -137,6 +154,13 @@ type Parser struct {
 	translator *sexp.Translator[ast.Expr]
 	// Mapping from constructed S-Expressions to their spans in the original text.
 	nodemap *sexp.SourceMap[ast.Node]
+	// Mapping from S-Expressions to their spans in the original text.  This
+	// is extended with those S-Expressions constructed by macro expansion.
+	srcmap *sexp.SourceMap[sexp.SExp]
+	// Responsible for handling include directives (if permitted).
+	includer func(string) error
+	// Macros, module templates and enumerations defined so far.
+	env *parseEnvironment
 }
 
 // NewParser constructs a new parser using a given mapping from S-Expressions to
@@ -146,7 +170,7 @@ func NewParser(srcfile *sexp.SourceFile, srcmap *sexp.SourceMap[sexp.SExp]) *Par
 	// Construct (initially empty) node map
 	nodemap := sexp.NewSourceMap[ast.Node](srcmap.Source())
 	// Construct parser
-	parser := &Parser{p, nodemap}
+	parser := &Parser{p, nodemap, srcmap, nil, newParseEnvironment()}
 	// Configure expression translator
 	p.AddSymbolRule(constantParserRule)
 	p.AddSymbolRule(varAccessParserRule)
@@ -155,6 +179,13 @@ func NewParser(srcfile *sexp.SourceFile, srcmap *sexp.SourceMap[sexp.SExp]) *Par
 	p.AddRecursiveListRule("*", mulParserRule)
 	p.AddRecursiveListRule("~", normParserRule)
 	p.AddRecursiveListRule("^", powParserRule)
+	p.AddRecursiveListRule("/", constOpParserRule(ast.CONST_DIV))
+	p.AddRecursiveListRule("%", constOpParserRule(ast.CONST_REM))
+	p.AddRecursiveListRule("shl", constOpParserRule(ast.CONST_SHL))
+	p.AddRecursiveListRule("shr", constOpParserRule(ast.CONST_SHR))
+	p.AddRecursiveListRule("band", constOpParserRule(ast.CONST_BAND))
+	p.AddRecursiveListRule("bor", constOpParserRule(ast.CONST_BOR))
+	p.AddRecursiveListRule("bxor", constOpParserRule(ast.CONST_BXOR))
 	p.AddRecursiveListRule("begin", beginParserRule)
 	p.AddRecursiveListRule("debug", debugParserRule)
 	p.AddListRule("for", forParserRule(parser))
@@ -201,11 +232,11 @@ func (p *Parser) parseModuleContents(path util.Path, terms []sexp.SExp) ([]ast.D
 			errors = append(errors, *err)
 		} else if e.MatchSymbols(2, "module") {
 			return decls, terms[i:], errors
-		} else if decl, errs := p.parseDeclaration(path, e); len(errs) > 0 {
+		} else if ds, errs := p.parseTopLevel(path, e); len(errs) > 0 {
 			errors = append(errors, errs...)
 		} else {
 			// Continue accumulating declarations for this module.
-			decls = append(decls, decl)
+			decls = append(decls, ds...)
 		}
 	}
 	// Sanity check errors
@@ -216,24 +247,103 @@ func (p *Parser) parseModuleContents(path util.Path, terms []sexp.SExp) ([]ast.D
 	return decls, make([]sexp.SExp, 0), nil
 }
 
+// Parse a top-level term within a module, such as an include directive, a macro
+// definition or a declaration.  Any macro invocations are expanded first, and
+// this may give rise to zero or more declarations.
+func (p *Parser) parseTopLevel(path util.Path, e *sexp.List) ([]ast.Declaration, []SyntaxError) {
+	var (
+		errors []SyntaxError
+		decls  []ast.Declaration
+	)
+	// Check for reserved symbols, and then expand any macros
+	if errs := p.checkReservedSymbols(e); len(errs) > 0 {
+		return nil, errs
+	}
+	//
+	p.env.expanded = 0
+	terms, errs := p.expandMacros(e, 0)
+	//
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	//
+	for _, term := range terms {
+		l, ok := term.(*sexp.List)
+		//
+		if !ok {
+			errors = append(errors, *p.translator.SyntaxError(term, "unexpected or malformed declaration"))
+		} else if l.MatchSymbols(1, "include") {
+			errors = append(errors, p.parseInclude(path, l)...)
+		} else if l.MatchSymbols(1, "defmacro") {
+			errors = append(errors, p.parseDefMacro(l)...)
+		} else if l.MatchSymbols(1, "defmodule-template") {
+			errors = append(errors, p.parseDefModuleTemplate(l)...)
+		} else if l.MatchSymbols(1, "defenum") {
+			decl, errs := p.parseDefEnum(path, l)
+			errors = append(errors, errs...)
+			decls = append(decls, decl...)
+		} else if decl, errs := p.parseDeclaration(path, l); len(errs) > 0 {
+			errors = append(errors, errs...)
+		} else {
+			decls = append(decls, decl)
+		}
+	}
+	//
+	return decls, errors
+}
+
 // Parse a module declaration of the form "(module m1)" which indicates the
-// start of module m1.
-func (p *Parser) parseModuleStart(s sexp.SExp) (string, []SyntaxError) {
+// start of module m1.  Alternatively, a declaration of the form "(module m1 T
+// args...)" indicates the start of module m1 as an instance of module template
+// T, in which case the declarations of the instance are also returned.
+func (p *Parser) parseModuleStart(s sexp.SExp) (string, []ast.Declaration, []SyntaxError) {
 	l, ok := s.(*sexp.List)
 	// Check for error
 	if !ok {
 		err := p.translator.SyntaxError(s, "unexpected or malformed declaration")
-		return "", []SyntaxError{*err}
+		return "", nil, []SyntaxError{*err}
 	}
 	// Sanity check declaration
-	if len(l.Elements) > 2 {
+	if len(l.Elements) < 2 || l.Elements[1].AsSymbol() == nil {
 		err := p.translator.SyntaxError(l, "malformed module declaration")
-		return "", []SyntaxError{*err}
+		return "", nil, []SyntaxError{*err}
 	}
-	// Extract column name
+	// Extract module name
 	name := l.Elements[1].AsSymbol().Value
+	// Check for template instance
+	if len(l.Elements) > 2 {
+		decls, errs := p.parseModuleInstance(util.NewAbsolutePath(name), l)
+		return name, decls, errs
+	}
 	//
-	return name, nil
+	return name, nil, nil
+}
+
+// Parse an include directive of the form "(include "file.lisp")".  Include
+// directives are not declarations as such and, instead, are passed to the
+// includer for resolution.  Since declarations in an included file are parsed
+// independently of the including file, include directives must precede the
+// first module declaration (i.e. otherwise, it would be unclear which module
+// any declarations outside a module in the included file belong to).
+func (p *Parser) parseInclude(module util.Path, s *sexp.List) []SyntaxError {
+	if s.Len() != 2 {
+		return p.translator.SyntaxErrors(s, "malformed include")
+	} else if module.Depth() != 0 {
+		return p.translator.SyntaxErrors(s, "include not permitted after module declaration")
+	}
+	//
+	path := s.Elements[1].AsSymbol()
+	// Check path is a non-empty string
+	if path == nil || len(path.Value) <= 2 || !strings.HasPrefix(path.Value, "\"") ||
+		!strings.HasSuffix(path.Value, "\"") {
+		return p.translator.SyntaxErrors(s.Elements[1], "invalid include path")
+	} else if p.includer == nil {
+		return p.translator.SyntaxErrors(s, "include not permitted")
+	} else if err := p.includer(path.Value[1 : len(path.Value)-1]); err != nil {
+		return p.translator.SyntaxErrors(s, err.Error())
+	}
+	//
+	return nil
 }
 
 func (p *Parser) parseDeclaration(module util.Path, s *sexp.List) (ast.Declaration, []SyntaxError) {
@@ -248,8 +358,12 @@ func (p *Parser) parseDeclaration(module util.Path, s *sexp.List) (ast.Declarati
 		decl, errors = p.parseDefColumns(module, s)
 	} else if s.Len() == 3 && s.MatchSymbols(1, "defcomputed") {
 		decl, errors = p.parseDefComputed(module, s.Elements)
+	} else if s.Len() == 3 && s.MatchSymbols(1, "defcomputedcolumn") {
+		decl, errors = p.parseDefComputedColumn(module, s.Elements)
 	} else if s.Len() > 1 && s.MatchSymbols(1, "defconst") {
 		decl, errors = p.parseDefConst(module, s.Elements)
+	} else if s.Len() == 2 && s.MatchSymbols(1, "defconstassert") {
+		decl, errors = p.parseDefConstAssert(s.Elements)
 	} else if s.Len() == 4 && s.MatchSymbols(2, "defconstraint") {
 		decl, errors = p.parseDefConstraint(module, s.Elements)
 	} else if s.MatchSymbols(1, "defunalias") {
@@ -260,9 +374,11 @@ func (p *Parser) parseDeclaration(module util.Path, s *sexp.List) (ast.Declarati
 		decl, errors = p.parseDefFun(module, false, s.Elements)
 	} else if s.Len() == 3 && s.MatchSymbols(1, "definrange") {
 		decl, errors = p.parseDefInRange(s.Elements)
+	} else if s.Len() == 3 && s.MatchSymbols(1, "in-enum") {
+		decl, errors = p.parseDefInEnum(s.Elements)
 	} else if s.Len() == 3 && s.MatchSymbols(1, "definterleaved") {
 		decl, errors = p.parseDefInterleaved(module, s.Elements)
-	} else if s.Len() == 4 && s.MatchSymbols(1, "deflookup") {
+	} else if s.Len() >= 4 && s.MatchSymbols(1, "deflookup") {
 		decl, errors = p.parseDefLookup(s.Elements)
 	} else if s.Len() == 3 && s.MatchSymbols(2, "defpermutation") {
 		decl, errors = p.parseDefPermutation(module, s.Elements)
@@ -354,6 +470,7 @@ func (p *Parser) parseColumnDeclaration(context util.Path, path util.Path, compu
 		multiplier uint
 		datatype   ast.Type
 		mustProve  bool
+		display    tr.Display = tr.NewDisplay(tr.DISPLAY_HEX)
 	)
 	// Set defaults for input columns
 	if !computed {
@@ -372,24 +489,26 @@ func (p *Parser) parseColumnDeclaration(context util.Path, path util.Path, compu
 		// Column name is always first
 		name = *path.Extend(l.Elements[0].String(false))
 		//	Parse type (if applicable)
-		if datatype, mustProve, error = p.parseColumnDeclarationAttributes(l.Elements[1:]); error != nil {
+		if datatype, mustProve, display, error = p.parseColumnDeclarationAttributes(l.Elements[1:]); error != nil {
 			return nil, error
 		}
 	} else {
 		name = *path.Extend(e.String(false))
 	}
 	//
-	def := ast.NewDefColumn(context, name, datatype, mustProve, multiplier, computed)
+	def := ast.NewDefColumn(context, name, datatype, mustProve, multiplier, computed, display)
 	// Update source mapping
 	p.mapSourceNode(e, def)
 	//
 	return def, nil
 }
 
-func (p *Parser) parseColumnDeclarationAttributes(attrs []sexp.SExp) (ast.Type, bool, *SyntaxError) {
+func (p *Parser) parseColumnDeclarationAttributes(attrs []sexp.SExp) (ast.Type, bool, tr.Display, *SyntaxError) {
 	var (
-		dataType  ast.Type = ast.NewFieldType()
-		mustProve bool     = false
+		dataType  ast.Type   = ast.NewFieldType()
+		mustProve bool       = false
+		display   tr.Display = tr.NewDisplay(tr.DISPLAY_HEX)
+		ok        bool
 		array_min uint
 		array_max uint
 		err       *SyntaxError
@@ -400,44 +519,51 @@ func (p *Parser) parseColumnDeclarationAttributes(attrs []sexp.SExp) (ast.Type,
 		symbol := ith.AsSymbol()
 		// Sanity check
 		if symbol == nil {
-			return nil, false, p.translator.SyntaxError(ith, "unknown column attribute")
+			return nil, false, display, p.translator.SyntaxError(ith, "unknown column attribute")
 		}
 		//
 		switch symbol.Value {
 		case ":display":
-			// skip these for now, as they are only relevant to the inspector.
 			if i+1 == len(attrs) {
-				return nil, false, p.translator.SyntaxError(ith, "incomplete display definition")
+				return nil, false, display, p.translator.SyntaxError(ith, "incomplete display definition")
 			} else if attrs[i+1].AsSymbol() == nil {
-				return nil, false, p.translator.SyntaxError(ith, "malformed display definition")
+				return nil, false, display, p.translator.SyntaxError(ith, "malformed display definition")
 			}
 			// Check what display attribute we have
-			switch attrs[i+1].AsSymbol().String(false) {
-			case ":dec", ":hex", ":bytes", ":opcode":
-				// all good
-				i = i + 1
-			default:
-				// not good
-				return nil, false, p.translator.SyntaxError(ith, "unknown display definition")
+			name := attrs[i+1].AsSymbol().String(false)
+			//
+			if !strings.HasPrefix(name, ":") {
+				return nil, false, display, p.translator.SyntaxError(ith, "unknown display definition")
+			} else if display, ok = tr.ParseDisplay(name[1:]); !ok {
+				return nil, false, display, p.translator.SyntaxError(ith, "unknown display definition")
 			}
+			// skip display mode
+			i = i + 1
 		case ":array":
 			if array_min, array_max, err = p.parseArrayDimension(attrs[i+1]); err != nil {
-				return nil, false, err
+				return nil, false, display, err
 			}
 			// skip dimension
 			i++
 		default:
-			if dataType, mustProve, err = p.parseType(ith); err != nil {
-				return nil, false, err
+			if enum := p.enumType(symbol); enum != nil {
+				// Enumeration type
+				dataType, display = enum.datatype, tr.NewEnumDisplay(enum.values)
+			} else if dataType, mustProve, err = p.parseType(ith); err != nil {
+				return nil, false, display, err
 			}
 		}
 	}
+	// Signed columns display negative values as such
+	if underlying := dataType.AsUnderlying(); underlying != nil && underlying.AsInt() != nil {
+		display.Signed = true
+	}
 	// Done
 	if array_max != 0 {
-		return ast.NewArrayType(dataType, array_min, array_max), mustProve, nil
+		return ast.NewArrayType(dataType, array_min, array_max), mustProve, display, nil
 	}
 	//
-	return dataType, mustProve, nil
+	return dataType, mustProve, display, nil
 }
 
 func (p *Parser) parseArrayDimension(s sexp.SExp) (uint, uint, *SyntaxError) {
@@ -519,6 +645,30 @@ func (p *Parser) parseDefComputed(module util.Path, elements []sexp.SExp) (ast.D
 	return &ast.DefComputed{Targets: targets, Function: sources[0], Sources: sources[1:]}, nil
 }
 
+// Parse a defcomputedcolumn declaration
+func (p *Parser) parseDefComputedColumn(module util.Path, elements []sexp.SExp) (ast.Declaration, []SyntaxError) {
+	var (
+		errors []SyntaxError
+		target *ast.DefColumn
+		err    *SyntaxError
+	)
+	// Parse target declaration
+	if target, err = p.parseColumnDeclaration(module, module, true, elements[1]); err != nil {
+		errors = append(errors, *err)
+	} else if _, ok := target.Binding().(*ast.ColumnBinding).DataType.(*ast.ArrayType); ok {
+		errors = append(errors, *p.translator.SyntaxError(elements[1], "array type not permitted"))
+	}
+	// Parse computation
+	computation, errs := p.translator.Translate(elements[2])
+	errors = append(errors, errs...)
+	//
+	if len(errors) > 0 {
+		return nil, errors
+	}
+	//
+	return &ast.DefComputedColumn{Target: target, Computation: computation}, nil
+}
+
 // Parse a constant declaration
 func (p *Parser) parseDefConst(module util.Path, elements []sexp.SExp) (ast.Declaration, []SyntaxError) {
 	var (
@@ -663,30 +813,39 @@ func (p *Parser) parseDefInterleavedSourceArray(source *sexp.Array) (ast.TypedSy
 // Parse a lookup declaration
 func (p *Parser) parseDefLookup(elements []sexp.SExp) (ast.Declaration, []SyntaxError) {
 	var (
-		errors  []SyntaxError
-		sources []ast.Expr
-		targets []ast.Expr
+		errors         []SyntaxError
+		sources        []ast.Expr
+		targets        []ast.Expr
+		sourceSelector ast.Expr
+		targetSelector ast.Expr
+		n              = len(elements)
 	)
 	// Extract items
 	handle := elements[1]
-	sexpTargets := elements[2].AsList()
-	sexpSources := elements[3].AsList()
+	sexpTargets := elements[n-2].AsList()
+	sexpSources := elements[n-1].AsList()
 	// Check Handle
 	if !isIdentifier(handle) {
 		errors = append(errors, *p.translator.SyntaxError(elements[1], "malformed handle"))
 	}
+	// Parse selectors (if applicable)
+	if n > 4 {
+		var errs []SyntaxError
+		sourceSelector, targetSelector, errs = p.parseLookupAttributes(elements[2 : n-2])
+		errors = append(errors, errs...)
+	}
 	// Check target expressions
 	if sexpTargets == nil {
-		errors = append(errors, *p.translator.SyntaxError(elements[2], "malformed target columns"))
+		errors = append(errors, *p.translator.SyntaxError(elements[n-2], "malformed target columns"))
 	}
 	// Check source Expressions
 	if sexpSources == nil {
-		errors = append(errors, *p.translator.SyntaxError(elements[3], "malformed source columns"))
+		errors = append(errors, *p.translator.SyntaxError(elements[n-1], "malformed source columns"))
 	}
 	// Sanity check number of columns matches
 	if sexpTargets != nil && sexpSources != nil {
 		if sexpTargets.Len() != sexpSources.Len() {
-			errors = append(errors, *p.translator.SyntaxError(elements[3], "incorrect number of columns"))
+			errors = append(errors, *p.translator.SyntaxError(elements[n-1], "incorrect number of columns"))
 		} else {
 			sources = make([]ast.Expr, sexpSources.Len())
 			targets = make([]ast.Expr, sexpTargets.Len())
@@ -707,7 +866,54 @@ func (p *Parser) parseDefLookup(elements []sexp.SExp) (ast.Declaration, []Syntax
 		return nil, errors
 	}
 	// Done
-	return ast.NewDefLookup(handle.AsSymbol().Value, sources, targets), nil
+	return ast.NewDefLookup(handle.AsSymbol().Value, sourceSelector, sources, targetSelector, targets), nil
+}
+
+// Parse the attributes of a lookup declaration.  Currently, these are limited
+// to the (optional) source selector (":guard") and target selector
+// (":target-guard").
+func (p *Parser) parseLookupAttributes(attrs []sexp.SExp) (ast.Expr, ast.Expr, []SyntaxError) {
+	var (
+		errors         []SyntaxError
+		sourceSelector ast.Expr
+		targetSelector ast.Expr
+	)
+	// Process each attribute in turn
+	for i := 0; i < len(attrs); i++ {
+		var (
+			ith  = attrs[i]
+			errs []SyntaxError
+		)
+		// Check start of attribute
+		if ith.AsSymbol() == nil {
+			errs = p.translator.SyntaxErrors(ith, "malformed attribute")
+		} else if i+1 == len(attrs) {
+			errs = p.translator.SyntaxErrors(ith, "incomplete attribute")
+		} else {
+			switch ith.AsSymbol().Value {
+			case ":guard":
+				if sourceSelector != nil {
+					errs = p.translator.SyntaxErrors(ith, "duplicate guard")
+				} else {
+					sourceSelector, errs = p.translator.Translate(attrs[i+1])
+				}
+			case ":target-guard":
+				if targetSelector != nil {
+					errs = p.translator.SyntaxErrors(ith, "duplicate guard")
+				} else {
+					targetSelector, errs = p.translator.Translate(attrs[i+1])
+				}
+			default:
+				errs = p.translator.SyntaxErrors(ith, "unknown attribute")
+			}
+			// skip attribute value
+			i++
+		}
+		//
+		errors = append(errors, errs...)
+	}
+	//
+	return sourceSelector, targetSelector, errors
 }
 
 // Parse a permutation declaration
@@ -1005,6 +1211,18 @@ func (p *Parser) parseFunctionParameter(element sexp.SExp) (*ast.DefParameter, [
 	return ast.NewDefParameter(list.Get(0).AsSymbol().Value, datatype), nil
 }
 
+// Parse a constant assertion declaration
+func (p *Parser) parseDefConstAssert(elements []sexp.SExp) (ast.Declaration, []SyntaxError) {
+	// Translate assertion
+	expr, errors := p.translator.Translate(elements[1])
+	// Error check
+	if len(errors) != 0 {
+		return nil, errors
+	}
+	// Done
+	return ast.NewDefConstAssert(expr), nil
+}
+
 // Parse a range declaration
 func (p *Parser) parseDefInRange(elements []sexp.SExp) (ast.Declaration, []SyntaxError) {
 	var bound fr.Element
@@ -1024,13 +1242,13 @@ func (p *Parser) parseDefInRange(elements []sexp.SExp) (ast.Declaration, []Synta
 	return &ast.DefInRange{Expr: expr, Bound: bound}, nil
 }
 
-func (p *Parser) parseConstraintAttributes(module util.Path, attributes sexp.SExp) (domain util.Option[int],
+func (p *Parser) parseConstraintAttributes(module util.Path, attributes sexp.SExp) (domain util.Option[[]int],
 	guard ast.Expr, perspective *ast.PerspectiveName, err []SyntaxError) {
 	//
 	var errors []SyntaxError
 	// Check attribute list is a list
 	if attributes.AsList() == nil {
-		return util.None[int](), nil, nil, p.translator.SyntaxErrors(attributes, "expected attribute list")
+		return util.None[[]int](), nil, nil, p.translator.SyntaxErrors(attributes, "expected attribute list")
 	}
 	// Deconstruct as list
 	attrs := attributes.AsList()
@@ -1064,7 +1282,7 @@ func (p *Parser) parseConstraintAttributes(module util.Path, attributes sexp.SEx
 	}
 	// Error Check
 	if len(errors) != 0 {
-		return util.None[int](), nil, nil, errors
+		return util.None[[]int](), nil, nil, errors
 	}
 	// Done
 	return domain, guard, perspective, nil
@@ -1086,33 +1304,89 @@ func parseSymbolName[T ast.Binding](p *Parser, symbol sexp.SExp, module util.Pat
 	return name, nil
 }
 
-func (p *Parser) parseDomainAttribute(attribute sexp.SExp) (domain util.Option[int], err []SyntaxError) {
+// Parse a domain attribute, which is a set of one or more rows (e.g. {0 1 -1})
+// and/or row ranges (e.g. {0..3}).  Negative rows are taken from the end of the
+// trace, where -1 represents the last row.  Thus, a range must either be
+// entirely non-negative or entirely negative.  The resulting rows are sorted,
+// with duplicates removed.
+func (p *Parser) parseDomainAttribute(attribute sexp.SExp) (domain util.Option[[]int], err []SyntaxError) {
+	var rows []int
+	//
 	if attribute.AsSet() == nil {
-		return util.None[int](), p.translator.SyntaxErrors(attribute, "malformed domain set")
+		return util.None[[]int](), p.translator.SyntaxErrors(attribute, "malformed domain set")
 	}
 	// Sanity check
 	set := attribute.AsSet()
-	// Check all domain elements well-formed.
+	// Parse each domain element in turn
 	for i := 0; i < set.Len(); i++ {
 		ith := set.Get(i)
 		if ith.AsSymbol() == nil {
-			return util.None[int](), p.translator.SyntaxErrors(ith, "malformed domain")
+			return util.None[[]int](), p.translator.SyntaxErrors(ith, "malformed domain")
 		}
-	}
-	// Currently, only support domains of size 1.
-	if set.Len() == 1 {
-		first, err := strconv.Atoi(set.Get(0).AsSymbol().Value)
+		//
+		first, last, err := parseDomainElement(ith.AsSymbol().Value)
 		// Check for parse error
 		if err != nil {
-			return util.None[int](), p.translator.SyntaxErrors(set.Get(0), "malformed domain element")
+			return util.None[[]int](), p.translator.SyntaxErrors(ith, err.Error())
+		} else if last-first >= MAX_DOMAIN_SIZE-len(rows) {
+			// NOTE: rows are checked before expansion, since a large range
+			// could otherwise exhaust memory.
+			msg := fmt.Sprintf("domain too large (exceeds %d rows)", MAX_DOMAIN_SIZE)
+			return util.None[[]int](), p.translator.SyntaxErrors(ith, msg)
+		}
+		//
+		for row := first; row <= last; row++ {
+			rows = append(rows, row)
 		}
-		// Done
-		return util.Some(first), nil
 	}
-	// Fail
-	return util.None[int](), p.translator.SyntaxErrors(attribute, "multiple values not supported")
+	// Sanity check
+	if len(rows) == 0 {
+		return util.None[[]int](), p.translator.SyntaxErrors(attribute, "empty domain")
+	}
+	// Sort and remove duplicates
+	slices.Sort(rows)
+	// Done
+	return util.Some(slices.Compact(rows)), nil
+}
+
+// MAX_DOMAIN_SIZE is the largest number of rows permitted in the domain of a
+// constraint.  Since a domain is expanded into its individual rows, this
+// prevents large ranges from exhausting memory.
+const MAX_DOMAIN_SIZE = 1024
+
+// Parse an individual element of a domain set, which is either a single row
+// (e.g. "-1") or an inclusive range of rows (e.g. "0..3").
+func parseDomainElement(element string) (int, int, error) {
+	var (
+		first, last int
+		err         error
+	)
+	//
+	if before, after, found := strings.Cut(element, ".."); !found {
+		// Single row
+		if first, err = strconv.Atoi(element); err != nil {
+			return 0, 0, errors.New("malformed domain element")
+		}
+		//
+		return first, first, nil
+	} else if first, err = strconv.Atoi(before); err != nil {
+		return 0, 0, errors.New("malformed domain range")
+	} else if last, err = strconv.Atoi(after); err != nil {
+		return 0, 0, errors.New("malformed domain range")
+	} else if (first < 0) != (last < 0) {
+		return 0, 0, errors.New("domain range cannot mix positive and negative rows")
+	} else if first > last {
+		return 0, 0, errors.New("empty domain range")
+	}
+	//
+	return first, last, nil
 }
 
+// MAX_SIGNED_BITWIDTH is the largest bitwidth permitted for a signed type.  This
+// ensures every value of the type (after applying its offset) is strictly less
+// than the field modulus.
+const MAX_SIGNED_BITWIDTH = 252
+
 func (p *Parser) parseType(term sexp.SExp) (ast.Type, bool, *SyntaxError) {
 	symbol := term.AsSymbol()
 	if symbol == nil {
@@ -1135,18 +1409,24 @@ func (p *Parser) parseType(term sexp.SExp) (ast.Type, bool, *SyntaxError) {
 		//
 		datatype = ast.NewFieldType()
 	default:
-		// Handle generic types like i16, i128, etc.
+		// Handle generic types like i16, i128, u32, s64, etc.  Observe that,
+		// for now, both "i" and "u" forms denote unsigned integers, whilst the
+		// "s" form denotes signed integers.
 		str := parts[0]
-		if !strings.HasPrefix(str, ":i") {
+		if !strings.HasPrefix(str, ":i") && !strings.HasPrefix(str, ":u") && !strings.HasPrefix(str, ":s") {
 			return nil, false, p.translator.SyntaxError(symbol, "unknown type")
 		}
 		// Parse bitwidth
 		n, err := strconv.Atoi(str[2:])
 		if err != nil {
 			return nil, false, p.translator.SyntaxError(symbol, err.Error())
+		} else if str[1] != 's' {
+			datatype = ast.NewUintType(uint(n))
+		} else if n < 1 || n > MAX_SIGNED_BITWIDTH {
+			return nil, false, p.translator.SyntaxError(symbol, "invalid signed bitwidth")
+		} else {
+			datatype = ast.NewIntType(uint(n))
 		}
-		// Done
-		datatype = ast.NewUintType(uint(n))
 	}
 	// Types not proven unless explicitly requested
 	var proven bool = false
@@ -1402,6 +1682,26 @@ func mulParserRule(_ string, args []ast.Expr) (ast.Expr, error) {
 	return &ast.Mul{Args: args}, nil
 }
 
+// Construct a parser rule for a given kind of constant operation.  Division,
+// remainder and shifts are binary, whilst bitwise operations accept one or
+// more arguments.
+func constOpParserRule(kind uint8) func(string, []ast.Expr) (ast.Expr, error) {
+	return func(_ string, args []ast.Expr) (ast.Expr, error) {
+		switch kind {
+		case ast.CONST_BAND, ast.CONST_BOR, ast.CONST_BXOR:
+			if len(args) == 0 {
+				return nil, errors.New("incorrect number of arguments")
+			}
+		default:
+			if len(args) != 2 {
+				return nil, errors.New("incorrect number of arguments")
+			}
+		}
+		// Done
+		return &ast.ConstOp{Kind: kind, Args: args}, nil
+	}
+}
+
 func ifParserRule(_ string, args []ast.Expr) (ast.Expr, error) {
 	if len(args) == 2 {
 		return &ast.If{Kind: 0, Condition: args[0], TrueBranch: args[1], FalseBranch: nil}, nil
@@ -1566,7 +1866,7 @@ func isIdentifierStart(c rune) bool {
 }
 
 func isIdentifierMiddle(c rune) bool {
-	return unicode.IsDigit(c) || isIdentifierStart(c) || c == '-' || c == '!' || c == '@'
+	return unicode.IsDigit(c) || isIdentifierStart(c) || c == '-' || c == '!' || c == '@' || c == '#'
 }
 
 func isFunctionIdentifierStart(c rune) bool {
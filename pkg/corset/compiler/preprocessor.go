@@ -67,12 +67,18 @@ func (p *preprocessor) preprocessDeclaration(decl ast.Declaration) []SyntaxError
 		// ignore
 	case *ast.DefComputed:
 		// ignore
+	case *ast.DefComputedColumn:
+		errors = p.preprocessDefComputedColumn(d)
 	case *ast.DefConst:
 		// ignore
+	case *ast.DefConstAssert:
+		// ignore
 	case *ast.DefConstraint:
 		errors = p.preprocessDefConstraint(d)
 	case *ast.DefFun:
 		// ignore
+	case *ast.DefInEnum:
+		errors = p.preprocessDefInEnum(d)
 	case *ast.DefInRange:
 		errors = p.preprocessDefInRange(d)
 	case *ast.DefInterleaved:
@@ -118,16 +124,31 @@ func (p *preprocessor) preprocessDefConstraint(decl *ast.DefConstraint) []Syntax
 //nolint:staticcheck
 func (p *preprocessor) preprocessDefLookup(decl *ast.DefLookup) []SyntaxError {
 	var (
-		source_errs []SyntaxError
-		target_errs []SyntaxError
+		source_errs   []SyntaxError
+		target_errs   []SyntaxError
+		selector_errs []SyntaxError
 	)
 	// preprocess source expressions
 	decl.Sources, source_errs = p.preprocessExpressionsInModule(decl.Sources)
 	decl.Targets, target_errs = p.preprocessExpressionsInModule(decl.Targets)
+	// preprocess (optional) selectors
+	decl.SourceSelector, selector_errs = p.preprocessOptionalExpressionInModule(decl.SourceSelector)
+	source_errs = append(source_errs, selector_errs...)
+	decl.TargetSelector, selector_errs = p.preprocessOptionalExpressionInModule(decl.TargetSelector)
+	target_errs = append(target_errs, selector_errs...)
 	// Combine errors
 	return append(source_errs, target_errs...)
 }
 
+// preprocess an "in-enum" declaration.
+func (p *preprocessor) preprocessDefInEnum(decl *ast.DefInEnum) []SyntaxError {
+	var errors []SyntaxError
+	// preprocess constrained expression
+	decl.Expr, errors = p.preprocessExpressionInModule(decl.Expr)
+	// Done
+	return errors
+}
+
 // preprocess a "definrange" declaration.
 func (p *preprocessor) preprocessDefInRange(decl *ast.DefInRange) []SyntaxError {
 	var errors []SyntaxError
@@ -137,6 +158,15 @@ func (p *preprocessor) preprocessDefInRange(decl *ast.DefInRange) []SyntaxError
 	return errors
 }
 
+// preprocess a "defcomputedcolumn" declaration.
+func (p *preprocessor) preprocessDefComputedColumn(decl *ast.DefComputedColumn) []SyntaxError {
+	var errors []SyntaxError
+	// preprocess computation
+	decl.Computation, errors = p.preprocessExpressionInModule(decl.Computation)
+	// Done
+	return errors
+}
+
 // preprocess a "defperspective" declaration.
 func (p *preprocessor) preprocessDefPerspective(decl *ast.DefPerspective) []SyntaxError {
 	var errors []SyntaxError
@@ -252,6 +282,8 @@ func (p *preprocessor) preprocessExpressionInModule(expr ast.Expr) (ast.Expr, []
 		nexpr, errors = &ast.Add{Args: args}, errs
 	case *ast.Constant:
 		return e, nil
+	case *ast.ConstOp:
+		return p.preprocessConstOpInModule(e)
 	case *ast.Debug:
 		if p.debug {
 			return p.preprocessExpressionInModule(e.Arg)
@@ -327,6 +359,34 @@ func (p *preprocessor) preprocessForInModule(expr *ast.For) (ast.Expr, []SyntaxE
 	return &ast.List{Args: args}, nil
 }
 
+// Preprocess a constant operation which, where possible, is reduced to a
+// constant.  This is not always possible at this stage, since arguments may not
+// be constant (e.g. after inlining a function applied to a column).  Such cases
+// are reported during translation.
+func (p *preprocessor) preprocessConstOpInModule(expr *ast.ConstOp) (ast.Expr, []SyntaxError) {
+	var nexpr ast.Expr
+	//
+	args, errors := p.preprocessExpressionsInModule(expr.Args)
+	// Error check
+	if len(errors) > 0 {
+		return nil, errors
+	}
+	//
+	op := &ast.ConstOp{Kind: expr.Kind, Args: args}
+	//
+	if val := op.AsConstant(); val != nil {
+		nexpr = &ast.Constant{Val: *val}
+	} else if op.IsUndefined() {
+		return nil, p.srcmap.SyntaxErrors(expr, undefinedConstOp(expr.Kind))
+	} else {
+		nexpr = op
+	}
+	// Copy over source information
+	p.srcmap.Copy(expr, nexpr)
+	//
+	return nexpr, nil
+}
+
 func (p *preprocessor) preprocessLetInModule(expr *ast.Let) (ast.Expr, []SyntaxError) {
 	var (
 		mapping map[uint]ast.Expr = make(map[uint]ast.Expr)
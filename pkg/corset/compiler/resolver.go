@@ -265,6 +265,9 @@ func (r *resolver) declarationDependenciesAreFinalised(scope *ModuleScope,
 			// symbols within this declaration.
 			scope = scope.Enter(dc.Perspective.Name())
 		}
+	} else if dc, ok := decl.(*ast.DefConst); ok && dc.Parameters {
+		// Template arguments are resolved in the instantiating scope.
+		scope = instantiatingScope(scope)
 	}
 	//
 	for iter := decl.Dependencies(); iter.HasNext(); {
@@ -296,12 +299,22 @@ func (r *resolver) finaliseDeclaration(scope *ModuleScope, decl ast.Declaration)
 	switch d := decl.(type) {
 	case *ast.DefComputed:
 		return r.finaliseDefComputedInModule(d)
+	case *ast.DefComputedColumn:
+		return r.finaliseDefComputedColumnInModule(scope, d)
 	case *ast.DefConst:
+		if d.Parameters {
+			return r.finaliseDefConstInModule(instantiatingScope(scope), d)
+		}
+		//
 		return r.finaliseDefConstInModule(scope, d)
+	case *ast.DefConstAssert:
+		return r.finaliseDefConstAssertInModule(scope, d)
 	case *ast.DefConstraint:
 		return r.finaliseDefConstraintInModule(scope, d)
 	case *ast.DefFun:
 		return r.finaliseDefFunInModule(scope, d)
+	case *ast.DefInEnum:
+		return r.finaliseDefInEnumInModule(scope, d)
 	case *ast.DefInRange:
 		return r.finaliseDefInRangeInModule(scope, d)
 	case *ast.DefInterleaved:
@@ -362,9 +375,48 @@ func (r *resolver) finaliseDefComputedInModule(decl *ast.DefComputed) []SyntaxEr
 	return errors
 }
 
+// Finalise a computed column declaration.  This requires resolving the
+// expression which determines the column's values, from which the column's
+// length multiplier is then inferred.  If no type is given for the computed
+// column, then it defaults to the field type.
+func (r *resolver) finaliseDefComputedColumnInModule(enclosing Scope, decl *ast.DefComputedColumn) []SyntaxError {
+	var (
+		scope      = NewLocalScope(enclosing, false, false)
+		target     = decl.Target.Binding().(*ast.ColumnBinding)
+		multiplier = uint(1)
+		datatype   = target.DataType
+	)
+	// Resolve computation
+	if errors := r.finaliseExpressionInModule(scope, decl.Computation); len(errors) > 0 {
+		return errors
+	}
+	// Sanity check computation does not depend upon itself
+	for _, dep := range decl.Computation.Dependencies() {
+		if decl.Defines(dep) {
+			return r.srcmap.SyntaxErrors(dep, "computed column cannot depend upon itself")
+		}
+	}
+	// Determine length multiplier from context of computation.  Observe that
+	// conflicting contexts (or accesses to columns outside the enclosing
+	// module) have already been reported during resolution.
+	if context := decl.Computation.Context(); !context.IsVoid() {
+		multiplier = context.LengthMultiplier()
+	}
+	// Apply default type (if applicable)
+	if datatype == nil {
+		datatype = ast.NewFieldType()
+	}
+	// Finalise column binding
+	target.Finalise(multiplier, datatype)
+	// Done
+	return nil
+}
+
 // Finalise one or more constant definitions within a given module.
 // Specifically, we need to check that the constant values provided are indeed
-// constants.
+// constants.  Since constants within the same declaration may refer to each
+// other, they are finalised in dependency order and any which remain indicate
+// a cyclic definition.
 func (r *resolver) finaliseDefConstInModule(enclosing Scope, decl *ast.DefConst) []SyntaxError {
 	var errors []SyntaxError
 	//
@@ -374,20 +426,76 @@ func (r *resolver) finaliseDefConstInModule(enclosing Scope, decl *ast.DefConst)
 		errs := r.finaliseExpressionInModule(scope, c.ConstBinding.Value)
 		// Accumulate errors
 		errors = append(errors, errs...)
-		if len(errors) == 0 {
-			// Check it is indeed constant!
-			if constant := c.ConstBinding.Value.AsConstant(); constant != nil {
-				// Finalise constant binding.  Note, no need to register a syntax
-				// error for the error case, because it would have already been
-				// accounted for during resolution.
+	}
+	//
+	if len(errors) > 0 {
+		return errors
+	}
+	// Iterate constants to fixed point (i.e. until no more can be finalised)
+	for changed := true; changed; {
+		changed = false
+		//
+		for _, c := range decl.Constants {
+			// Check it is indeed constant!  Note, no need to register a syntax
+			// error for the error case, because it would have already been
+			// accounted for during resolution.
+			if !c.ConstBinding.IsFinalised() && constantDependenciesAreFinalised(c) &&
+				c.ConstBinding.Value.AsConstant() != nil {
+				// Finalise constant binding.
 				c.ConstBinding.Finalise()
+				//
+				changed = true
 			}
 		}
 	}
+	// Check for any constants which could not be finalised.
+	for _, c := range decl.Constants {
+		if !constantDependenciesAreFinalised(c) {
+			errors = append(errors, *r.srcmap.SyntaxError(c, "cyclic declaration"))
+		}
+	}
 	//
 	return errors
 }
 
+// Check whether all constants used in the definition of a given constant have
+// themselves been finalised.
+func constantDependenciesAreFinalised(decl *ast.DefConstUnit) bool {
+	for _, dep := range decl.ConstBinding.Value.Dependencies() {
+		if binding, ok := dep.Binding().(*ast.ConstantBinding); ok && !binding.IsFinalised() {
+			return false
+		}
+	}
+	//
+	return true
+}
+
+// Determine the scope in which a module template instance is declared, given
+// the scope of the instance itself.  Since module declarations are not nested,
+// this is the enclosing scope of the instance.
+func instantiatingScope(scope *ModuleScope) *ModuleScope {
+	if scope.parent != nil {
+		return scope.parent
+	}
+	//
+	return scope
+}
+
+// Finalise a constant assertion after all symbols have been resolved.  This
+// involves checking the assertion refers only to constants (i.e. that it is
+// resolved within a pure context).
+func (r *resolver) finaliseDefConstAssertInModule(enclosing Scope, decl *ast.DefConstAssert) []SyntaxError {
+	var scope = NewLocalScope(enclosing, false, true)
+	// Resolve assertion
+	errors := r.finaliseExpressionInModule(scope, decl.Assertion)
+	// Error check
+	if len(errors) == 0 {
+		decl.Finalise()
+	}
+	// Done
+	return errors
+}
+
 // Finalise a vanishing constraint declaration after all symbols have been
 // resolved. This involves: (a) checking the context is valid; (b) checking the
 // expressions are well-typed.
@@ -477,6 +585,8 @@ func (r *resolver) finaliseDefPermutationInModule(decl *ast.DefPermutation) []Sy
 		if source, ok := ith.Binding().(*ast.ColumnBinding); !ok {
 			errors = append(errors, *r.srcmap.SyntaxError(ith, "invalid source column"))
 			return errors
+		} else if !started && source.DataType.AsUnderlying().AsInt() != nil {
+			errors = append(errors, *r.srcmap.SyntaxError(ith, "unsigned type required"))
 		} else if !started && source.DataType.AsUnderlying().AsUint() == nil {
 			errors = append(errors, *r.srcmap.SyntaxError(ith, "fixed-width type required"))
 		} else if started && multiplier != source.Multiplier {
@@ -509,6 +619,21 @@ func (r *resolver) finaliseDefPerspectiveInModule(enclosing Scope, decl *ast.Def
 	return errors
 }
 
+// Finalise an enumeration membership constraint after all symbols have been
+// resolved.  This involves: (a) checking the context is valid; (b) checking the
+// expressions are well-typed.
+func (r *resolver) finaliseDefInEnumInModule(enclosing Scope, decl *ast.DefInEnum) []SyntaxError {
+	var scope = NewLocalScope(enclosing, false, false)
+	// Resolve constrained expression
+	errors := r.finaliseExpressionInModule(scope, decl.Expr)
+	// Error check
+	if len(errors) == 0 {
+		decl.Finalise()
+	}
+	// Done
+	return errors
+}
+
 // Finalise a range constraint declaration after all symbols have been
 // resolved. This involves: (a) checking the context is valid; (b) checking the
 // expressions are well-typed.
@@ -555,6 +680,16 @@ func (r *resolver) finaliseDefLookupInModule(enclosing Scope, decl *ast.DefLooku
 	source_errors := r.finaliseExpressionsInModule(sourceScope, decl.Sources)
 	// Resolve target expressions
 	target_errors := r.finaliseExpressionsInModule(targetScope, decl.Targets)
+	// Resolve selectors (if applicable).  Observe that these are resolved in
+	// the same scope as the expressions they select, thus ensuring their
+	// contexts are consistent.
+	if decl.SourceSelector != nil {
+		source_errors = append(source_errors, r.finaliseExpressionInModule(sourceScope, decl.SourceSelector)...)
+	}
+	//
+	if decl.TargetSelector != nil {
+		target_errors = append(target_errors, r.finaliseExpressionInModule(targetScope, decl.TargetSelector)...)
+	}
 	//
 	return append(source_errors, target_errors...)
 }
@@ -596,6 +731,8 @@ func (r *resolver) finaliseExpressionInModule(scope LocalScope, expr ast.Expr) [
 		return r.finaliseExpressionsInModule(scope, v.Args)
 	case *ast.Constant:
 		return nil
+	case *ast.ConstOp:
+		return r.finaliseExpressionsInModule(scope, v.Args)
 	case *ast.Debug:
 		return r.finaliseExpressionInModule(scope, v.Arg)
 	case *ast.Exp:
@@ -0,0 +1,129 @@
+package compiler
+
+import (
+	"slices"
+
+	"github.com/consensys/go-corset/pkg/corset/ast"
+	"github.com/consensys/go-corset/pkg/util"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+// moduleTemplate represents a parameterised module definition of the form
+// "(defmodule-template NAME (params...) decls...)".  A template is instantiated
+// by a module declaration of the form "(module inst NAME args...)", which
+// creates a module "inst" containing the declarations of the template.  Within
+// each instance, the template parameters are bound as constants of that
+// module and, hence, are scoped accordingly.
+type moduleTemplate struct {
+	// Name of this template.
+	name string
+	// Parameters of this template.
+	params []string
+	// Declarations making up the body of this template.
+	body []*sexp.List
+}
+
+// Parse a module template definition, and register it with the parse
+// environment.
+func (p *Parser) parseDefModuleTemplate(s *sexp.List) []SyntaxError {
+	var (
+		errors []SyntaxError
+		params *sexp.List
+		t      = &moduleTemplate{}
+	)
+	//
+	if s.Len() >= 3 {
+		params = s.Elements[2].AsList()
+	}
+	//
+	if params == nil {
+		return p.translator.SyntaxErrors(s, "malformed module template")
+	} else if !isIdentifier(s.Get(1)) {
+		return p.translator.SyntaxErrors(s.Get(1), "invalid module template name")
+	} else if _, ok := p.env.templates[s.Get(1).AsSymbol().Value]; ok {
+		return p.translator.SyntaxErrors(s.Get(1), "module template already defined")
+	}
+	//
+	t.name = s.Get(1).AsSymbol().Value
+	// Parse parameters
+	for _, ith := range params.Elements {
+		if !isIdentifier(ith) {
+			errors = append(errors, *p.translator.SyntaxError(ith, "invalid module template parameter"))
+		} else if slices.Contains(t.params, ith.AsSymbol().Value) {
+			errors = append(errors, *p.translator.SyntaxError(ith, "duplicate module template parameter"))
+		} else {
+			t.params = append(t.params, ith.AsSymbol().Value)
+		}
+	}
+	// Parse body
+	for _, ith := range s.Elements[3:] {
+		if l := ith.AsList(); l == nil {
+			errors = append(errors, *p.translator.SyntaxError(ith, "unexpected or malformed declaration"))
+		} else if l.MatchSymbols(1, "module") || l.MatchSymbols(1, "defmodule-template") {
+			errors = append(errors, *p.translator.SyntaxError(ith, "nested module not permitted"))
+		} else {
+			t.body = append(t.body, l)
+		}
+	}
+	//
+	if len(errors) == 0 {
+		p.env.templates[t.name] = t
+	}
+	//
+	return errors
+}
+
+// Parse a module instance declaration of the form "(module inst NAME args...)",
+// producing the declarations of the instance.  The template parameters are
+// bound as constants of the instance (to the corresponding arguments, which are
+// resolved in the instantiating scope rather than the instance), whilst
+// the declarations of the template are parsed afresh for each instance.  Nodes
+// of the template body retain their original spans where these are known and,
+// otherwise, are given the span of the module instance declaration.
+func (p *Parser) parseModuleInstance(path util.Path, s *sexp.List) ([]ast.Declaration, []SyntaxError) {
+	var (
+		errors []SyntaxError
+		decls  []ast.Declaration
+		span   = p.translator.SpanOf(s)
+	)
+	//
+	if s.Get(2).AsSymbol() == nil {
+		return nil, p.translator.SyntaxErrors(s.Get(2), "invalid module template name")
+	}
+	//
+	t, ok := p.env.templates[s.Get(2).AsSymbol().Value]
+	args := s.Elements[3:]
+	//
+	if !ok {
+		return nil, p.translator.SyntaxErrors(s.Get(2), "unknown module template")
+	} else if len(args) != len(t.params) {
+		return nil, p.translator.SyntaxErrors(s, "incorrect number of arguments")
+	}
+	// Bind parameters as constants of this instance
+	if len(args) > 0 {
+		elements := []sexp.SExp{p.copyTerm(sexp.NewSymbol("defconst"), span)}
+		//
+		for i, param := range t.params {
+			elements = append(elements, p.copyTerm(sexp.NewSymbol(param), p.translator.SpanOf(args[i])), args[i])
+		}
+		//
+		decl, errs := p.parseDefConst(path, elements)
+		//
+		if len(errs) > 0 {
+			return nil, errs
+		}
+		// Arguments are resolved in the instantiating scope
+		decl.(*ast.DefConst).Parameters = true
+		//
+		p.mapSourceNode(s, decl)
+		decls = append(decls, decl)
+	}
+	// Instantiate body
+	for _, ith := range t.body {
+		ds, errs := p.parseTopLevel(path, p.copyTerm(ith, span).AsList())
+		errors = append(errors, errs...)
+		decls = append(decls, ds...)
+	}
+	//
+	return decls, errors
+}
@@ -3,6 +3,8 @@ package compiler
 import (
 	"fmt"
 	"math"
+	"math/big"
+	"slices"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/go-corset/pkg/corset/ast"
@@ -23,7 +25,7 @@ import (
 func TranslateCircuit(env Environment, srcmap *sexp.SourceMaps[ast.Node],
 	circuit *ast.Circuit) (*hir.Schema, []SyntaxError) {
 	//
-	t := translator{env, srcmap, hir.EmptySchema()}
+	t := translator{env, srcmap, hir.EmptySchema(), nil}
 	// Allocate all modules into schema
 	t.translateModules(circuit)
 	// Translate input columns
@@ -32,6 +34,8 @@ func TranslateCircuit(env Environment, srcmap *sexp.SourceMaps[ast.Node],
 	if errs := t.translateOtherDeclarations(circuit); len(errs) > 0 {
 		return nil, errs
 	}
+	// Translate enumeration lookups
+	t.translateEnumLookups()
 	// Done
 	return t.schema, nil
 }
@@ -48,6 +52,24 @@ type translator struct {
 	srcmap *sexp.SourceMaps[ast.Node]
 	// Represents the schema being constructed by this translator.
 	schema *hir.Schema
+	// Membership constraints for non-contiguous enumerations.  These are
+	// translated last, since they require additional (fixed) columns which
+	// must follow all columns allocated by the environment.
+	enumLookups []enumLookup
+}
+
+// enumLookup represents a membership constraint for a non-contiguous
+// enumeration, which is translated as a lookup into a fixed table holding the
+// members of that enumeration.
+type enumLookup struct {
+	// Enumeration whose members the expression is constrained to.
+	enum *tr.Enumeration
+	// Members of the enumeration (in sorted order).
+	values []fr.Element
+	// Context of the constrained expression.
+	context tr.Context
+	// The constrained expression.
+	expr hir.Expr
 }
 
 func (t *translator) translateModules(circuit *ast.Circuit) {
@@ -95,7 +117,7 @@ func (t *translator) translateInputColumnsInModule(module string) {
 			panic("inactive register encountered")
 		} else if regInfo.IsInput() {
 			// Declare column at HIR level.
-			cid := t.schema.AddDataColumn(regInfo.Context, regInfo.Name(), regInfo.DataType)
+			cid := t.schema.AddDataColumn(regInfo.Context, regInfo.Name(), regInfo.DataType, regInfo.Display())
 			// Prove underlying types (as necessary)
 			t.translateTypeConstraints(regIndex)
 			// Sanity check
@@ -145,11 +167,11 @@ func (t *translator) translateTypeConstraints(regIndex uint) {
 	}
 	// Apply provability (if it is required)
 	if required {
-		reg_width := regInfo.DataType.AsUint().BitWidth()
+		reg_width := regInfo.DataType.BitWidth()
 		// For now, enforce all source columns have matching bitwidth.
 		for _, col := range regInfo.Sources {
 			// Determine bitwidth
-			col_width := col.datatype.AsUint().BitWidth()
+			col_width := col.datatype.BitWidth()
 			// Sanity check (for now)
 			if col_width != reg_width {
 				// See above discussion of why this strong restriction is
@@ -158,8 +180,16 @@ func (t *translator) translateTypeConstraints(regIndex uint) {
 			}
 		}
 		// Add appropriate type constraint
-		bound := regInfo.DataType.AsUint().Bound()
-		t.schema.AddRangeConstraint(regInfo.Name(), regInfo.Context, &hir.ColumnAccess{Column: regIndex, Shift: 0}, bound)
+		var expr hir.Expr = &hir.ColumnAccess{Column: regIndex, Shift: 0}
+		//
+		if int_t := regInfo.DataType.AsInt(); int_t != nil {
+			// Signed values are offset so that they fall within [0,2^n).
+			// For example, for s8 the range -128..127 becomes 0..255.
+			expr = &hir.Add{Args: []hir.Expr{expr, &hir.Constant{Val: int_t.Offset()}}}
+			t.schema.AddRangeConstraint(regInfo.Name(), regInfo.Context, expr, int_t.Bound())
+		} else {
+			t.schema.AddRangeConstraint(regInfo.Name(), regInfo.Context, expr, regInfo.DataType.AsUint().Bound())
+		}
 	}
 }
 
@@ -200,15 +230,21 @@ func (t *translator) translateDeclaration(decl ast.Declaration, module util.Path
 		// Not an assignment or a constraint, hence ignore.
 	case *ast.DefComputed:
 		errors = t.translateDefComputed(d, module)
+	case *ast.DefComputedColumn:
+		errors = t.translateDefComputedColumn(d, module)
 	case *ast.DefColumns:
 		// Not an assignment or a constraint, hence ignore.
 	case *ast.DefConst:
 		// For now, constants are always compiled out when going down to HIR.
+	case *ast.DefConstAssert:
+		// Constant assertions are checked at compile time, hence ignore.
 	case *ast.DefConstraint:
 		errors = t.translateDefConstraint(d, module)
 	case *ast.DefFun:
 		// For now, functions are always compiled out when going down to HIR.
 		// In the future, this might change if we add support for macros to HIR.
+	case *ast.DefInEnum:
+		errors = t.translateDefInEnum(d, module)
 	case *ast.DefInRange:
 		errors = t.translateDefInRange(d, module)
 	case *ast.DefInterleaved:
@@ -251,6 +287,7 @@ func (t *translator) translateDefComputed(decl *ast.DefComputed, module util.Pat
 		target := t.env.Register(targetId)
 		// Construct columns
 		targets[i] = sc.NewColumn(target.Context, target.Name(), target.DataType)
+		targets[i].Display = target.Display()
 		// Record first CID
 		if i == 0 {
 			firstCid = targetId
@@ -271,6 +308,39 @@ func (t *translator) translateDefComputed(decl *ast.DefComputed, module util.Pat
 	return errors
 }
 
+// Translate a "defcomputedcolumn" declaration.
+func (t *translator) translateDefComputedColumn(decl *ast.DefComputedColumn, module util.Path) []SyntaxError {
+	// Lookup target column info
+	targetPath := module.Extend(decl.Target.Name())
+	targetId := t.env.RegisterOf(targetPath)
+	target := t.env.Register(targetId)
+	// Translate computation
+	computation, errors := t.translateExpressionInModule(decl.Computation, module, 0)
+	//
+	if len(errors) > 0 {
+		return errors
+	}
+	// Register assignment
+	cid := t.schema.AddAssignment(assignment.NewComputedColumn(target.Context, target.Name(), target.DataType,
+		target.Display(), hir.NewUnitExpr(computation)))
+	// Sanity check column identifiers align.
+	if cid != targetId {
+		err := fmt.Sprintf("inconsistent (computed) column identifier (%d v %d)", cid, targetId)
+		errors = append(errors, *t.srcmap.SyntaxError(decl, err))
+	}
+	// Constrain target to match its computation (i.e. target - computation ==
+	// 0), since otherwise the prover is free to choose its values.  The handle
+	// uses a reserved suffix to prevent clashes with user-defined constraints.
+	access := &hir.ColumnAccess{Column: targetId, Shift: 0}
+	constraint := &hir.Sub{Args: []hir.Expr{access, computation}}
+	handle := fmt.Sprintf("%s:computed", target.Name())
+	t.schema.AddVanishingConstraint(handle, target.Context, util.None[[]int](), constraint)
+	// Prove underlying type (as necessary)
+	t.translateTypeConstraints(targetId)
+	// Done
+	return errors
+}
+
 // Translate a "defconstraint" declaration.
 func (t *translator) translateDefConstraint(decl *ast.DefConstraint, module util.Path) []SyntaxError {
 	// Translate constraint body
@@ -338,19 +408,131 @@ func (t *translator) translateDefLookup(decl *ast.DefLookup, module util.Path) [
 	// Translate source expressions
 	sources, src_errs := t.translateUnitExpressionsInModule(decl.Sources, module, 0)
 	targets, tgt_errs := t.translateUnitExpressionsInModule(decl.Targets, module, 0)
+	// Translate (optional) selectors
+	src_selector, src_sel_errs := t.translateOptionalSelectorInModule(decl.SourceSelector, module)
+	tgt_selector, tgt_sel_errs := t.translateOptionalSelectorInModule(decl.TargetSelector, module)
 	// Combine errors
 	errors := append(src_errs, tgt_errs...)
+	errors = append(errors, src_sel_errs...)
+	errors = append(errors, tgt_sel_errs...)
 	//
 	if len(errors) == 0 {
-		src_context := t.env.ContextOf(ast.ContextOfExpressions(decl.Sources))
-		target_context := t.env.ContextOf(ast.ContextOfExpressions(decl.Targets))
+		src_context := ast.ContextOfExpressions(decl.Sources)
+		target_context := ast.ContextOfExpressions(decl.Targets)
+		// Include selectors (if applicable).  Observe the resolver has already
+		// ensured these are consistent with the expressions they select.
+		if decl.SourceSelector != nil {
+			src_context = src_context.Join(decl.SourceSelector.Context())
+		}
+		//
+		if decl.TargetSelector != nil {
+			target_context = target_context.Join(decl.TargetSelector.Context())
+		}
 		// Add translated constraint
-		t.schema.AddLookupConstraint(decl.Handle, src_context, target_context, sources, targets)
+		t.schema.AddLookupConstraint(decl.Handle, t.env.ContextOf(src_context), t.env.ContextOf(target_context),
+			sources, targets, src_selector, tgt_selector)
 	}
 	// Done
 	return errors
 }
 
+// Translate an optional lookup selector.  That is an expression which maybe nil
+// (i.e. doesn't exist), in which case an empty selector is returned.
+func (t *translator) translateOptionalSelectorInModule(selector ast.Expr,
+	module util.Path) (util.Option[hir.UnitExpr], []SyntaxError) {
+	//
+	if selector != nil {
+		expr, errors := t.translateExpressionInModule(selector, module, 0)
+		return util.Some(hir.NewUnitExpr(expr)), errors
+	}
+	//
+	return util.None[hir.UnitExpr](), nil
+}
+
+// Translate an "in-enum" declaration.  When the values of the enumeration form
+// a contiguous range [min..min+n), this becomes a range constraint on the
+// expression (less min).  Otherwise, it becomes a lookup into a fixed table
+// holding the members of the enumeration (see translateEnumLookups).
+func (t *translator) translateDefInEnum(decl *ast.DefInEnum, module util.Path) []SyntaxError {
+	// Translate constrained expression
+	expr, errors := t.translateExpressionInModule(decl.Expr, module, 0)
+	//
+	if len(errors) > 0 {
+		return errors
+	}
+	//
+	var (
+		context = expr.Context(t.schema)
+		values  = slices.Clone(decl.Enum.Values)
+	)
+	// Sort values to check whether they are contiguous
+	slices.SortFunc(values, func(l, r fr.Element) int { return l.Cmp(&r) })
+	//
+	if isContiguous(values) {
+		var bound fr.Element
+		//
+		if !values[0].IsZero() {
+			expr = &hir.Sub{Args: []hir.Expr{expr, &hir.Constant{Val: values[0]}}}
+		}
+		//
+		bound.SetUint64(uint64(len(values)))
+		t.schema.AddRangeConstraint("", context, expr, bound)
+	} else {
+		t.enumLookups = append(t.enumLookups, enumLookup{decl.Enum, values, context, expr})
+	}
+	// Done
+	return nil
+}
+
+// Translate the membership constraints for non-contiguous enumerations.  Each
+// such enumeration is given a fixed table (i.e. a fixed column in its own
+// module) holding its members, and each membership constraint becomes a lookup
+// into that table.  Since "#" cannot appear in a user-defined module name, the
+// module of a table cannot clash with any other module.
+func (t *translator) translateEnumLookups() {
+	tables := make(map[*tr.Enumeration]uint)
+	//
+	for _, ith := range t.enumLookups {
+		cid, ok := tables[ith.enum]
+		// Allocate table (if not already done)
+		if !ok {
+			var largest big.Int
+			// Determine smallest type holding all members
+			ith.values[len(ith.values)-1].BigInt(&largest)
+			//
+			mid := t.schema.AddModule(fmt.Sprintf("%s#", ith.enum.Name))
+			context := tr.NewContext(mid, 1)
+			datatype := sc.NewUintType(uint(largest.BitLen()))
+			column := assignment.NewFixedColumn(context, ith.enum.Name, datatype, tr.NewEnumDisplay(ith.enum),
+				ith.values)
+			cid = t.schema.AddAssignment(column)
+			tables[ith.enum] = cid
+		}
+		//
+		source := []hir.UnitExpr{hir.NewUnitExpr(ith.expr)}
+		target := []hir.UnitExpr{hir.NewUnitExpr(&hir.ColumnAccess{Column: cid, Shift: 0})}
+		target_context := t.schema.Columns().Nth(cid).Context
+		//
+		t.schema.AddLookupConstraint(ith.enum.Name, ith.context, target_context, source, target,
+			util.None[hir.UnitExpr](), util.None[hir.UnitExpr]())
+	}
+}
+
+// Determine whether a sorted array of values forms a contiguous range.
+func isContiguous(values []fr.Element) bool {
+	one := fr.One()
+	//
+	for i := 1; i < len(values); i++ {
+		var next fr.Element
+		//
+		if next.Add(&values[i-1], &one); !next.Equal(&values[i]) {
+			return false
+		}
+	}
+	//
+	return true
+}
+
 // Translate a "definrange" declaration.
 func (t *translator) translateDefInRange(decl *ast.DefInRange, module util.Path) []SyntaxError {
 	// Translate constraint body
@@ -409,6 +591,7 @@ func (t *translator) translateDefPermutation(decl *ast.DefPermutation, module ut
 		target := t.env.Register(targetId)
 		// Construct columns
 		targets[i] = sc.NewColumn(target.Context, target.Name(), target.DataType)
+		targets[i].Display = target.Display()
 		sourceBinding := decl.Sources[i].Binding().(*ast.ColumnBinding)
 		sources[i] = t.env.RegisterOf(&sourceBinding.Path)
 		signs[i] = decl.Signs[i]
@@ -519,6 +702,8 @@ func (t *translator) translateExpressionInModule(expr ast.Expr, module util.Path
 		val.SetBigInt(&e.Val)
 		//
 		return &hir.Constant{Val: val}, nil
+	case *ast.ConstOp:
+		return t.translateConstOpInModule(e)
 	case *ast.Exp:
 		return t.translateExpInModule(e, module, shift)
 	case *ast.If:
@@ -553,6 +738,21 @@ func (t *translator) translateExpressionInModule(expr ast.Expr, module util.Path
 	}
 }
 
+// Translate a constant operation, which should have been reduced to a constant
+// by this stage.  Anything else indicates a non-constant argument which could
+// not be identified during type checking (e.g. a call to an impure function).
+func (t *translator) translateConstOpInModule(expr *ast.ConstOp) (hir.Expr, []SyntaxError) {
+	var val fr.Element
+	//
+	if c := expr.AsConstant(); c == nil {
+		return nil, t.srcmap.SyntaxErrors(expr, "expected constant arguments")
+	} else {
+		val.SetBigInt(c)
+	}
+	//
+	return &hir.Constant{Val: val}, nil
+}
+
 func (t *translator) translateExpInModule(expr *ast.Exp, module util.Path, shift int) (hir.Expr, []SyntaxError) {
 	arg, errs := t.translateExpressionInModule(expr.Arg, module, shift)
 	pow := expr.Pow.AsConstant()
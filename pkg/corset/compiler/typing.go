@@ -71,12 +71,18 @@ func (p *typeChecker) typeCheckDeclaration(decl ast.Declaration) []SyntaxError {
 		// ignore
 	case *ast.DefComputed:
 		// ignore (for now)
+	case *ast.DefComputedColumn:
+		errors = p.typeCheckDefComputedColumn(d)
 	case *ast.DefConst:
 		errors = p.typeCheckDefConstInModule(d)
+	case *ast.DefConstAssert:
+		errors = p.typeCheckDefConstAssert(d)
 	case *ast.DefConstraint:
 		errors = p.typeCheckDefConstraint(d)
 	case *ast.DefFun:
 		errors = p.typeCheckDefFunInModule(d)
+	case *ast.DefInEnum:
+		errors = p.typeCheckDefInEnum(d)
 	case *ast.DefInRange:
 		errors = p.typeCheckDefInRange(d)
 	case *ast.DefInterleaved:
@@ -97,6 +103,21 @@ func (p *typeChecker) typeCheckDeclaration(decl ast.Declaration) []SyntaxError {
 	return errors
 }
 
+// typeCheck a "defcomputedcolumn" declaration.  Observe that the computation
+// cannot have loobean semantics, since its value is assigned directly to the
+// target column.
+func (p *typeChecker) typeCheckDefComputedColumn(decl *ast.DefComputedColumn) []SyntaxError {
+	// typeCheck computation
+	computation_t, errors := p.typeCheckExpressionInModule(decl.Computation)
+	//
+	if computation_t != nil && computation_t.HasLoobeanSemantics() {
+		err := p.srcmap.SyntaxError(decl.Computation, "unexpected loobean computation")
+		errors = append(errors, *err)
+	}
+	// Done
+	return errors
+}
+
 // ast.Type check one or more constant definitions within a given module.
 func (p *typeChecker) typeCheckDefConstInModule(decl *ast.DefConst) []SyntaxError {
 	var errors []SyntaxError
@@ -111,6 +132,20 @@ func (p *typeChecker) typeCheckDefConstInModule(decl *ast.DefConst) []SyntaxErro
 	return errors
 }
 
+// typeCheck a "defconstassert" declaration.  Observe that the assertion cannot
+// have loobean semantics, since it holds when its value is non-zero.
+func (p *typeChecker) typeCheckDefConstAssert(decl *ast.DefConstAssert) []SyntaxError {
+	// typeCheck assertion
+	assertion_t, errors := p.typeCheckExpressionInModule(decl.Assertion)
+	//
+	if assertion_t != nil && assertion_t.HasLoobeanSemantics() {
+		err := p.srcmap.SyntaxError(decl.Assertion, "unexpected loobean assertion")
+		errors = append(errors, *err)
+	}
+	// Done
+	return errors
+}
+
 // typeCheck a "defconstraint" declaration.
 func (p *typeChecker) typeCheckDefConstraint(decl *ast.DefConstraint) []SyntaxError {
 	// typeCheck (optional) guard
@@ -146,10 +181,63 @@ func (p *typeChecker) typeCheckDefFunInModule(decl *ast.DefFun) []SyntaxError {
 //nolint:staticcheck
 func (p *typeChecker) typeCheckDefLookup(decl *ast.DefLookup) []SyntaxError {
 	// typeCheck source expressions
-	_, source_errs := p.typeCheckExpressionsInModule(decl.Sources)
-	_, target_errs := p.typeCheckExpressionsInModule(decl.Targets)
+	source_ts, source_errs := p.typeCheckExpressionsInModule(decl.Sources)
+	target_ts, target_errs := p.typeCheckExpressionsInModule(decl.Targets)
+	// typeCheck selectors (if applicable)
+	selector_errs := p.typeCheckLookupSelector(decl.SourceSelector)
+	selector_errs = append(selector_errs, p.typeCheckLookupSelector(decl.TargetSelector)...)
 	// Combine errors
-	return append(source_errs, target_errs...)
+	errors := append(source_errs, target_errs...)
+	// Check signedness of sources and targets match.  Since signed values are
+	// represented by their additive inverse in the field, a negative source
+	// value could never match an unsigned target (and vice versa).
+	for i := 0; i < len(source_ts) && i < len(target_ts); i++ {
+		if isSigned(source_ts[i]) != isSigned(target_ts[i]) && isInteger(source_ts[i]) && isInteger(target_ts[i]) {
+			msg := fmt.Sprintf("incompatible signed and unsigned types (%s and %s)", source_ts[i], target_ts[i])
+			errors = append(errors, *p.srcmap.SyntaxError(decl.Sources[i], msg))
+		}
+	}
+	//
+	return append(errors, selector_errs...)
+}
+
+// Determine whether a given type is a signed integer type.
+func isSigned(datatype ast.Type) bool {
+	if datatype == nil || datatype.AsUnderlying() == nil {
+		return false
+	}
+	//
+	return datatype.AsUnderlying().AsInt() != nil
+}
+
+// Determine whether a given type is an integer type (i.e. signed or unsigned).
+func isInteger(datatype ast.Type) bool {
+	if datatype == nil || datatype.AsUnderlying() == nil {
+		return false
+	}
+	//
+	return datatype.AsUnderlying().AsField() == nil
+}
+
+// typeCheck an (optional) lookup selector.  As for constraint guards, a
+// selector cannot have loobean semantics.
+func (p *typeChecker) typeCheckLookupSelector(selector ast.Expr) []SyntaxError {
+	selector_t, errors := p.typeCheckOptionalExpressionInModule(selector)
+	//
+	if selector_t != nil && selector_t.HasLoobeanSemantics() {
+		err := p.srcmap.SyntaxError(selector, "unexpected loobean guard")
+		errors = append(errors, *err)
+	}
+	//
+	return errors
+}
+
+// typeCheck an "in-enum" declaration.
+func (p *typeChecker) typeCheckDefInEnum(decl *ast.DefInEnum) []SyntaxError {
+	// typeCheck constrained expression
+	_, errors := p.typeCheckExpressionInModule(decl.Expr)
+	// Done
+	return errors
 }
 
 // typeCheck a "definrange" declaration.
@@ -220,11 +308,12 @@ func (p *typeChecker) typeCheckExpressionInModule(expr ast.Expr) (ast.Type, []Sy
 	case *ast.ArrayAccess:
 		return p.typeCheckArrayAccessInModule(e)
 	case *ast.Add:
-		types, errs := p.typeCheckExpressionsInModule(e.Args)
-		return ast.LeastUpperBoundAll(types), errs
+		return p.typeCheckArithmeticInModule(e, e.Args, ast.LeastUpperBoundAll)
 	case *ast.Constant:
 		nbits := e.Val.BitLen()
 		return ast.NewUintType(uint(nbits)), nil
+	case *ast.ConstOp:
+		return p.typeCheckConstOpInModule(e)
 	case *ast.Debug:
 		return p.typeCheckExpressionInModule(e.Arg)
 	case *ast.Exp:
@@ -245,8 +334,7 @@ func (p *typeChecker) typeCheckExpressionInModule(expr ast.Expr) (ast.Type, []Sy
 		types, errs := p.typeCheckExpressionsInModule(e.Args)
 		return ast.LeastUpperBoundAll(types), errs
 	case *ast.Mul:
-		types, errs := p.typeCheckExpressionsInModule(e.Args)
-		return ast.GreatestLowerBoundAll(types), errs
+		return p.typeCheckArithmeticInModule(e, e.Args, ast.GreatestLowerBoundAll)
 	case *ast.Normalise:
 		_, errs := p.typeCheckExpressionInModule(e.Arg)
 		// Normalise guaranteed to return either 0 or 1.
@@ -259,8 +347,7 @@ func (p *typeChecker) typeCheckExpressionInModule(expr ast.Expr) (ast.Type, []Sy
 		// combine errors
 		return arg_t, append(arg_errs, shf_errs...)
 	case *ast.Sub:
-		types, errs := p.typeCheckExpressionsInModule(e.Args)
-		return ast.LeastUpperBoundAll(types), errs
+		return p.typeCheckArithmeticInModule(e, e.Args, ast.LeastUpperBoundAll)
 	case *ast.VariableAccess:
 		return p.typeCheckVariableInModule(e)
 	default:
@@ -268,6 +355,36 @@ func (p *typeChecker) typeCheckExpressionInModule(expr ast.Expr) (ast.Type, []Sy
 	}
 }
 
+// ast.Type check an arithmetic expression (i.e. an addition, subtraction or
+// multiplication), where the result type is determined by joining the operand
+// types.  Mixing signed and unsigned operands is permitted, in which case the
+// result is a signed type large enough to hold both (e.g. s8 and u8 gives s9).
+// However, this is an error when no such signed type exists (e.g. s8 and
+// u252).
+func (p *typeChecker) typeCheckArithmeticInModule(expr ast.Expr, args []ast.Expr,
+	join func([]ast.Type) ast.Type) (ast.Type, []SyntaxError) {
+	types, errs := p.typeCheckExpressionsInModule(args)
+	datatype := join(types)
+	//
+	if len(errs) > 0 || !isSigned(datatype) || datatype.AsUnderlying().BitWidth() <= MAX_SIGNED_BITWIDTH {
+		return datatype, errs
+	}
+	// Identify offending operands for error message
+	var signed, unsigned ast.Type
+	//
+	for _, t := range types {
+		if isSigned(t) {
+			signed = t
+		} else if isInteger(t) && (unsigned == nil || t.AsUnderlying().BitWidth() > unsigned.AsUnderlying().BitWidth()) {
+			unsigned = t
+		}
+	}
+	//
+	msg := fmt.Sprintf("incompatible signed and unsigned types (%s and %s)", signed, unsigned)
+	//
+	return nil, p.srcmap.SyntaxErrors(expr, msg)
+}
+
 // ast.Type check an array access expression.  The main thing is to check that the
 // column being accessed was originally defined as an array column.
 func (p *typeChecker) typeCheckArrayAccessInModule(expr *ast.ArrayAccess) (ast.Type, []SyntaxError) {
@@ -282,6 +399,52 @@ func (p *typeChecker) typeCheckArrayAccessInModule(expr *ast.ArrayAccess) (ast.T
 	}
 }
 
+// Type check a constant operation (e.g. division, bitwise and, etc).  Since
+// such operations have no counterpart at the constraint level, their arguments
+// cannot depend upon columns.  Observe that arguments can still depend upon
+// function parameters, since these will be constant after inlining (and, if
+// not, this will be reported during translation).
+func (p *typeChecker) typeCheckConstOpInModule(expr *ast.ConstOp) (ast.Type, []SyntaxError) {
+	types, errors := p.typeCheckExpressionsInModule(expr.Args)
+	// Check arguments do not depend on columns
+	for _, arg := range expr.Args {
+		if dependsOnColumn(arg) {
+			errors = append(errors, *p.srcmap.SyntaxError(arg, "expected constant argument"))
+		}
+	}
+	// Check operation is defined
+	if len(errors) == 0 && expr.IsUndefined() {
+		errors = append(errors, *p.srcmap.SyntaxError(expr, undefinedConstOp(expr.Kind)))
+	} else if val := expr.AsConstant(); val != nil {
+		return ast.NewUintType(uint(val.BitLen())), errors
+	}
+	//
+	return ast.LeastUpperBoundAll(types), errors
+}
+
+// Determine whether or not a given expression depends upon one or more
+// columns.
+func dependsOnColumn(expr ast.Expr) bool {
+	for _, dep := range expr.Dependencies() {
+		if _, ok := dep.Binding().(*ast.ColumnBinding); ok {
+			return true
+		}
+	}
+	//
+	return false
+}
+
+// Determine an appropriate error message for a constant operation whose
+// (constant) arguments are outside its domain.
+func undefinedConstOp(kind uint8) string {
+	switch kind {
+	case ast.CONST_DIV, ast.CONST_REM:
+		return "division by zero"
+	default:
+		return "invalid shift amount"
+	}
+}
+
 // ast.Type an if condition contained within some expression which, in turn, is
 // contained within some module.  An important step occurrs here where, based on
 // the semantics of the condition, this is inferred as an "if-zero" or an
@@ -337,8 +500,10 @@ func (p *typeChecker) typeCheckInvokeInModule(expr *ast.Invoke) (ast.Type, []Syn
 			return signature.Return(), nil
 		}
 		// TODO: this is potentially expensive, and it would likely be good if we
-		// could avoid it.
-		body := signature.Apply(expr.Args, nil)
+		// could avoid it.  NOTE: the source map is required here to report
+		// errors arising from the arguments (e.g. a column passed where a
+		// constant is required).
+		body := signature.Apply(expr.Args, p.srcmap)
 		// Dig out the type
 		return p.typeCheckExpressionInModule(body)
 	}
@@ -408,8 +573,9 @@ func (p *typeChecker) typeCheckVariableInModule(expr *ast.VariableAccess) (ast.T
 		//
 	} else if binding, ok := expr.Binding().(*ast.ColumnBinding); ok {
 		return binding.DataType, nil
-	} else if binding, ok := expr.Binding().(*ast.ConstantBinding); ok {
-		// Constant
+	} else if binding, ok := expr.Binding().(*ast.ConstantBinding); ok && binding.IsFinalised() {
+		// Constant.  Observe that unfinalised constants (e.g. which are cyclic)
+		// are not typed since these have already been reported by the resolver.
 		return p.typeCheckExpressionInModule(binding.Value)
 	} else if binding, ok := expr.Binding().(*ast.LocalVariableBinding); ok {
 		// Parameter, for or let variable
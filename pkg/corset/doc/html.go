@@ -0,0 +1,153 @@
+package doc
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// HTML_EXT is the file extension used for HTML documentation.
+const HTML_EXT = ".html"
+
+// HTMLIndex renders an index of the given modules as an HTML page.
+func HTMLIndex(modules []*Module) string {
+	var builder strings.Builder
+	//
+	htmlHeader(&builder, "Modules")
+	builder.WriteString("<h1>Modules</h1>\n<table>\n")
+	builder.WriteString("<tr><th>Module</th><th>Columns</th><th>Perspectives</th><th>Constraints</th>" +
+		"<th>Lookups</th></tr>\n")
+	//
+	for _, m := range modules {
+		builder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td></tr>\n",
+			htmlLink(m.Name), len(m.Columns), len(m.Perspectives), len(m.Constraints), len(m.Lookups)))
+	}
+	//
+	builder.WriteString("</table>\n")
+	htmlFooter(&builder)
+	//
+	return builder.String()
+}
+
+// HTML renders the documentation for a given module as an HTML page.
+func HTML(module *Module) string {
+	var builder strings.Builder
+	//
+	htmlHeader(&builder, fmt.Sprintf("Module %s", module.Title()))
+	builder.WriteString(fmt.Sprintf("<h1>Module <code>%s</code></h1>\n", html.EscapeString(module.Title())))
+	builder.WriteString(fmt.Sprintf("<p><a href=\"%s%s\">All modules</a></p>\n", INDEX, HTML_EXT))
+	//
+	if len(module.Columns) > 0 {
+		builder.WriteString("<h2>Columns</h2>\n")
+		htmlColumns(&builder, module.Columns)
+	}
+	//
+	if len(module.Perspectives) > 0 {
+		builder.WriteString("<h2>Perspectives</h2>\n")
+		//
+		for _, p := range module.Perspectives {
+			builder.WriteString(fmt.Sprintf("<h3 id=\"perspective-%s\"><code>%s</code></h3>\n",
+				html.EscapeString(p.Name), html.EscapeString(p.Name)))
+			htmlDoc(&builder, p.Doc)
+			builder.WriteString(fmt.Sprintf("<ul>\n<li>Selector: <code>%s</code></li>\n</ul>\n",
+				html.EscapeString(p.Selector)))
+			htmlColumns(&builder, p.Columns)
+		}
+	}
+	//
+	if len(module.Constraints) > 0 {
+		builder.WriteString("<h2>Constraints</h2>\n")
+		//
+		for _, c := range module.Constraints {
+			builder.WriteString(fmt.Sprintf("<h3 id=\"constraint-%s\"><code>%s</code></h3>\n",
+				html.EscapeString(c.Handle), html.EscapeString(c.Handle)))
+			htmlDoc(&builder, c.Doc)
+			//
+			if c.Domain != "" || c.Guard != "" || c.Perspective != "" {
+				builder.WriteString("<ul>\n")
+				//
+				if c.Domain != "" {
+					builder.WriteString(fmt.Sprintf("<li>Domain: <code>%s</code></li>\n", html.EscapeString(c.Domain)))
+				}
+				//
+				if c.Guard != "" {
+					builder.WriteString(fmt.Sprintf("<li>Guard: <code>%s</code></li>\n", html.EscapeString(c.Guard)))
+				}
+				//
+				if c.Perspective != "" {
+					name := html.EscapeString(c.Perspective)
+					builder.WriteString(fmt.Sprintf("<li>Perspective: <a href=\"#perspective-%s\"><code>%s</code></a></li>\n",
+						name, name))
+				}
+				//
+				builder.WriteString("</ul>\n")
+			}
+			//
+			htmlSource(&builder, c.Source)
+		}
+	}
+	//
+	if len(module.Lookups) > 0 {
+		builder.WriteString("<h2>Lookups</h2>\n")
+		//
+		for _, l := range module.Lookups {
+			builder.WriteString(fmt.Sprintf("<h3 id=\"lookup-%s\"><code>%s</code></h3>\n",
+				html.EscapeString(l.Handle), html.EscapeString(l.Handle)))
+			htmlDoc(&builder, l.Doc)
+			builder.WriteString(fmt.Sprintf("<ul>\n<li>Source: %s</li>\n<li>Target: %s</li>\n</ul>\n",
+				htmlLink(l.SourceModule), htmlLink(l.TargetModule)))
+			htmlSource(&builder, l.Source)
+		}
+	}
+	//
+	htmlFooter(&builder)
+	//
+	return builder.String()
+}
+
+func htmlHeader(builder *strings.Builder, title string) {
+	builder.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	builder.WriteString(fmt.Sprintf("<title>%s</title>\n", html.EscapeString(title)))
+	builder.WriteString("<style>table { border-collapse: collapse; } th, td { border: 1px solid #ccc; " +
+		"padding: 2px 8px; text-align: left; }</style>\n")
+	builder.WriteString("</head>\n<body>\n")
+}
+
+func htmlFooter(builder *strings.Builder) {
+	builder.WriteString("</body>\n</html>\n")
+}
+
+func htmlColumns(builder *strings.Builder, columns []Column) {
+	builder.WriteString("<table>\n<tr><th>Column</th><th>Type</th><th>Array</th><th>Multiplier</th>" +
+		"<th>Display</th><th>Kind</th><th>Description</th></tr>\n")
+	//
+	for _, c := range columns {
+		kind := "input"
+		//
+		if c.Computed {
+			kind = "computed"
+		}
+		//
+		builder.WriteString(fmt.Sprintf("<tr><td><code>%s</code></td><td><code>%s</code></td><td>%s</td>"+
+			"<td>%d</td><td>%s</td><td>%s</td><td>%s</td></tr>\n", html.EscapeString(c.Name),
+			html.EscapeString(c.Type), html.EscapeString(c.Array), c.Multiplier, html.EscapeString(c.Display), kind,
+			html.EscapeString(c.Doc)))
+	}
+	//
+	builder.WriteString("</table>\n")
+}
+
+func htmlDoc(builder *strings.Builder, doc string) {
+	if doc != "" {
+		builder.WriteString(fmt.Sprintf("<p>%s</p>\n", strings.ReplaceAll(html.EscapeString(doc), "\n", "<br>\n")))
+	}
+}
+
+func htmlSource(builder *strings.Builder, source string) {
+	builder.WriteString(fmt.Sprintf("<pre><code>%s</code></pre>\n", html.EscapeString(source)))
+}
+
+func htmlLink(module string) string {
+	return fmt.Sprintf("<a href=\"%s%s\"><code>%s</code></a>", Filename(module), HTML_EXT,
+		html.EscapeString(moduleTitle(module)))
+}
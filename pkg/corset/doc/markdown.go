@@ -0,0 +1,134 @@
+package doc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MARKDOWN_EXT is the file extension used for Markdown documentation.
+const MARKDOWN_EXT = ".md"
+
+// MarkdownIndex renders an index of the given modules in Markdown.
+func MarkdownIndex(modules []*Module) string {
+	var builder strings.Builder
+	//
+	builder.WriteString("# Modules\n\n")
+	builder.WriteString("| Module | Columns | Perspectives | Constraints | Lookups |\n")
+	builder.WriteString("|---|---|---|---|---|\n")
+	//
+	for _, m := range modules {
+		builder.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d |\n", markdownLink(m.Name), len(m.Columns),
+			len(m.Perspectives), len(m.Constraints), len(m.Lookups)))
+	}
+	//
+	return builder.String()
+}
+
+// Markdown renders the documentation for a given module in Markdown.
+func Markdown(module *Module) string {
+	var builder strings.Builder
+	//
+	builder.WriteString(fmt.Sprintf("# Module `%s`\n\n", module.Title()))
+	builder.WriteString(fmt.Sprintf("[All modules](%s%s)\n", INDEX, MARKDOWN_EXT))
+	//
+	if len(module.Columns) > 0 {
+		builder.WriteString("\n## Columns\n\n")
+		markdownColumns(&builder, module.Columns)
+	}
+	//
+	if len(module.Perspectives) > 0 {
+		builder.WriteString("\n## Perspectives\n")
+		//
+		for _, p := range module.Perspectives {
+			builder.WriteString(fmt.Sprintf("\n### `%s`\n\n", p.Name))
+			markdownDoc(&builder, p.Doc)
+			builder.WriteString(fmt.Sprintf("- Selector: `%s`\n\n", markdownCode(p.Selector)))
+			markdownColumns(&builder, p.Columns)
+		}
+	}
+	//
+	if len(module.Constraints) > 0 {
+		builder.WriteString("\n## Constraints\n")
+		//
+		for _, c := range module.Constraints {
+			builder.WriteString(fmt.Sprintf("\n### `%s`\n\n", c.Handle))
+			markdownDoc(&builder, c.Doc)
+			//
+			if c.Domain != "" {
+				builder.WriteString(fmt.Sprintf("- Domain: `%s`\n", c.Domain))
+			}
+			//
+			if c.Guard != "" {
+				builder.WriteString(fmt.Sprintf("- Guard: `%s`\n", markdownCode(c.Guard)))
+			}
+			//
+			if c.Perspective != "" {
+				builder.WriteString(fmt.Sprintf("- Perspective: `%s`\n", c.Perspective))
+			}
+			//
+			if c.Domain != "" || c.Guard != "" || c.Perspective != "" {
+				builder.WriteString("\n")
+			}
+			//
+			markdownSource(&builder, c.Source)
+		}
+	}
+	//
+	if len(module.Lookups) > 0 {
+		builder.WriteString("\n## Lookups\n")
+		//
+		for _, l := range module.Lookups {
+			builder.WriteString(fmt.Sprintf("\n### `%s`\n\n", l.Handle))
+			markdownDoc(&builder, l.Doc)
+			builder.WriteString(fmt.Sprintf("- Source: %s\n", markdownLink(l.SourceModule)))
+			builder.WriteString(fmt.Sprintf("- Target: %s\n\n", markdownLink(l.TargetModule)))
+			markdownSource(&builder, l.Source)
+		}
+	}
+	//
+	return builder.String()
+}
+
+func markdownColumns(builder *strings.Builder, columns []Column) {
+	builder.WriteString("| Column | Type | Array | Multiplier | Display | Kind | Description |\n")
+	builder.WriteString("|---|---|---|---|---|---|---|\n")
+	//
+	for _, c := range columns {
+		kind := "input"
+		//
+		if c.Computed {
+			kind = "computed"
+		}
+		//
+		builder.WriteString(fmt.Sprintf("| `%s` | `%s` | %s | %d | %s | %s | %s |\n", c.Name, c.Type, c.Array,
+			c.Multiplier, c.Display, kind, markdownCell(c.Doc)))
+	}
+}
+
+func markdownDoc(builder *strings.Builder, doc string) {
+	if doc != "" {
+		builder.WriteString(doc)
+		builder.WriteString("\n\n")
+	}
+}
+
+func markdownSource(builder *strings.Builder, source string) {
+	builder.WriteString("```lisp\n")
+	builder.WriteString(source)
+	builder.WriteString("\n```\n")
+}
+
+func markdownLink(module string) string {
+	return fmt.Sprintf("[`%s`](%s%s)", moduleTitle(module), Filename(module), MARKDOWN_EXT)
+}
+
+// Escape text for use within a table cell.
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", " ")
+}
+
+// Collapse whitespace within text for use in an inline code span.
+func markdownCode(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,394 @@
+package doc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/consensys/go-corset/pkg/corset"
+	"github.com/consensys/go-corset/pkg/corset/ast"
+	"github.com/consensys/go-corset/pkg/corset/compiler"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+// Module describes the contents of a given module for documentation purposes.
+// Items are listed in the order they are declared.
+type Module struct {
+	// Name of the module ("" for the root module).
+	Name string
+	// Columns declared in the module, excluding those declared in
+	// perspectives.
+	Columns []Column
+	// Perspectives declared in the module.
+	Perspectives []Perspective
+	// Constraints declared in the module.
+	Constraints []Constraint
+	// Lookups declared in the module.
+	Lookups []Lookup
+}
+
+// Column describes a given column.
+type Column struct {
+	// Name of the column.
+	Name string
+	// Documentation attached to the column (if any).
+	Doc string
+	// Type of the column (or of its elements, for an array column).
+	Type string
+	// Array bounds of the column (e.g. "[1:4]"), or "" if not an array.
+	Array string
+	// Length multiplier of the column.
+	Multiplier uint
+	// Display mode for the column (e.g. "hex").
+	Display string
+	// Indicates whether the column is computed, or an input column.
+	Computed bool
+}
+
+// Perspective describes a given perspective, along with its columns.
+type Perspective struct {
+	// Name of the perspective.
+	Name string
+	// Documentation attached to the perspective (if any).
+	Doc string
+	// Source text of the selector.
+	Selector string
+	// Columns declared within the perspective.
+	Columns []Column
+}
+
+// Constraint describes a given (vanishing) constraint.
+type Constraint struct {
+	// Handle of the constraint.
+	Handle string
+	// Documentation attached to the constraint (if any).
+	Doc string
+	// Rows on which the constraint applies (e.g. "{0 -1}"), or "" if it
+	// applies to all rows.
+	Domain string
+	// Source text of the guard, or "" if there is no guard.
+	Guard string
+	// Perspective to which the constraint belongs, or "" if none.
+	Perspective string
+	// Source text of the constraint declaration.
+	Source string
+}
+
+// Lookup describes a given lookup constraint.
+type Lookup struct {
+	// Handle of the lookup.
+	Handle string
+	// Documentation attached to the lookup (if any).
+	Doc string
+	// Module of the source expressions.
+	SourceModule string
+	// Module of the target expressions.
+	TargetModule string
+	// Source text of the lookup declaration.
+	Source string
+}
+
+// IsEmpty determines whether there is anything to document for this module.
+func (p *Module) IsEmpty() bool {
+	return len(p.Columns) == 0 && len(p.Perspectives) == 0 && len(p.Constraints) == 0 && len(p.Lookups) == 0
+}
+
+// Title returns a human-readable name for this module.
+func (p *Module) Title() string {
+	return moduleTitle(p.Name)
+}
+
+// Extract documentation for all modules declared in a given set of source
+// files.  This requires that the source files are resolved and type checked,
+// and hence fails if they contain errors.  Modules which declare nothing of
+// interest (e.g. a root module containing only functions) are omitted.
+func Extract(stdlib bool, srcfiles []*sexp.SourceFile) ([]*Module, []sexp.SyntaxError) {
+	if stdlib {
+		srcfiles = append(srcfiles, sexp.NewSourceFile("stdlib.lisp", corset.STDLIB))
+	}
+	// Parse source file(s)
+	circuit, srcmap, errs := compiler.ParseSourceFiles(srcfiles)
+	// Check for parsing errors
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	// Resolve and type check circuit
+	_, res_errs := compiler.ResolveCircuit(srcmap, &circuit)
+	type_errs := compiler.TypeCheckCircuit(srcmap, &circuit)
+	//
+	if len(res_errs) > 0 || len(type_errs) > 0 {
+		return nil, append(res_errs, type_errs...)
+	}
+	//
+	var (
+		extractor = extractor{srcmap, make(map[string]*sourceComments)}
+		modules   []*Module
+	)
+	//
+	for _, m := range append([]ast.Module{{Name: "", Declarations: circuit.Declarations}}, circuit.Modules...) {
+		if module := extractor.extractModule(m); !module.IsEmpty() {
+			modules = append(modules, module)
+		}
+	}
+	//
+	return modules, nil
+}
+
+// extractor holds the information needed for extracting documentation from
+// declarations.
+type extractor struct {
+	srcmap *sexp.SourceMaps[ast.Node]
+	// Comments for each source file, indexed by filename.
+	comments map[string]*sourceComments
+}
+
+func (p *extractor) extractModule(m ast.Module) *Module {
+	var module = &Module{Name: m.Name}
+	//
+	for _, d := range m.Declarations {
+		switch decl := d.(type) {
+		case *ast.DefColumns:
+			module.Columns = append(module.Columns, p.extractColumns(decl, decl.Columns)...)
+		case *ast.DefComputedColumn:
+			module.Columns = append(module.Columns, p.extractColumns(decl, []*ast.DefColumn{decl.Target})...)
+		case *ast.DefComputed:
+			module.Columns = append(module.Columns, p.extractColumns(decl, decl.Targets)...)
+		case *ast.DefInterleaved:
+			module.Columns = append(module.Columns, p.extractColumns(decl, []*ast.DefColumn{decl.Target})...)
+		case *ast.DefPermutation:
+			module.Columns = append(module.Columns, p.extractColumns(decl, decl.Targets)...)
+		case *ast.DefPerspective:
+			// The documentation of a perspective is not attached to its columns.
+			module.Perspectives = append(module.Perspectives, Perspective{
+				decl.Name(), p.docOf(decl, false), p.sourceOf(decl.Selector), p.extractColumns(nil, decl.Columns)})
+		case *ast.DefConstraint:
+			module.Constraints = append(module.Constraints, p.extractConstraint(decl))
+		case *ast.DefLookup:
+			module.Lookups = append(module.Lookups, Lookup{decl.Handle, p.docOf(decl, false),
+				ast.ContextOfExpressions(decl.Sources).Module(), ast.ContextOfExpressions(decl.Targets).Module(),
+				p.sourceOf(decl)})
+		}
+	}
+	//
+	return module
+}
+
+// Extract the given columns of a declaration.  The documentation attached to
+// the declaration itself (if any) applies to those columns without
+// documentation of their own.
+func (p *extractor) extractColumns(decl ast.Declaration, columns []*ast.DefColumn) []Column {
+	var (
+		result []Column
+		doc    string
+	)
+	//
+	if decl != nil {
+		doc = p.docOf(decl, false)
+	}
+	//
+	for _, column := range columns {
+		var (
+			binding  = column.Binding().(*ast.ColumnBinding)
+			datatype = binding.DataType
+			array    string
+			typeName string
+		)
+		//
+		if arrType, ok := datatype.(*ast.ArrayType); ok {
+			array = fmt.Sprintf("[%d:%d]", arrType.MinIndex(), arrType.MaxIndex())
+			datatype = arrType.Element()
+		}
+		//
+		if datatype != nil {
+			typeName = datatype.String()
+		}
+		//
+		if binding.MustProve {
+			typeName = fmt.Sprintf("%s@prove", typeName)
+		}
+		//
+		columnDoc := p.docOf(column, true)
+		//
+		if columnDoc == "" {
+			columnDoc = doc
+		}
+		//
+		result = append(result, Column{column.Name(), columnDoc, typeName, array, binding.Multiplier,
+			binding.Display.String(), binding.Computed})
+	}
+	//
+	return result
+}
+
+func (p *extractor) extractConstraint(decl *ast.DefConstraint) Constraint {
+	var constraint = Constraint{Handle: decl.Handle, Doc: p.docOf(decl, false), Source: p.sourceOf(decl)}
+	//
+	if decl.Domain.HasValue() {
+		var rows []string
+		//
+		for _, row := range decl.Domain.Unwrap() {
+			rows = append(rows, fmt.Sprintf("%d", row))
+		}
+		//
+		constraint.Domain = fmt.Sprintf("{%s}", strings.Join(rows, " "))
+	}
+	//
+	if decl.Guard != nil {
+		constraint.Guard = p.sourceOf(decl.Guard)
+	}
+	//
+	if decl.Perspective != nil {
+		constraint.Perspective = decl.Perspective.Path().Tail()
+	}
+	//
+	return constraint
+}
+
+// Determine the source text for a given node.
+func (p *extractor) sourceOf(node ast.Node) string {
+	if file, span, ok := p.srcmap.Lookup(node); ok {
+		return string(file.Contents()[span.Start():span.End()])
+	}
+	//
+	return node.Lisp().String(false)
+}
+
+// Determine the documentation attached to a given node.  That is, the comment
+// lines immediately preceding it or, if requested, a comment following it on
+// the same line.
+func (p *extractor) docOf(node ast.Node, trailing bool) string {
+	var (
+		file, span, ok = p.srcmap.Lookup(node)
+		lines          []string
+	)
+	//
+	if !ok {
+		return ""
+	}
+	//
+	comments := p.commentsOf(file)
+	lines = comments.preceding(span.Start())
+	//
+	if trailing && len(lines) == 0 {
+		lines = comments.trailing(span)
+	}
+	//
+	return strings.Join(lines, "\n")
+}
+
+func (p *extractor) commentsOf(file *sexp.SourceFile) *sourceComments {
+	if comments, ok := p.comments[file.Filename()]; ok {
+		return comments
+	}
+	// Parse file to identify its comments.  Errors can be ignored here, since
+	// the file has already been parsed successfully.
+	parser := sexp.NewParser(file)
+	//
+	for {
+		if term, err := parser.Parse(); term == nil || err != nil {
+			break
+		}
+	}
+	//
+	comments := &sourceComments{file.Contents(), parser.Comments()}
+	p.comments[file.Filename()] = comments
+	//
+	return comments
+}
+
+// sourceComments identifies all comments within a given source file.
+type sourceComments struct {
+	text  []rune
+	spans []sexp.Span
+}
+
+// Determine the contents of all comment lines immediately preceding a given
+// offset.  Such comments must occupy whole lines, and there must be no blank
+// lines between them.
+func (p *sourceComments) preceding(offset int) []string {
+	var lines []string
+	//
+	for i := len(p.spans) - 1; i >= 0; i-- {
+		span := p.spans[i]
+		//
+		if span.End() > offset {
+			continue
+		} else if gap := string(p.text[span.End():offset]); strings.TrimSpace(gap) != "" ||
+			strings.Count(gap, "\n") != 1 || !p.startsLine(span.Start()) {
+			break
+		}
+		//
+		if line := p.contentOf(span); !strings.HasPrefix(line, corset.LINT_IGNORE) {
+			lines = append([]string{line}, lines...)
+		}
+		//
+		offset = span.Start()
+	}
+	//
+	return lines
+}
+
+// Determine the contents of a comment following a given span on the same line,
+// provided that the span starts its line.
+func (p *sourceComments) trailing(span sexp.Span) []string {
+	if !p.startsLine(span.Start()) {
+		return nil
+	}
+	//
+	for _, comment := range p.spans {
+		if comment.Start() >= span.End() {
+			gap := string(p.text[span.End():comment.Start()])
+			//
+			if strings.Trim(gap, " \t)") == "" && !strings.HasPrefix(p.contentOf(comment), corset.LINT_IGNORE) {
+				return []string{p.contentOf(comment)}
+			}
+			//
+			break
+		}
+	}
+	//
+	return nil
+}
+
+// Check whether a given offset is preceded only by whitespace on its line.
+func (p *sourceComments) startsLine(offset int) bool {
+	for i := offset - 1; i >= 0 && p.text[i] != '\n'; i-- {
+		if p.text[i] != ' ' && p.text[i] != '\t' {
+			return false
+		}
+	}
+	//
+	return true
+}
+
+// Extract the contents of a comment, excluding the leading semi-colons.
+func (p *sourceComments) contentOf(span sexp.Span) string {
+	text := string(p.text[span.Start():span.End()])
+	return strings.TrimSpace(strings.TrimLeft(text, ";"))
+}
+
+// Filename returns the name of the file (without extension) documenting a given
+// module.  Links between modules assume each is documented in its own file,
+// alongside an index file.  To ensure these names cannot collide, the root
+// module is documented in "_root", whilst modules named after the index (or
+// starting with an underscore) are prefixed with an underscore.
+func Filename(module string) string {
+	if module == "" {
+		return "_root"
+	} else if module == INDEX || strings.HasPrefix(module, "_") {
+		return fmt.Sprintf("_%s", module)
+	}
+	//
+	return module
+}
+
+// INDEX is the name of the file (without extension) for the index of all
+// modules.
+const INDEX = "index"
+
+func moduleTitle(name string) string {
+	if name == "" {
+		return "(root)"
+	}
+	//
+	return name
+}
@@ -0,0 +1,633 @@
+package corset
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+// FormatSourceFile formats a given source file according to a canonical
+// layout, returning the formatted text or an error if the file cannot be
+// parsed.  Terms which fit within the given width are kept on a single line.
+// Otherwise, they are broken over multiple lines according to their form.  For
+// example, the body of a "defconstraint" is indented beneath its name and
+// attributes, whilst the arguments of an "if" (or any other function) are
+// aligned beneath the first.  The columns of a "defcolumns" (or
+// "defperspective") are placed on separate lines, with their attributes
+// aligned.  Comments are preserved, as are (single) blank lines separating
+// terms.
+func FormatSourceFile(srcfile *sexp.SourceFile, width uint) (string, *SyntaxError) {
+	var (
+		parser  = sexp.NewParser(srcfile)
+		terms   []sexp.SExp
+		printer = formatPrinter{width: int(width)}
+	)
+	// Parse all terms
+	for {
+		term, err := parser.Parse()
+		//
+		if err != nil {
+			return "", err
+		} else if term == nil {
+			break
+		}
+		//
+		terms = append(terms, term)
+	}
+	//
+	builder := formatBuilder{srcfile.Contents(), parser.SourceMap(), parser.Comments(), 0, 0}
+	// Build nodes for each term, attaching comments as appropriate.
+	nodes := builder.buildAll(terms, len(srcfile.Contents()))
+	footer := builder.takeComments(len(srcfile.Contents()))
+	// Print nodes
+	for i, node := range nodes {
+		printer.suffix = 0
+		printer.printOnNewLine(node, 0, i == 0)
+		printer.trail(node.trailing)
+	}
+	// Print any remaining comments
+	for i, c := range footer {
+		if len(nodes) > 0 || i > 0 {
+			printer.separate(0, c.blank)
+		}
+		//
+		printer.write(c.text)
+	}
+	//
+	if len(nodes) > 0 || len(footer) > 0 {
+		printer.newline(0)
+	}
+	//
+	return printer.out.String(), nil
+}
+
+// Layouts used for printing a list which does not fit on a single line.
+const (
+	// Elements after the header are aligned beneath the first argument.
+	alignedLayout = iota
+	// Elements after the header are indented beneath the head.
+	bodyLayout
+	// As for body layout, except elements after the header are treated as
+	// name-value pairs (e.g. as for "defconst").
+	pairsLayout
+	// As for body layout, except elements after the header are treated as
+	// column declarations (e.g. as for "defcolumns").
+	columnsLayout
+)
+
+// Determines how a given form (e.g. "defconstraint") is printed when it cannot
+// fit on a single line.
+type formatForm struct {
+	// Number of elements (including the head) which are printed on the first
+	// line.
+	header int
+	// Layout of the remaining elements.
+	layout int
+	// Index of an element which is a list of column declarations (or -1 if
+	// there is none).
+	columns int
+}
+
+var formatForms = map[string]formatForm{
+	"defalias":           {1, pairsLayout, -1},
+	"defcolumns":         {1, columnsLayout, -1},
+	"defcomputed":        {1, bodyLayout, -1},
+	"defcomputedcolumn":  {2, bodyLayout, -1},
+	"defconst":           {1, pairsLayout, -1},
+	"defconstassert":     {1, bodyLayout, -1},
+	"defconstraint":      {3, bodyLayout, -1},
+	"defenum":            {2, bodyLayout, -1},
+	"definterleaved":     {2, bodyLayout, -1},
+	"deflookup":          {2, bodyLayout, -1},
+	"defmacro":           {2, bodyLayout, -1},
+	"defmodule-template": {3, bodyLayout, -1},
+	"defpermutation":     {1, bodyLayout, -1},
+	"defperspective":     {3, bodyLayout, 3},
+	"defproperty":        {2, bodyLayout, -1},
+	"defpurefun":         {2, bodyLayout, -1},
+	"defun":              {2, bodyLayout, -1},
+	"defunalias":         {1, pairsLayout, -1},
+	"for":                {3, bodyLayout, -1},
+	"let":                {2, bodyLayout, -1},
+}
+
+// ============================================================================
+// Nodes
+// ============================================================================
+
+// A node represents a term to be formatted, along with any comments attached to
+// it.
+type formatNode struct {
+	// Text of this node, which is either a symbol or the prefix of a quotation
+	// (e.g. "`").  In the latter case, this node has exactly one child.
+	text string
+	// Opening and closing brackets for a list, set or array.
+	open, close string
+	// Children of this node (e.g. elements of a list).
+	children []*formatNode
+	// Comments immediately preceding this node.
+	comments []formatComment
+	// Comment following this node on the same line (if any).
+	trailing string
+	// Comments following the last child of this node (if any).
+	footer []formatComment
+	// Indicates whether a blank line separated this node from whatever
+	// preceded it (e.g. its comments).
+	blank bool
+}
+
+// A comment which occupies its own line.
+type formatComment struct {
+	text string
+	// Indicates whether a blank line separated this comment from whatever
+	// preceded it.
+	blank bool
+}
+
+// Determine whether this is a list, set or array.
+func (p *formatNode) isList() bool {
+	return p.open != ""
+}
+
+// Determine whether or not this node contains comments, which prevent it from
+// being printed on a single line.
+func (p *formatNode) simple() bool {
+	for _, child := range p.children {
+		if len(child.comments) > 0 || child.trailing != "" || !child.simple() {
+			return false
+		}
+	}
+	//
+	return len(p.footer) == 0
+}
+
+// Print this node on a single line, provided it contains no comments.
+func (p *formatNode) flat() (string, bool) {
+	if !p.simple() {
+		return "", false
+	} else if !p.isList() && len(p.children) == 0 {
+		return p.text, true
+	} else if !p.isList() {
+		quoted, _ := p.children[0].flat()
+		return p.text + quoted, true
+	}
+	//
+	elements := make([]string, len(p.children))
+	//
+	for i, child := range p.children {
+		elements[i], _ = child.flat()
+	}
+	//
+	return p.open + strings.Join(elements, " ") + p.close, true
+}
+
+// Determine the form of this node (e.g. "defconstraint").
+func (p *formatNode) form() (formatForm, bool) {
+	if p.open == "(" && len(p.children) > 0 && !p.children[0].isList() && len(p.children[0].children) == 0 {
+		form, ok := formatForms[p.children[0].text]
+		return form, ok
+	}
+	//
+	return formatForm{}, false
+}
+
+// ============================================================================
+// Builder
+// ============================================================================
+
+// The builder is responsible for constructing nodes from parsed terms, and
+// attaching comments to them.
+type formatBuilder struct {
+	// Text of the source file.
+	text []rune
+	// Spans of parsed terms.
+	srcmap *sexp.SourceMap[sexp.SExp]
+	// Spans of all comments.
+	comments []sexp.Span
+	// Index of next comment to be attached.
+	next int
+	// Offset of the end of whatever was last processed.
+	last int
+}
+
+// Build nodes for a sequence of terms within a given enclosing span, where end
+// identifies the end of that span.
+func (p *formatBuilder) buildAll(terms []sexp.SExp, end int) []*formatNode {
+	var nodes []*formatNode
+	//
+	for _, term := range terms {
+		node := p.build(term)
+		node.trailing = p.takeTrailing(end)
+		nodes = append(nodes, node)
+	}
+	//
+	return nodes
+}
+
+func (p *formatBuilder) build(term sexp.SExp) *formatNode {
+	var (
+		span = p.srcmap.Get(term)
+		node = &formatNode{comments: p.takeComments(span.Start())}
+	)
+	//
+	node.blank = p.isBlank(span.Start())
+	p.last = span.Start() + 1
+	//
+	switch t := term.(type) {
+	case *sexp.Symbol:
+		node.text = t.Value
+	case *sexp.List:
+		if prefix := p.quotation(t); prefix != "" {
+			node.text = prefix
+			p.last = span.Start() + len(prefix)
+			node.children = []*formatNode{p.build(t.Elements[1])}
+		} else {
+			node.open, node.close = "(", ")"
+			node.children = p.buildAll(t.Elements, span.End())
+		}
+	case *sexp.Set:
+		node.open, node.close = "{", "}"
+		node.children = p.buildAll(t.Elements, span.End())
+	case *sexp.Array:
+		node.open, node.close = "[", "]"
+		node.children = p.buildAll(t.Elements, span.End())
+	}
+	// Comments preceding the head of a list are moved before the list itself.
+	if node.isList() && len(node.children) > 0 {
+		node.comments = append(node.comments, node.children[0].comments...)
+		node.children[0].comments = nil
+	}
+	//
+	node.footer = p.takeComments(span.End())
+	p.last = span.End()
+	//
+	return node
+}
+
+// Determine whether a given list was written using a quotation shorthand (e.g.
+// "`X" for "(quasiquote X)"), and return the shorthand if so.
+func (p *formatBuilder) quotation(list *sexp.List) string {
+	if list.Len() == 2 && list.Get(0).AsSymbol() != nil {
+		span := p.srcmap.Get(list.Get(0))
+		text := string(p.text[span.Start():span.End()])
+		//
+		if text == "`" || text == "," || text == ",@" {
+			return text
+		}
+	}
+	//
+	return ""
+}
+
+// Take all comments which start before a given offset.
+func (p *formatBuilder) takeComments(offset int) []formatComment {
+	var comments []formatComment
+	//
+	for ; p.next < len(p.comments) && p.comments[p.next].Start() < offset; p.next++ {
+		span := p.comments[p.next]
+		text := strings.TrimRight(string(p.text[span.Start():span.End()]), " \t\r")
+		comments = append(comments, formatComment{text, p.isBlank(span.Start())})
+		p.last = span.End()
+	}
+	//
+	return comments
+}
+
+// Take the next comment if it immediately follows whatever was last processed
+// on the same line, and starts before the given offset.
+func (p *formatBuilder) takeTrailing(offset int) string {
+	if p.next < len(p.comments) {
+		span := p.comments[p.next]
+		//
+		gap := string(p.text[p.last:span.Start()])
+		//
+		if span.Start() < offset && strings.Trim(gap, " \t") == "" {
+			p.next++
+			p.last = span.End()
+			//
+			return strings.TrimRight(string(p.text[span.Start():span.End()]), " \t\r")
+		}
+	}
+	//
+	return ""
+}
+
+// Determine whether there is a blank line between whatever was last processed
+// and a given offset.
+func (p *formatBuilder) isBlank(offset int) bool {
+	return strings.Count(string(p.text[p.last:offset]), "\n") > 1
+}
+
+// ============================================================================
+// Printer
+// ============================================================================
+
+type formatPrinter struct {
+	out strings.Builder
+	// Maximum width of a line (though this can be exceeded by terms which
+	// cannot be broken).
+	width int
+	// Current column.
+	column int
+	// Trailing comment(s) to be printed at the end of the current line.
+	pending string
+	// Number of closing brackets which will follow the node being printed on
+	// the same line.
+	suffix int
+}
+
+func (p *formatPrinter) write(text string) {
+	p.out.WriteString(text)
+	p.column += utf8.RuneCountInString(text)
+}
+
+// Start a new line with a given indentation, whilst flushing any pending
+// trailing comments.
+func (p *formatPrinter) newline(indent int) {
+	if p.pending != "" {
+		p.out.WriteString(" " + p.pending)
+		p.pending = ""
+	}
+	//
+	p.out.WriteString("\n" + strings.Repeat(" ", indent))
+	p.column = indent
+}
+
+// Start a new line with a given indentation, preceded by a blank line if
+// requested.
+func (p *formatPrinter) separate(indent int, blank bool) {
+	if blank {
+		p.newline(0)
+	}
+	//
+	p.newline(indent)
+}
+
+// Record a trailing comment to be printed at the end of the current line.
+func (p *formatPrinter) trail(comment string) {
+	if comment == "" {
+		return
+	} else if p.pending != "" {
+		p.pending = p.pending + " " + comment
+	} else {
+		p.pending = comment
+	}
+}
+
+// Print a node on a new line, preceded by its comments.  If this is the first
+// item printed, then no new line is started.
+func (p *formatPrinter) printOnNewLine(node *formatNode, indent int, first bool) {
+	p.startLine(node, indent, first)
+	p.print(node, -1)
+}
+
+// Print the comments preceding a given node, and then start the line on which
+// the node itself will be printed.  If this is the first item printed, then no
+// new line is started.
+func (p *formatPrinter) startLine(node *formatNode, indent int, first bool) {
+	for _, c := range node.comments {
+		if !first {
+			p.separate(indent, c.blank)
+		}
+		//
+		p.write(c.text)
+		first = false
+	}
+	//
+	if !first {
+		p.separate(indent, node.blank)
+	}
+}
+
+// Print a node at the current column.  If the node is a column declaration, then
+// its name is padded to the given width (unless this is negative).
+func (p *formatPrinter) print(node *formatNode, padding int) {
+	if text, ok := flatColumn(node, padding); ok && p.fits(text) {
+		p.write(text)
+	} else if text, ok := node.flat(); ok && p.fits(text) {
+		p.write(text)
+	} else if !node.isList() {
+		p.write(node.text)
+		//
+		if len(node.children) > 0 {
+			p.print(node.children[0], -1)
+		}
+	} else {
+		p.printList(node)
+	}
+}
+
+// Print a list which does not fit on a single line.
+func (p *formatPrinter) printList(node *formatNode) {
+	var (
+		form, ok = node.form()
+		outer    = p.suffix
+		indent   int
+	)
+	//
+	p.write(node.open)
+	//
+	if !ok && len(node.children) > 1 && !node.children[0].isList() && len(node.children[0].children) == 0 {
+		// Default layout for applications
+		form = formatForm{2, alignedLayout, -1}
+	} else if !ok {
+		// Default layout for everything else
+		form = formatForm{1, alignedLayout, -1}
+	}
+	//
+	header := headerOf(node, form.header)
+	// Determine indentation of remaining elements
+	if form.layout != alignedLayout {
+		indent = p.column + 1
+	} else if form.header > 1 {
+		indent = p.column + utf8.RuneCountInString(node.children[0].text) + 1
+	} else {
+		indent = p.column
+	}
+	// Print header
+	for i := 0; i < header; i++ {
+		p.follow(node, i, outer)
+		//
+		if text, ok := node.children[i].flat(); i != 0 && form.layout != alignedLayout && (!ok || !p.fits(" "+text)) {
+			// Header element does not fit on the first line.
+			header = i
+			break
+		} else if i != 0 {
+			p.write(" ")
+		}
+		//
+		p.printElement(node, form, i)
+	}
+	// Print remaining elements
+	switch form.layout {
+	case pairsLayout:
+		p.printPairs(node, header, indent, outer)
+	case columnsLayout:
+		p.printColumns(node, header, indent, outer)
+	default:
+		for i := header; i < len(node.children); i++ {
+			p.follow(node, i, outer)
+			p.startLine(node.children[i], indent, false)
+			p.printElement(node, form, i)
+		}
+	}
+	//
+	p.printFooter(node, indent)
+}
+
+// Print the ith element of a given list at the current column, along with its
+// trailing comment.  Column lists (e.g. in a "defperspective") which cannot fit
+// on the current line are printed with their columns aligned.
+func (p *formatPrinter) printElement(node *formatNode, form formatForm, i int) {
+	var child = node.children[i]
+	//
+	if text, ok := child.flat(); i == form.columns && child.open == "(" && (!ok || !p.fits(text)) {
+		outer := p.suffix
+		//
+		p.write(child.open)
+		p.printColumns(child, 0, p.column, outer)
+		p.printFooter(child, p.column)
+	} else {
+		p.print(child, -1)
+	}
+	//
+	p.trail(child.trailing)
+}
+
+// Print elements of a list as name-value pairs, starting from a given element.
+// Each pair is placed on a new line, with the values aligned.
+func (p *formatPrinter) printPairs(node *formatNode, start int, indent int, outer int) {
+	var (
+		children = node.children
+		padding  = 0
+	)
+	// Determine padding
+	for i := start; i < len(children); i += 2 {
+		if text, ok := children[i].flat(); ok {
+			padding = max(padding, utf8.RuneCountInString(text))
+		}
+	}
+	//
+	for i := start; i < len(children); i += 2 {
+		name := children[i]
+		//
+		p.follow(node, i, outer)
+		p.printOnNewLine(name, indent, false)
+		//
+		if i+1 == len(children) {
+			p.trail(name.trailing)
+		} else if value := children[i+1]; name.trailing != "" || len(value.comments) > 0 {
+			// Cannot place value on same line as name
+			p.trail(name.trailing)
+			p.follow(node, i+1, outer)
+			p.printOnNewLine(value, indent+2, false)
+			p.trail(value.trailing)
+		} else {
+			text, _ := name.flat()
+			p.write(strings.Repeat(" ", max(0, padding-utf8.RuneCountInString(text))+1))
+			p.follow(node, i+1, outer)
+			p.print(value, -1)
+			p.trail(value.trailing)
+		}
+	}
+}
+
+// Print elements of a list as column declarations, starting from a given
+// element.  Each column is placed on a separate line with the given
+// indentation, and their names are padded such that their attributes are
+// aligned.  Observe that the first column is printed on the current line if it
+// is already positioned at the given indentation.
+func (p *formatPrinter) printColumns(node *formatNode, start int, indent int, outer int) {
+	var padding = 0
+	//
+	for _, column := range node.children[start:] {
+		if column.open == "(" && len(column.children) > 1 && !column.children[0].isList() {
+			padding = max(padding, utf8.RuneCountInString(column.children[0].text))
+		}
+	}
+	//
+	for i := start; i < len(node.children); i++ {
+		column := node.children[i]
+		//
+		p.follow(node, i, outer)
+		p.startLine(column, indent, i == start && p.column == indent)
+		p.print(column, padding)
+		p.trail(column.trailing)
+	}
+}
+
+// Determine the number of closing brackets which follow the ith element of a
+// given list on the same line, where outer is the number following the list
+// itself.
+func (p *formatPrinter) follow(node *formatNode, i int, outer int) {
+	if i == len(node.children)-1 && len(node.footer) == 0 {
+		p.suffix = outer + len(node.close)
+	} else {
+		p.suffix = 0
+	}
+}
+
+// Print any comments following the last element of a list, followed by its
+// closing bracket.
+func (p *formatPrinter) printFooter(node *formatNode, indent int) {
+	for _, c := range node.footer {
+		p.separate(indent, c.blank)
+		p.write(c.text)
+	}
+	//
+	if len(node.footer) > 0 {
+		p.newline(indent)
+	}
+	//
+	p.write(node.close)
+}
+
+// Determine whether a given piece of text fits on the current line, including
+// any closing brackets which follow it.
+func (p *formatPrinter) fits(text string) bool {
+	return p.column+utf8.RuneCountInString(text)+p.suffix <= p.width
+}
+
+// Determine how many elements of a given list can be printed on the first line.
+// This is limited by the form, but also by any comments.  Specifically,
+// comments preceding an element force it onto a new line, whilst a trailing
+// comment must finish the line.
+func headerOf(node *formatNode, header int) int {
+	var children = node.children
+	//
+	header = min(header, len(children))
+	//
+	for i := 1; i < header; i++ {
+		if len(children[i].comments) > 0 || children[i-1].trailing != "" {
+			return i
+		} else if !children[i].simple() && i < header-1 {
+			return i + 1
+		}
+	}
+	//
+	return header
+}
+
+// Print a column declaration on a single line, where the name is padded to a
+// given width.  This fails if the node is not a column declaration (i.e. a
+// list whose head is a symbol), or the padding is negative.
+func flatColumn(node *formatNode, padding int) (string, bool) {
+	if padding < 0 || node.open != "(" || len(node.children) < 2 || node.children[0].isList() {
+		return "", false
+	} else if _, ok := node.flat(); !ok {
+		return "", false
+	}
+	//
+	var (
+		name     = node.children[0].text
+		elements = []string{name + strings.Repeat(" ", max(0, padding-utf8.RuneCountInString(name)))}
+	)
+	//
+	for _, child := range node.children[1:] {
+		text, _ := child.flat()
+		elements = append(elements, text)
+	}
+	//
+	return node.open + strings.Join(elements, " ") + node.close, true
+}
@@ -0,0 +1,121 @@
+package corset
+
+import (
+	"strings"
+
+	"github.com/consensys/go-corset/pkg/corset/compiler"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+// Warning defines the kind of warnings that can be reported by the linter.
+type Warning = compiler.Warning
+
+// LINT_IGNORE is the directive used within a comment to suppress warnings.  A
+// comment such as ";; lint:ignore unused-column" suppresses warnings of the
+// given kind(s) reported on the same line or, if the comment is on a line by
+// itself, on the line immediately following.  If no kinds are given, then all
+// warnings are suppressed.
+const LINT_IGNORE = "lint:ignore"
+
+// LintSourceFiles checks one or more source files for common problems which do
+// not prevent compilation, but which may indicate mistakes (e.g. unused
+// columns).  Warnings are not reported for the standard library, or where they
+// have been suppressed by a comment.  This process fails if the source files
+// are mal-formed, or contain syntax errors or other forms of error (e.g. type
+// errors).
+func LintSourceFiles(stdlib bool, srcfiles []*sexp.SourceFile) ([]Warning, []SyntaxError) {
+	var (
+		warnings     []Warning
+		suppressions = make(map[string]map[int][]string)
+		files        = includeStdlib(stdlib, srcfiles)
+	)
+	// Parse all source files (inc stdblib if applicable).
+	circuit, srcmap, errs := compiler.ParseSourceFiles(files)
+	// Check for parsing errors
+	if errs != nil {
+		return nil, errs
+	}
+	// Resolve and type check circuit
+	_, res_errs := compiler.ResolveCircuit(srcmap, &circuit)
+	type_errs := compiler.TypeCheckCircuit(srcmap, &circuit)
+	// Don't proceed if errors at this point.
+	if len(res_errs) > 0 || len(type_errs) > 0 {
+		return nil, append(res_errs, type_errs...)
+	}
+	// Filter out any warnings which are suppressed, or which arise from the
+	// standard library.
+	for _, warning := range compiler.LintCircuit(srcmap, &circuit) {
+		srcfile := warning.Error.SourceFile()
+		//
+		if stdlib && srcfile.Filename() == files[len(files)-1].Filename() {
+			continue
+		} else if _, ok := suppressions[srcfile.Filename()]; !ok {
+			suppressions[srcfile.Filename()] = lintSuppressions(srcfile)
+		}
+		//
+		line := warning.Error.FirstEnclosingLine()
+		lines := suppressions[srcfile.Filename()]
+		//
+		if !isSuppressed(warning.Kind, lines[line.Number()]) {
+			warnings = append(warnings, warning)
+		}
+	}
+	//
+	return warnings, nil
+}
+
+// Determine the suppression comments in a given source file, mapping each line
+// to which such a comment applies to the kinds of warnings suppressed.  The
+// kind "*" indicates all warnings are suppressed.
+func lintSuppressions(srcfile *sexp.SourceFile) map[int][]string {
+	var (
+		parser       = sexp.NewParser(srcfile)
+		text         = srcfile.Contents()
+		suppressions = make(map[int][]string)
+	)
+	// Parse file to identify comments.  Errors can be ignored here, since the
+	// file has already been parsed successfully.
+	for {
+		if term, err := parser.Parse(); term == nil || err != nil {
+			break
+		}
+	}
+	//
+	for _, span := range parser.Comments() {
+		comment := strings.TrimLeft(string(text[span.Start():span.End()]), "; \t")
+		//
+		if kinds, ok := strings.CutPrefix(comment, LINT_IGNORE); ok {
+			line := srcfile.FindFirstEnclosingLine(span)
+			target := line.Number()
+			// A comment on its own line applies to the following line.
+			if strings.TrimSpace(string(text[line.Start():span.Start()])) == "" {
+				target++
+			}
+			//
+			if kinds := strings.FieldsFunc(kinds, isSeparator); len(kinds) > 0 {
+				suppressions[target] = append(suppressions[target], kinds...)
+			} else {
+				// No kinds given, hence all are suppressed
+				suppressions[target] = append(suppressions[target], "*")
+			}
+		}
+	}
+	//
+	return suppressions
+}
+
+// Determine whether a given kind of warning is suppressed by the given
+// suppression comment (if any).
+func isSuppressed(kind string, kinds []string) bool {
+	for _, k := range kinds {
+		if k == kind || k == "*" {
+			return true
+		}
+	}
+	//
+	return false
+}
+
+func isSeparator(c rune) bool {
+	return c == ' ' || c == ',' || c == '\t'
+}
@@ -0,0 +1,318 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/consensys/go-corset/pkg/corset"
+	"github.com/consensys/go-corset/pkg/corset/ast"
+	"github.com/consensys/go-corset/pkg/corset/compiler"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+// analysis captures the result of compiling a given source file, such that
+// questions about it can be answered (e.g. which definition a given symbol
+// refers to, etc).
+type analysis struct {
+	// Errors arising from compiling the source file.
+	errors []sexp.SyntaxError
+	// Indicates whether or not the source file (and anything it includes)
+	// could be parsed.  If not, then there are no definitions or occurrences.
+	parsed bool
+	// Source maps for all nodes in the circuit.
+	srcmap *sexp.SourceMaps[ast.Node]
+	// All symbols defined in the circuit (including any included files and the
+	// standard library).
+	definitions []definition
+	// All symbols used or defined in the source file being analysed.
+	occurrences []occurrence
+	// All module declarations in the source file being analysed, in order of
+	// appearance.
+	modules []moduleDeclaration
+}
+
+// definition identifies a symbol defined within a given module by a given
+// declaration.
+type definition struct {
+	// Enclosing module of the definition ("" for the root module).
+	module string
+	// Declaration responsible for this definition.
+	decl ast.Declaration
+	// The symbol defined.
+	symbol ast.SymbolDefinition
+}
+
+// occurrence identifies a span within the source file being analysed where a
+// symbol is used (or defined), along with the definition(s) to which it refers.
+// Observe that an invocation of an overloaded function refers to every
+// definition of that function.
+type occurrence struct {
+	span        sexp.Span
+	definitions []*definition
+}
+
+// moduleDeclaration identifies the span of a module declaration (e.g. "(module
+// m1)") within the source file being analysed.
+type moduleDeclaration struct {
+	name string
+	span sexp.Span
+}
+
+// analyse a given source file by parsing it (along with the standard library,
+// if requested), and then resolving and type checking the resulting circuit.
+// The resolved circuit is indexed for answering subsequent queries and, only if
+// no errors have arisen thus far, is then fully compiled in order to report any
+// remaining errors.
+func analyse(stdlib bool, srcfile *sexp.SourceFile) *analysis {
+	var (
+		srcfiles = []*sexp.SourceFile{srcfile}
+		result   analysis
+	)
+	//
+	if stdlib {
+		srcfiles = append(srcfiles, sexp.NewSourceFile("stdlib.lisp", corset.STDLIB))
+	}
+	// Parse source file(s)
+	circuit, srcmap, errs := compiler.ParseSourceFiles(srcfiles)
+	// Check for parsing errors
+	if len(errs) > 0 {
+		result.errors = errs
+		return &result
+	}
+	// Resolve and type check circuit
+	scope, res_errs := compiler.ResolveCircuit(srcmap, &circuit)
+	type_errs := compiler.TypeCheckCircuit(srcmap, &circuit)
+	//
+	result.parsed = true
+	result.srcmap = srcmap
+	result.errors = append(res_errs, type_errs...)
+	result.index(srcfile, &circuit)
+	// Fully compile the circuit to report any remaining errors.  Observe that
+	// the circuit must be indexed beforehand, since preprocessing modifies it.
+	if len(result.errors) == 0 {
+		result.errors = compile(scope, srcmap, &circuit)
+	}
+	//
+	return &result
+}
+
+// Compile a resolved and type checked circuit, returning any errors arising.
+// This follows the remaining stages of corset.Compiler, but avoids parsing and
+// resolving the circuit again.
+func compile(scope *compiler.ModuleScope, srcmap *sexp.SourceMaps[ast.Node], circuit *ast.Circuit) []sexp.SyntaxError {
+	if errs := compiler.CheckConstantAssertions(srcmap, circuit); len(errs) > 0 {
+		return errs
+	} else if errs := compiler.PreprocessCircuit(false, srcmap, circuit); len(errs) > 0 {
+		return errs
+	}
+	//
+	environment := compiler.NewGlobalEnvironment(scope, compiler.DEFAULT_ALLOCATOR)
+	_, errs := compiler.TranslateCircuit(environment, srcmap, circuit)
+	//
+	return errs
+}
+
+// Index all definitions in the circuit, along with all occurrences of symbols
+// within the given source file.
+func (p *analysis) index(srcfile *sexp.SourceFile, circuit *ast.Circuit) {
+	var bindings = make(map[ast.Binding]*definition)
+	// Collect all definitions
+	p.indexDefinitions("", circuit.Declarations)
+	//
+	for _, m := range circuit.Modules {
+		p.indexDefinitions(m.Name, m.Declarations)
+	}
+	// Index definitions by their bindings
+	for i := range p.definitions {
+		def := &p.definitions[i]
+		bindings[def.symbol.Binding()] = def
+		// Record definitions occurring in this file
+		p.addOccurrence(srcfile, def.symbol, def)
+	}
+	// Collect all uses of symbols
+	p.indexDependencies(srcfile, bindings, circuit.Declarations)
+	//
+	for _, m := range circuit.Modules {
+		p.indexDependencies(srcfile, bindings, m.Declarations)
+	}
+	// Collect all module declarations
+	p.indexModules(srcfile)
+}
+
+// Index all module declarations within the given source file.  Since modules
+// are not themselves nodes of the circuit, these are identified from the
+// S-Expressions making up the source file.
+func (p *analysis) indexModules(srcfile *sexp.SourceFile) {
+	terms, srcmap, err := srcfile.ParseAll()
+	//
+	if err != nil {
+		return
+	}
+	//
+	for _, term := range terms {
+		if l := term.AsList(); l != nil && l.MatchSymbols(2, "module") && l.Get(1).AsSymbol() != nil {
+			p.modules = append(p.modules, moduleDeclaration{l.Get(1).AsSymbol().Value, srcmap.Get(term)})
+		}
+	}
+}
+
+func (p *analysis) indexDefinitions(module string, decls []ast.Declaration) {
+	for _, decl := range decls {
+		for iter := decl.Definitions(); iter.HasNext(); {
+			p.definitions = append(p.definitions, definition{module, decl, iter.Next()})
+		}
+	}
+}
+
+func (p *analysis) indexDependencies(srcfile *sexp.SourceFile, bindings map[ast.Binding]*definition,
+	decls []ast.Declaration) {
+	//
+	for _, decl := range decls {
+		for iter := decl.Dependencies(); iter.HasNext(); {
+			symbol := iter.Next()
+			//
+			if !symbol.IsResolved() {
+				continue
+			} else if def, ok := bindings[symbol.Binding()]; ok {
+				p.addOccurrence(srcfile, symbol, def)
+			} else if symbol.IsFunction() {
+				// Must be an overloaded function, hence refers to all
+				// definitions of the same name.
+				p.addOccurrence(srcfile, symbol, p.functionsNamed(symbol.Path().Tail())...)
+			}
+		}
+	}
+}
+
+// Record an occurrence of a given node which refers to the given definitions,
+// provided the node is located in the given source file.
+func (p *analysis) addOccurrence(srcfile *sexp.SourceFile, node ast.Node, defs ...*definition) {
+	if file, span, ok := p.srcmap.Lookup(node); ok && file.Filename() == srcfile.Filename() && len(defs) > 0 {
+		p.occurrences = append(p.occurrences, occurrence{span, defs})
+	}
+}
+
+// Identify all function definitions with a given name.
+func (p *analysis) functionsNamed(name string) []*definition {
+	var defs []*definition
+	//
+	for i, def := range p.definitions {
+		if def.symbol.IsFunction() && def.symbol.Name() == name {
+			defs = append(defs, &p.definitions[i])
+		}
+	}
+	//
+	return defs
+}
+
+// Find the innermost occurrence enclosing a given offset within the source
+// file being analysed, or nil if no such occurrence exists.
+func (p *analysis) occurrenceAt(offset int) *occurrence {
+	var innermost *occurrence
+	//
+	for i, o := range p.occurrences {
+		if o.span.Start() <= offset && offset <= o.span.End() {
+			if innermost == nil || o.span.Length() < innermost.span.Length() {
+				innermost = &p.occurrences[i]
+			}
+		}
+	}
+	//
+	return innermost
+}
+
+// Determine the module enclosing a given offset within the source file being
+// analysed, which is that of the last module declaration preceding it (or the
+// root module if none).
+func (p *analysis) moduleAt(offset int) string {
+	var module = ""
+	//
+	for _, m := range p.modules {
+		if m.span.Start() < offset {
+			module = m.name
+		}
+	}
+	//
+	return module
+}
+
+// Identify all definitions visible within a given module.  That is, all
+// columns of the module along with all constants and functions declared either
+// in the module or the root module.
+func (p *analysis) definitionsVisibleIn(module string) []*definition {
+	var defs []*definition
+	//
+	for i, def := range p.definitions {
+		_, column := def.symbol.Binding().(*ast.ColumnBinding)
+		//
+		if def.module == module || (def.module == "" && !column) {
+			defs = append(defs, &p.definitions[i])
+		}
+	}
+	//
+	return defs
+}
+
+// describe a given definition in a form suitable for displaying on hover.
+func describe(def *definition) string {
+	switch b := def.symbol.Binding().(type) {
+	case *ast.ColumnBinding:
+		var kind = "column"
+		//
+		if b.Computed {
+			kind = "computed column"
+		}
+		//
+		if b.DataType == nil {
+			return fmt.Sprintf("%s `%s`", kind, b.Path.String())
+		}
+		//
+		return fmt.Sprintf("%s `%s` `:%s`", kind, b.Path.String(), b.DataType.String())
+	case *ast.ConstantBinding:
+		if val := b.Value.AsConstant(); val != nil {
+			return fmt.Sprintf("constant `%s` = %s", b.Path.String(), val.String())
+		}
+		//
+		return fmt.Sprintf("constant `%s`", b.Path.String())
+	case *ast.DefunBinding:
+		if fun, ok := def.decl.(*ast.DefFun); ok {
+			return fmt.Sprintf("function `%s`", describeSignature(fun, b))
+		}
+	}
+	//
+	return fmt.Sprintf("`%s`", def.symbol.Path().String())
+}
+
+// describeSignature describes the signature of a given function, such as "(defpurefun ((f :u8) (x
+// :u8) y))".
+func describeSignature(fun *ast.DefFun, binding *ast.DefunBinding) string {
+	var (
+		builder   strings.Builder
+		name      = fun.Name()
+		signature = binding.Signature()
+	)
+	//
+	if fun.IsPure() {
+		builder.WriteString("(defpurefun (")
+	} else {
+		builder.WriteString("(defun (")
+	}
+	//
+	if ret := signature.Return(); ret != nil {
+		name = fmt.Sprintf("(%s :%s)", name, ret.String())
+	}
+	//
+	builder.WriteString(name)
+	//
+	for _, param := range fun.Parameters() {
+		if param.Binding.DataType != nil {
+			builder.WriteString(fmt.Sprintf(" (%s :%s)", param.Binding.Name, param.Binding.DataType.String()))
+		} else {
+			builder.WriteString(fmt.Sprintf(" %s", param.Binding.Name))
+		}
+	}
+	//
+	builder.WriteString("))")
+	//
+	return builder.String()
+}
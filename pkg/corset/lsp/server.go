@@ -0,0 +1,475 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"net/url"
+	"path/filepath"
+	"strconv"
+
+	"github.com/consensys/go-corset/pkg/corset/ast"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+	log "github.com/sirupsen/logrus"
+)
+
+// Server implements a language server for Corset source files, which
+// communicates with its client using the Language Server Protocol (LSP).  The
+// server supports diagnostics (i.e. reporting syntax and type errors), going to
+// the definition of a column, constant or function, hovering over a symbol to
+// see its type, and completion of names visible within the enclosing module.
+// Each open document is compiled independently (along with any files it
+// includes and, optionally, the standard library).
+type Server struct {
+	// Determines whether or not the standard library is included.
+	stdlib bool
+	// Stream from which messages are read.
+	reader *bufio.Reader
+	// Stream to which messages are written.
+	writer io.Writer
+	// Documents currently open, indexed by their URI.
+	documents map[string]*document
+	// Indicates whether the client has requested the server shutdown.
+	shutdown bool
+}
+
+// document represents a source file currently opened by the client.
+type document struct {
+	// Source file representing the current contents of the document.
+	srcfile *sexp.SourceFile
+	// Most recent analysis of this document.
+	analysis *analysis
+	// Most recent analysis of this document which parsed successfully.  This
+	// is used for completion, since documents being edited are frequently
+	// malformed.
+	parsed *analysis
+	// URIs of files for which diagnostics were last published on behalf of
+	// this document.
+	published []string
+}
+
+// NewServer constructs a new language server which reads messages from a given
+// reader, and writes messages to a given writer.
+func NewServer(stdlib bool, reader io.Reader, writer io.Writer) *Server {
+	return &Server{stdlib, bufio.NewReader(reader), writer, make(map[string]*document), false}
+}
+
+// Run the server until the client asks it to exit, or the input stream is
+// closed.  An error is returned if the server exits without first being asked to
+// shutdown, or if a malformed message is received.
+func (p *Server) Run() error {
+	for {
+		msg, err := p.read()
+		//
+		if err == io.EOF {
+			return errors.New("unexpected end of input")
+		} else if err != nil {
+			return err
+		} else if msg.Method == "exit" {
+			if !p.shutdown {
+				return errors.New("exit without shutdown")
+			}
+			//
+			return nil
+		}
+		//
+		if err := p.dispatch(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// Dispatch a given message to the appropriate handler.  Unknown requests are
+// reported back to the client, whilst unknown notifications are ignored.
+func (p *Server) dispatch(msg *message) error {
+	log.Debugf("lsp: received %s", msg.Method)
+	//
+	result, err := p.handle(msg)
+	// Notifications do not receive a response
+	if msg.ID == nil {
+		return nil
+	} else if err != nil {
+		return p.reply(msg.ID, nil, err)
+	}
+	//
+	return p.reply(msg.ID, result, nil)
+}
+
+// Handle a given message, returning the result (if any).  Any internal failure
+// arising whilst handling the message (e.g. when compiling a malformed source
+// file) is reported as an error, rather than bringing down the server.
+func (p *Server) handle(msg *message) (result any, rerr *responseError) {
+	var err error
+	//
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("lsp: internal error handling %s (%v)", msg.Method, r)
+			result, rerr = nil, &responseError{errInternalError, fmt.Sprintf("internal error (%v)", r)}
+		}
+	}()
+	//
+	switch msg.Method {
+	case "initialize":
+		result = p.initialize()
+	case "shutdown":
+		p.shutdown = true
+	case "textDocument/didOpen":
+		err = p.didOpen(msg.Params)
+	case "textDocument/didChange":
+		err = p.didChange(msg.Params)
+	case "textDocument/didClose":
+		err = p.didClose(msg.Params)
+	case "textDocument/definition":
+		result, err = p.definition(msg.Params)
+	case "textDocument/hover":
+		result, err = p.hover(msg.Params)
+	case "textDocument/completion":
+		result, err = p.completion(msg.Params)
+	default:
+		return nil, &responseError{errMethodNotFound, fmt.Sprintf("unknown method %s", msg.Method)}
+	}
+	//
+	if err != nil {
+		return nil, &responseError{errInvalidParams, err.Error()}
+	}
+	//
+	return result, nil
+}
+
+// ============================================================================
+// Handlers
+// ============================================================================
+
+func (p *Server) initialize() *initializeResult {
+	return &initializeResult{
+		Capabilities: serverCapabilities{
+			TextDocumentSync:   textDocumentSyncFull,
+			HoverProvider:      true,
+			DefinitionProvider: true,
+			CompletionProvider: completionOptions{TriggerCharacters: []string{"("}},
+		},
+		ServerInfo: serverInfo{Name: "go-corset"},
+	}
+}
+
+func (p *Server) didOpen(params json.RawMessage) error {
+	var args didOpenParams
+	//
+	if err := json.Unmarshal(params, &args); err != nil {
+		return err
+	}
+	//
+	p.documents[args.TextDocument.URI] = &document{}
+	//
+	return p.update(args.TextDocument.URI, args.TextDocument.Text)
+}
+
+func (p *Server) didChange(params json.RawMessage) error {
+	var args didChangeParams
+	//
+	if err := json.Unmarshal(params, &args); err != nil {
+		return err
+	} else if _, ok := p.documents[args.TextDocument.URI]; !ok {
+		return fmt.Errorf("unknown document %s", args.TextDocument.URI)
+	} else if len(args.ContentChanges) == 0 {
+		return nil
+	}
+	// Since full synchronisation is used, the last change holds the entire
+	// contents of the document.
+	text := args.ContentChanges[len(args.ContentChanges)-1].Text
+	//
+	return p.update(args.TextDocument.URI, text)
+}
+
+func (p *Server) didClose(params json.RawMessage) error {
+	var args didCloseParams
+	//
+	if err := json.Unmarshal(params, &args); err != nil {
+		return err
+	}
+	//
+	doc, ok := p.documents[args.TextDocument.URI]
+	//
+	if !ok {
+		return fmt.Errorf("unknown document %s", args.TextDocument.URI)
+	}
+	// Clear any diagnostics previously published
+	for _, uri := range doc.published {
+		if err := p.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{uri, []diagnostic{}}); err != nil {
+			return err
+		}
+	}
+	//
+	delete(p.documents, args.TextDocument.URI)
+	//
+	return nil
+}
+
+func (p *Server) definition(params json.RawMessage) ([]location, error) {
+	var locations []location
+	//
+	doc, offset, err := p.lookup(params)
+	//
+	if err != nil || doc.analysis == nil {
+		return nil, err
+	} else if o := doc.analysis.occurrenceAt(offset); o != nil {
+		for _, def := range o.definitions {
+			file, span, ok := doc.analysis.srcmap.Lookup(def.symbol)
+			// Only definitions in files on disk can be navigated to (e.g.
+			// not those in the standard library).
+			if ok && filepath.IsAbs(file.Filename()) {
+				locations = append(locations, location{pathToURI(file.Filename()), rangeOf(file, span)})
+			}
+		}
+	}
+	//
+	return locations, nil
+}
+
+func (p *Server) hover(params json.RawMessage) (*hover, error) {
+	doc, offset, err := p.lookup(params)
+	//
+	if err != nil || doc.analysis == nil {
+		return nil, err
+	} else if o := doc.analysis.occurrenceAt(offset); o != nil {
+		var contents = describe(o.definitions[0])
+		//
+		for _, def := range o.definitions[1:] {
+			contents = fmt.Sprintf("%s\n\n%s", contents, describe(def))
+		}
+		//
+		return &hover{markupContent{"markdown", contents}, rangeOf(doc.srcfile, o.span)}, nil
+	}
+	//
+	return nil, nil
+}
+
+func (p *Server) completion(params json.RawMessage) ([]completionItem, error) {
+	var items = []completionItem{}
+	//
+	doc, offset, err := p.lookup(params)
+	//
+	if err != nil || doc.parsed == nil {
+		return items, err
+	}
+	//
+	for _, def := range doc.parsed.definitionsVisibleIn(doc.parsed.moduleAt(offset)) {
+		kind := completionKindVariable
+		//
+		switch def.symbol.Binding().(type) {
+		case *ast.ConstantBinding:
+			kind = completionKindConstant
+		case *ast.DefunBinding:
+			kind = completionKindFunction
+		}
+		//
+		items = append(items, completionItem{def.symbol.Name(), kind, describe(def)})
+	}
+	//
+	return items, nil
+}
+
+// Update the contents of a given document, and then analyse it and publish
+// any diagnostics arising.
+func (p *Server) update(uri string, text string) error {
+	var (
+		doc         = p.documents[uri]
+		diagnostics = map[string][]diagnostic{uri: {}}
+		published   []string
+	)
+	//
+	filename, err := uriToPath(uri)
+	//
+	if err != nil {
+		return err
+	}
+	//
+	// Discard the previous analysis, so that it is not used for the new
+	// contents should analysis fail.
+	doc.srcfile, doc.analysis = sexp.NewSourceFile(filename, []byte(text)), nil
+	doc.analysis = analyse(p.stdlib, doc.srcfile)
+	//
+	if doc.analysis.parsed {
+		doc.parsed = doc.analysis
+	}
+	// Group diagnostics by file
+	for _, e := range doc.analysis.errors {
+		file := e.SourceFile()
+		// Diagnostics cannot be published for the standard library.
+		if filepath.IsAbs(file.Filename()) {
+			fileURI := pathToURI(file.Filename())
+			if file.Filename() == filename {
+				fileURI = uri
+			}
+			//
+			diagnostics[fileURI] = append(diagnostics[fileURI], diagnostic{
+				Range:    rangeOf(file, e.Span()),
+				Severity: diagnosticSeverityError,
+				Source:   "go-corset",
+				Message:  e.Message(),
+			})
+		}
+	}
+	// Clear diagnostics for files which no longer have any
+	for _, fileURI := range doc.published {
+		if _, ok := diagnostics[fileURI]; !ok {
+			diagnostics[fileURI] = []diagnostic{}
+		}
+	}
+	// Publish diagnostics
+	for fileURI, diags := range diagnostics {
+		if err := p.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{fileURI, diags}); err != nil {
+			return err
+		} else if len(diags) > 0 {
+			published = append(published, fileURI)
+		}
+	}
+	//
+	doc.published = published
+	//
+	return nil
+}
+
+// Lookup the document and offset identified by a given set of position
+// parameters.
+func (p *Server) lookup(params json.RawMessage) (*document, int, error) {
+	var args textDocumentPositionParams
+	//
+	if err := json.Unmarshal(params, &args); err != nil {
+		return nil, 0, err
+	}
+	//
+	doc, ok := p.documents[args.TextDocument.URI]
+	//
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown document %s", args.TextDocument.URI)
+	}
+	//
+	return doc, offsetOf(doc.srcfile, args.Position), nil
+}
+
+// ============================================================================
+// Messages
+// ============================================================================
+
+// Read the next message from the client.  Each message consists of a header,
+// which must include the length of the content, followed by the content itself.
+func (p *Server) read() (*message, error) {
+	var msg message
+	//
+	header, err := textproto.NewReader(p.reader).ReadMIMEHeader()
+	//
+	if err != nil {
+		return nil, err
+	}
+	//
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	//
+	if err != nil {
+		return nil, errors.New("invalid or missing content length")
+	}
+	//
+	content := make([]byte, length)
+	//
+	if _, err := io.ReadFull(p.reader, content); err != nil {
+		return nil, err
+	} else if err := json.Unmarshal(content, &msg); err != nil {
+		return nil, err
+	}
+	//
+	return &msg, nil
+}
+
+func (p *Server) reply(id *json.RawMessage, result any, err *responseError) error {
+	return p.write(response{"2.0", id, result, err})
+}
+
+func (p *Server) notify(method string, params any) error {
+	return p.write(notification{"2.0", method, params})
+}
+
+func (p *Server) write(msg any) error {
+	content, err := json.Marshal(msg)
+	//
+	if err != nil {
+		return err
+	}
+	//
+	_, err = fmt.Fprintf(p.writer, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	//
+	return err
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// Convert a given span within a source file into a range.
+func rangeOf(srcfile *sexp.SourceFile, span sexp.Span) textRange {
+	return textRange{positionOf(srcfile, span.Start()), positionOf(srcfile, span.End())}
+}
+
+// Convert a given offset within a source file into a position.  Observe that
+// characters are measured in UTF-16 code units.
+func positionOf(srcfile *sexp.SourceFile, offset int) position {
+	var (
+		line   = srcfile.FindFirstEnclosingLine(sexp.NewSpan(offset, offset))
+		column = 0
+	)
+	//
+	for _, r := range srcfile.Contents()[line.Start():offset] {
+		column += utf16Length(r)
+	}
+	//
+	return position{line.Number() - 1, column}
+}
+
+// Convert a given position into an offset within a source file.  Positions
+// beyond the end of a line (or the file) are clamped accordingly.
+func offsetOf(srcfile *sexp.SourceFile, pos position) int {
+	var (
+		contents = srcfile.Contents()
+		offset   = 0
+	)
+	// Find start of line
+	for line := 0; line < pos.Line && offset < len(contents); offset++ {
+		if contents[offset] == '\n' {
+			line++
+		}
+	}
+	// Find character within line
+	for column := 0; column < pos.Character && offset < len(contents) && contents[offset] != '\n'; offset++ {
+		column += utf16Length(contents[offset])
+	}
+	//
+	return offset
+}
+
+// Determine the number of UTF-16 code units required to encode a given rune.
+func utf16Length(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	//
+	return 1
+}
+
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	//
+	if err != nil {
+		return "", err
+	} else if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported uri %s", uri)
+	}
+	//
+	return filepath.FromSlash(u.Path), nil
+}
+
+func pathToURI(path string) string {
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return u.String()
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/go-corset/pkg/mir"
 	sc "github.com/consensys/go-corset/pkg/schema"
+	"github.com/consensys/go-corset/pkg/util"
 )
 
 // LowerToMir lowers (or refines) an HIR table into an MIR schema.  That means
@@ -20,7 +21,7 @@ func (p *Schema) LowerToMir() *mir.Schema {
 	// Lower columns
 	for _, input := range p.inputs {
 		col := input.(DataColumn)
-		mirSchema.AddDataColumn(col.Context(), col.Name(), col.Type())
+		mirSchema.AddDataColumn(col.Context(), col.Name(), col.Type(), col.Display())
 	}
 	// Lower assignments (nothing to do here)
 	for _, a := range p.assignments {
@@ -74,7 +75,19 @@ func lowerLookupConstraint(c LookupConstraint, schema *mir.Schema) {
 		into[i] = lowerUnitTo(c.Targets[i], schema)
 	}
 	//
-	schema.AddLookupConstraint(c.Handle, c.SourceContext, c.TargetContext, from, into)
+	sourceSelector := lowerOptionalUnitTo(c.SourceSelector, schema)
+	targetSelector := lowerOptionalUnitTo(c.TargetSelector, schema)
+	//
+	schema.AddLookupConstraint(c.Handle, c.SourceContext, c.TargetContext, from, into, sourceSelector, targetSelector)
+}
+
+// Lower an optional unit expression (e.g. a lookup selector).
+func lowerOptionalUnitTo(e util.Option[UnitExpr], schema *mir.Schema) util.Option[mir.Expr] {
+	if e.HasValue() {
+		return util.Some(lowerUnitTo(e.Unwrap(), schema))
+	}
+	//
+	return util.None[mir.Expr]()
 }
 
 // Lower an expression which is expected to lower into a single expression.
@@ -38,6 +38,11 @@ type PropertyAssertion = *sc.PropertyAssertion[ZeroArrayTest]
 // Permutation captures the notion of a (sorted) permutation at the HIR level.
 type Permutation = *assignment.SortedPermutation
 
+// ComputedColumn captures the notion of a column whose values are determined by
+// an arbitrary expression at the HIR level.  As for lookups, the UnitExpr
+// adaptor is required and, hence, certain expression forms are not permitted.
+type ComputedColumn = *assignment.ComputedColumn[UnitExpr]
+
 // Schema for HIR constraints and columns.
 type Schema struct {
 	// The modules of the schema
@@ -77,15 +82,15 @@ func (p *Schema) AddModule(name string) uint {
 	return mid
 }
 
-// AddDataColumn appends a new data column with a given type.  Furthermore, the
-// type is enforced by the system when checking is enabled.
-func (p *Schema) AddDataColumn(context trace.Context, name string, base sc.Type) uint {
+// AddDataColumn appends a new data column with a given type and display mode.
+// Furthermore, the type is enforced by the system when checking is enabled.
+func (p *Schema) AddDataColumn(context trace.Context, name string, base sc.Type, display trace.Display) uint {
 	if context.Module() >= uint(len(p.modules)) {
 		panic(fmt.Sprintf("invalid module index (%d)", context.Module()))
 	}
 
 	cid := uint(len(p.inputs))
-	col := assignment.NewDataColumn(context, name, base)
+	col := assignment.NewDataColumn(context, name, base, display)
 	p.inputs = append(p.inputs, col)
 	// Update column cache
 	for c := col.Columns(); c.HasNext(); {
@@ -95,9 +100,11 @@ func (p *Schema) AddDataColumn(context trace.Context, name string, base sc.Type)
 	return cid
 }
 
-// AddLookupConstraint appends a new lookup constraint.
+// AddLookupConstraint appends a new lookup constraint.  Either selector can be
+// empty, in which case all rows are considered.
 func (p *Schema) AddLookupConstraint(handle string, source trace.Context, target trace.Context,
-	sources []UnitExpr, targets []UnitExpr) {
+	sources []UnitExpr, targets []UnitExpr, sourceSelector util.Option[UnitExpr],
+	targetSelector util.Option[UnitExpr]) {
 	if len(targets) != len(sources) {
 		panic("differeng number of target / source lookup columns")
 	}
@@ -106,7 +113,7 @@ func (p *Schema) AddLookupConstraint(handle string, source trace.Context, target
 
 	// Finally add constraint
 	p.constraints = append(p.constraints,
-		constraint.NewLookupConstraint(handle, source, target, sources, targets))
+		constraint.NewLookupConstraint(handle, source, target, sources, targets, sourceSelector, targetSelector))
 }
 
 // AddAssignment appends a new assignment (i.e. set of computed columns) to be
@@ -124,7 +131,7 @@ func (p *Schema) AddAssignment(c sc.Assignment) uint {
 }
 
 // AddVanishingConstraint appends a new vanishing constraint.
-func (p *Schema) AddVanishingConstraint(handle string, context trace.Context, domain util.Option[int], expr Expr) {
+func (p *Schema) AddVanishingConstraint(handle string, context trace.Context, domain util.Option[[]int], expr Expr) {
 	if context.Module() >= uint(len(p.modules)) {
 		panic(fmt.Sprintf("invalid module index (%d)", context.Module()))
 	}
@@ -286,4 +293,5 @@ func init() {
 	gob.Register(sc.Constraint(&constraint.RangeConstraint[MaxExpr]{}))
 	gob.Register(sc.Constraint(&constraint.PermutationConstraint{}))
 	gob.Register(sc.Constraint(&constraint.LookupConstraint[UnitExpr]{}))
+	gob.Register(sc.Declaration(&assignment.ComputedColumn[UnitExpr]{}))
 }
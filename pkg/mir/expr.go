@@ -357,7 +357,15 @@ func (p *ColumnAccess) RequiredCells(row int, tr trace.Trace) *util.AnySortedSet
 // values that this expression can evaluate to.
 func (p *ColumnAccess) IntRange(schema sc.Schema) *util.Interval {
 	bound := big.NewInt(2)
-	width := int64(schema.Columns().Nth(p.Column).DataType.BitWidth())
+	datatype := schema.Columns().Nth(p.Column).DataType
+	width := int64(datatype.BitWidth())
+	// Signed columns range over [-2^(n-1),2^(n-1))
+	if datatype.AsInt() != nil {
+		bound.Exp(bound, big.NewInt(width-1), nil)
+		//
+		return util.NewInterval(new(big.Int).Neg(bound), bound.Sub(bound, big.NewInt(1)))
+	}
+	//
 	bound.Exp(bound, big.NewInt(width), nil)
 	// Subtract 1 because interval is inclusive.
 	bound.Sub(bound, big.NewInt(1))
@@ -8,6 +8,7 @@ import (
 	air_gadgets "github.com/consensys/go-corset/pkg/air/gadgets"
 	sc "github.com/consensys/go-corset/pkg/schema"
 	"github.com/consensys/go-corset/pkg/trace"
+	"github.com/consensys/go-corset/pkg/util"
 )
 
 // LowerToAir lowers (or refines) an MIR table into an AIR schema.  That means
@@ -22,7 +23,7 @@ func (p *Schema) LowerToAir() *air.Schema {
 	// Add data columns.
 	for _, c := range p.inputs {
 		col := c.(DataColumn)
-		airSchema.AddColumn(col.Context(), col.Name(), col.Type())
+		airSchema.AddColumn(col.Context(), col.Name(), col.Type(), col.Display())
 	}
 	// Add Assignments. Again this has to be done first for things to work.
 	// Essentially to reflect the fact that these columns have been added above
@@ -59,6 +60,14 @@ func lowerAssignmentToAir(c sc.Assignment, mirSchema *Schema, airSchema *air.Sch
 		// Nothing to do for computation, as they can be passed directly down to
 		// the AIR level
 		return
+	} else if _, ok := c.(FixedColumn); ok {
+		// Nothing to do for fixed columns, as they can be passed directly down
+		// to the AIR level
+		return
+	} else if _, ok := c.(ComputedColumn); ok {
+		// Nothing to do for computed columns, as they can be passed directly
+		// down to the AIR level
+		return
 	} else {
 		panic("unknown assignment")
 	}
@@ -144,8 +153,25 @@ func lowerLookupConstraintToAir(c LookupConstraint, mirSchema *Schema, airSchema
 		targets[i] = air_gadgets.Expand(c.TargetContext, target, airSchema)
 		sources[i] = air_gadgets.Expand(c.SourceContext, source, airSchema)
 	}
+	// Lower and expand selectors (if applicable)
+	sourceSelector := lowerSelectorToAir(c.SourceContext, c.SourceSelector, mirSchema, airSchema)
+	targetSelector := lowerSelectorToAir(c.TargetContext, c.TargetSelector, mirSchema, airSchema)
 	// finally add the constraint
-	airSchema.AddLookupConstraint(c.Handle, c.SourceContext, c.TargetContext, sources, targets)
+	airSchema.AddLookupConstraint(c.Handle, c.SourceContext, c.TargetContext, sources, targets, sourceSelector,
+		targetSelector)
+}
+
+// Lower an optional lookup selector to the AIR level.  As for source and target
+// expressions, this requires expanding the selector into a column (if it is not
+// already one).
+func lowerSelectorToAir(ctx trace.Context, selector util.Option[Expr], mirSchema *Schema,
+	airSchema *air.Schema) util.Option[uint] {
+	if selector.HasValue() {
+		expr := lowerExprTo(ctx, selector.Unwrap(), mirSchema, airSchema)
+		return util.Some(air_gadgets.Expand(ctx, expr, airSchema))
+	}
+	//
+	return util.None[uint]()
 }
 
 // Lower a permutation to the AIR level.  This has quite a few
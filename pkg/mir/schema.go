@@ -40,6 +40,19 @@ type Interleaving = *assignment.Interleaving
 // Computation captures the notion of an computation at the MIR level.
 type Computation = *assignment.Computation
 
+// FixedColumn captures the notion of a column whose values are fixed by the
+// schema at the MIR level.
+type FixedColumn = *assignment.FixedColumn
+
+// ComputedColumn captures the notion of a column whose values are determined by
+// an arbitrary expression.  Since such columns are defined at the HIR level,
+// their determining expressions are opaque at this level.
+type ComputedColumn = interface {
+	schema.Assignment
+	// Expression returns the determining expression of this computed column.
+	Expression() schema.Evaluable
+}
+
 // Schema for MIR traces
 type Schema struct {
 	// The modules of the schema
@@ -80,12 +93,12 @@ func (p *Schema) AddModule(name string) uint {
 }
 
 // AddDataColumn appends a new data column.
-func (p *Schema) AddDataColumn(context trace.Context, name string, base schema.Type) {
+func (p *Schema) AddDataColumn(context trace.Context, name string, base schema.Type, display trace.Display) {
 	if context.Module() >= uint(len(p.modules)) {
 		panic(fmt.Sprintf("invalid module index (%d)", context.Module()))
 	}
 	// Create column
-	col := assignment.NewDataColumn(context, name, base)
+	col := assignment.NewDataColumn(context, name, base, display)
 	p.inputs = append(p.inputs, col)
 	// Update column cache
 	for c := col.Columns(); c.HasNext(); {
@@ -107,20 +120,21 @@ func (p *Schema) AddAssignment(c schema.Assignment) uint {
 	return index
 }
 
-// AddLookupConstraint appends a new lookup constraint.
+// AddLookupConstraint appends a new lookup constraint.  Either selector can be
+// empty, in which case all rows are considered.
 func (p *Schema) AddLookupConstraint(handle string, source trace.Context, target trace.Context,
-	sources []Expr, targets []Expr) {
+	sources []Expr, targets []Expr, sourceSelector util.Option[Expr], targetSelector util.Option[Expr]) {
 	if len(targets) != len(sources) {
 		panic("differeng number of target / source lookup columns")
 	}
 	// TODO: sanity source columns are in the same module, and likewise target
 	// columns (though they don't have to be in the same column together).
 	p.constraints = append(p.constraints,
-		constraint.NewLookupConstraint(handle, source, target, sources, targets))
+		constraint.NewLookupConstraint(handle, source, target, sources, targets, sourceSelector, targetSelector))
 }
 
 // AddVanishingConstraint appends a new vanishing constraint.
-func (p *Schema) AddVanishingConstraint(handle string, context trace.Context, domain util.Option[int], expr Expr) {
+func (p *Schema) AddVanishingConstraint(handle string, context trace.Context, domain util.Option[[]int], expr Expr) {
 	if context.Module() >= uint(len(p.modules)) {
 		panic(fmt.Sprintf("invalid module index (%d)", context.Module()))
 	}
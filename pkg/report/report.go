@@ -0,0 +1,458 @@
+package report
+
+import (
+	"cmp"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	sc "github.com/consensys/go-corset/pkg/schema"
+	"github.com/consensys/go-corset/pkg/schema/constraint"
+	tr "github.com/consensys/go-corset/pkg/trace"
+	"github.com/consensys/go-corset/pkg/util"
+)
+
+// FORMAT_JSON indicates a report should be written as a JSON document.
+const FORMAT_JSON = "json"
+
+// FORMAT_JUNIT indicates a report should be written as a JUnit XML document.
+const FORMAT_JUNIT = "junit"
+
+// TRACE_CASE is the name given to the case which records errors arising from
+// the trace itself (e.g. during expansion), rather than from any constraint.
+const TRACE_CASE = "trace"
+
+// UNNAMED_CASE is the name given to the case which records constraints without
+// a handle (e.g. range constraints arising from definrange) in a given module.
+const UNNAMED_CASE = "unnamed"
+
+// Report collects structured information about the outcome of checking a
+// trace, such that it can be subsequently written in a machine-readable format
+// (e.g. JSON or JUnit XML).
+type Report struct {
+	// Indicates whether or not the trace was accepted.
+	Accepted bool `json:"accepted"`
+	// IR levels at which the trace was checked.
+	Levels []string `json:"levels"`
+	// Failures (and errors) arising from checking the trace.
+	Failures []FailureRecord `json:"failures"`
+	// Constraints (and assertions) checked at each IR level.
+	cases map[string]*levelCases
+}
+
+// FailureRecord provides a structured description of a single failure.
+type FailureRecord struct {
+	// IR level at which this failure arose.
+	IR string `json:"ir"`
+	// Kind of failure (e.g. vanishing, lookup, range, etc).
+	Kind string `json:"kind"`
+	// Handle of the failing constraint (if applicable).
+	Handle string `json:"handle,omitempty"`
+	// Enclosing module of the failing constraint (if applicable).
+	Module string `json:"module,omitempty"`
+	// Failing row (if applicable).
+	Row *uint `json:"row,omitempty"`
+	// Human-readable message describing the failure.
+	Message string `json:"message"`
+	// Cells (and their values) required to evaluate the failing constraint.
+	Cells []CellRecord `json:"cells,omitempty"`
+	// Closest matching target row for a failing lookup (if applicable).
+	Closest *ClosestRecord `json:"closest,omitempty"`
+}
+
+// ClosestRecord identifies the target row which most closely matches the
+// source tuple of a failing lookup.
+type ClosestRecord struct {
+	// Target row.
+	Row uint `json:"row"`
+	// Number of target columns matching the source tuple on this row.
+	Matches uint `json:"matches"`
+	// Cells (and their values) of the target columns on this row.
+	Cells []CellRecord `json:"cells,omitempty"`
+}
+
+// CellRecord identifies a given cell in the trace, along with its value.
+type CellRecord struct {
+	// Qualified name of the column.
+	Column string `json:"column"`
+	// Row of the cell.
+	Row int `json:"row"`
+	// Value held in the cell (in decimal).
+	Value string `json:"value"`
+}
+
+// NewReport constructs an empty report, indicating the trace was accepted.
+func NewReport() *Report {
+	return &Report{true, nil, make([]FailureRecord, 0), make(map[string]*levelCases)}
+}
+
+// AddLevel records that a trace is being checked at the given IR level against
+// a given schema.  Every constraint (and assertion) of the schema is recorded,
+// such that the set of cases reported for a given schema is always the same
+// (regardless of whether or not the trace is accepted).  Initially, each is
+// marked as unchecked.
+func (p *Report) AddLevel(ir string, schema sc.Schema) {
+	level := &levelCases{schema, nil, make(map[util.Pair[string, string]]uint)}
+	// Errors arising from the trace itself are always reported
+	level.add("", TRACE_CASE).Checked = true
+	//
+	for iter := schema.Constraints().Append(schema.Assertions()); iter.HasNext(); {
+		level.add(level.nameOf(iter.Next()))
+	}
+	//
+	p.Levels = append(p.Levels, ir)
+	p.cases[ir] = level
+}
+
+// AddChecked records that the given constraints (or assertions) have been
+// checked at a given IR level.
+func (p *Report) AddChecked(ir string, constraints util.Iterator[sc.Constraint]) {
+	level := p.cases[ir]
+	//
+	for constraints.HasNext() {
+		level.add(level.nameOf(constraints.Next())).Checked = true
+	}
+}
+
+// AddErrors records zero or more errors arising at a given IR level.  Errors
+// which are treated only as warnings are recorded, but do not cause the trace
+// to be rejected.
+func (p *Report) AddErrors(error bool, ir string, errs []error) {
+	kind := "error"
+	//
+	if !error {
+		kind = "warning"
+	}
+	//
+	for _, err := range errs {
+		p.Accepted = p.Accepted && !error
+		p.Failures = append(p.Failures, FailureRecord{IR: ir, Kind: kind, Message: err.Error()})
+	}
+}
+
+// AddFailures records zero or more constraint failures arising at a given IR
+// level.  Since constraints are checked in parallel, the order in which
+// failures arise is not fixed.  Therefore, failures are sorted by constraint
+// and row to ensure the report is stable between runs.
+func (p *Report) AddFailures(ir string, failures []sc.Failure, trace tr.Trace, schema sc.Schema) {
+	records := make([]FailureRecord, len(failures))
+	//
+	for i, f := range failures {
+		p.Accepted = false
+		records[i] = newFailureRecord(ir, f, trace, schema)
+	}
+	//
+	slices.SortStableFunc(records, func(l FailureRecord, r FailureRecord) int {
+		if c := cmp.Compare(l.Module, r.Module); c != 0 {
+			return c
+		} else if c := cmp.Compare(l.Handle, r.Handle); c != 0 {
+			return c
+		} else if l.Row == nil || r.Row == nil {
+			return 0
+		}
+		//
+		return cmp.Compare(*l.Row, *r.Row)
+	})
+	//
+	p.Failures = append(p.Failures, records...)
+}
+
+// Write this report in the given format.
+func (p *Report) Write(out io.Writer, format string) error {
+	var (
+		bytes []byte
+		err   error
+	)
+	//
+	switch format {
+	case FORMAT_JSON:
+		bytes, err = json.MarshalIndent(p, "", "  ")
+	case FORMAT_JUNIT:
+		bytes, err = xml.MarshalIndent(p.toJUnit(), "", "  ")
+		bytes = append([]byte(xml.Header), bytes...)
+	default:
+		err = fmt.Errorf("unknown report format \"%s\"", format)
+	}
+	// Check for errors
+	if err != nil {
+		return err
+	}
+	//
+	_, err = fmt.Fprintln(out, string(bytes))
+	//
+	return err
+}
+
+// Construct a structured record for a given failure.
+func newFailureRecord(ir string, failure sc.Failure, trace tr.Trace, schema sc.Schema) FailureRecord {
+	var (
+		record = FailureRecord{IR: ir, Message: failure.Message()}
+		ctx    = tr.VoidContext[uint]()
+		cells  *util.AnySortedSet[tr.CellRef]
+	)
+	// Extract information specific to each kind of failure
+	switch f := failure.(type) {
+	case *constraint.VanishingFailure:
+		record.Kind = "vanishing"
+		ctx = f.Constraint.Context(schema)
+		cells = f.RequiredCells(trace)
+	case *constraint.LookupFailure:
+		record.Kind = "lookup"
+		ctx = f.SourceContext
+		cells = f.RequiredCells(trace)
+		//
+		if row, matches, ok := f.Closest(); ok {
+			record.Closest = &ClosestRecord{row, matches, cellRecords(f.TargetCells(row, trace), trace, schema)}
+		}
+	case *constraint.RangeFailure:
+		record.Kind = "range"
+		ctx = f.Expr.Context(schema)
+		cells = f.RequiredCells(trace)
+	case *constraint.PermutationFailure:
+		record.Kind = "permutation"
+	case *sc.AssertionFailure:
+		record.Kind = "assertion"
+		ctx = f.Constraint.Context(schema)
+		cells = f.RequiredCells(trace)
+	default:
+		record.Kind = "unknown"
+	}
+	// Extract handle and row (where applicable)
+	if f, ok := failure.(sc.RowFailure); ok {
+		handle, row := f.Location()
+		record.Handle = handle
+		record.Row = &row
+	}
+	// Determine enclosing module (where applicable)
+	record.Module = moduleOf(schema, ctx)
+	// Extract cell values (where applicable)
+	if cells != nil {
+		record.Cells = cellRecords(cells, trace, schema)
+	}
+	//
+	return record
+}
+
+// Construct structured records for the given cells, including their values.
+func cellRecords(cells *util.AnySortedSet[tr.CellRef], trace tr.Trace, schema sc.Schema) []CellRecord {
+	var records []CellRecord
+	//
+	for _, c := range cells.ToArray() {
+		val := trace.Column(c.Column).Get(c.Row)
+		name := sc.QualifiedName(schema, c.Column)
+		records = append(records, CellRecord{name, c.Row, val.String()})
+	}
+	//
+	return records
+}
+
+// Determine the name of the module identified by a given context, or the empty
+// string if the context does not identify a single module.
+func moduleOf(schema sc.Schema, ctx tr.Context) string {
+	if ctx.IsVoid() || ctx.IsConflicted() {
+		return ""
+	}
+	//
+	return schema.Modules().Nth(ctx.Module()).Name
+}
+
+// ============================================================================
+// Cases
+// ============================================================================
+
+// levelCases records the set of cases (i.e. constraints or assertions) checked
+// at a given IR level.  Cases are identified by their enclosing module and
+// name, and are held in the order they are declared in the schema.
+type levelCases struct {
+	schema sc.Schema
+	cases  []*caseRecord
+	index  map[util.Pair[string, string]]uint
+}
+
+// caseRecord identifies a single case, and whether or not it was checked.
+type caseRecord struct {
+	Module  string
+	Name    string
+	Checked bool
+}
+
+// Add a case with the given module and name (if it doesn't already exist),
+// returning its record.
+func (p *levelCases) add(module string, name string) *caseRecord {
+	key := util.NewPair(module, name)
+	//
+	if i, ok := p.index[key]; ok {
+		return p.cases[i]
+	}
+	//
+	record := &caseRecord{module, name, false}
+	p.index[key] = uint(len(p.cases))
+	p.cases = append(p.cases, record)
+	//
+	return record
+}
+
+// Determine the enclosing module and name of the case for a given constraint.
+// Constraints which cannot have a handle (e.g. permutations) are named after
+// their kind.
+func (p *levelCases) nameOf(c sc.Constraint) (string, string) {
+	switch c := c.(type) {
+	case sc.NamedConstraint:
+		handle, ctx := c.Name()
+		return moduleOf(p.schema, ctx), caseName(handle)
+	case *constraint.PermutationConstraint:
+		return "", "permutation"
+	default:
+		return "", "unknown"
+	}
+}
+
+// Determine the enclosing module and name of the case for a given failure
+// record.
+func (p *FailureRecord) caseOf() (string, string) {
+	switch {
+	case p.Kind == "error" || p.Kind == "warning":
+		return "", TRACE_CASE
+	case p.Row == nil:
+		return p.Module, p.Kind
+	default:
+		return p.Module, caseName(p.Handle)
+	}
+}
+
+// Determine the name of the case for a constraint with the given handle.
+func caseName(handle string) string {
+	if handle == "" {
+		return UNNAMED_CASE
+	}
+	//
+	return handle
+}
+
+// ============================================================================
+// JUnit XML
+// ============================================================================
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// Convert this report into a set of JUnit test suites.  Each IR level is
+// treated as a test suite, where each constraint (or assertion) is a test case
+// which fails when one or more rows fail.  Thus, the number of test cases
+// depends only upon the schema, and not on the trace being checked.  An
+// additional test case records errors arising from the trace itself.
+// Constraints which were not checked (e.g. because an earlier error arose) are
+// marked as skipped.
+func (p *Report) toJUnit() junitTestSuites {
+	suites := junitTestSuites{Name: "go-corset check"}
+	//
+	for _, ir := range p.Levels {
+		var (
+			level    = p.cases[ir]
+			failures = make(map[*caseRecord][]FailureRecord)
+			suite    = junitTestSuite{Name: ir}
+		)
+		// Allocate failures to their cases (where cases not arising from the
+		// schema are added on the fly).
+		for _, f := range p.Failures {
+			if f.IR == ir && f.Kind != "warning" {
+				record := level.add(f.caseOf())
+				record.Checked = true
+				failures[record] = append(failures[record], f)
+			}
+		}
+		// Construct test cases
+		for _, c := range level.cases {
+			testcase := junitTestCase{Name: c.Name, ClassName: ir}
+			//
+			if c.Module != "" {
+				testcase.ClassName = fmt.Sprintf("%s.%s", ir, c.Module)
+			}
+			//
+			if len(failures[c]) > 0 {
+				testcase.Failure = newJUnitFailure(failures[c])
+				suite.Failures++
+			} else if !c.Checked {
+				testcase.Skipped = &junitSkipped{"not checked"}
+				suite.Skipped++
+			}
+			//
+			suite.Cases = append(suite.Cases, testcase)
+		}
+		//
+		suite.Tests = len(suite.Cases)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+	//
+	return suites
+}
+
+// Construct a JUnit failure from one or more failures arising for the same
+// constraint.  The message is taken from the first failure, whilst the body
+// lists every failure along with the cells involved.
+func newJUnitFailure(failures []FailureRecord) *junitFailure {
+	var (
+		body    strings.Builder
+		message = failures[0].Message
+	)
+	//
+	if len(failures) > 1 {
+		message = fmt.Sprintf("%s (and %d more)", message, len(failures)-1)
+	}
+	//
+	for _, f := range failures {
+		body.WriteString(f.Message)
+		body.WriteString("\n")
+		//
+		for _, c := range f.Cells {
+			body.WriteString(fmt.Sprintf("  %s[%d] = %s\n", c.Column, c.Row, c.Value))
+		}
+		//
+		if f.Closest != nil {
+			body.WriteString(fmt.Sprintf("  closest row %d (%d matching)\n", f.Closest.Row, f.Closest.Matches))
+			//
+			for _, c := range f.Closest.Cells {
+				body.WriteString(fmt.Sprintf("    %s[%d] = %s\n", c.Column, c.Row, c.Value))
+			}
+		}
+	}
+	//
+	return &junitFailure{message, failures[0].Kind, body.String()}
+}
@@ -29,6 +29,17 @@ func (p *AssertionFailure) RequiredCells(trace tr.Trace) *util.AnySortedSet[tr.C
 	return p.Constraint.RequiredCells(int(p.Row), trace)
 }
 
+// Location returns the handle of the failing assertion, along with the row on
+// which it failed.
+func (p *AssertionFailure) Location() (string, uint) {
+	return p.Handle, p.Row
+}
+
+// Context returns the evaluation context of the failing assertion.
+func (p *AssertionFailure) Context(schema Schema) tr.Context {
+	return p.Constraint.Context(schema)
+}
+
 func (p *AssertionFailure) String() string {
 	return p.Message()
 }
@@ -60,23 +71,30 @@ func NewPropertyAssertion[T Testable](handle string, ctx tr.Context, property T)
 	return &PropertyAssertion[T]{handle, ctx, property}
 }
 
+// Name returns the handle of this assertion, along with its evaluation
+// context.
+func (p *PropertyAssertion[T]) Name() (string, tr.Context) {
+	return p.Handle, p.Context
+}
+
 // Accepts checks whether a vanishing constraint evaluates to zero on every row
-// of a table. If so, return nil otherwise return an error.
+// of a table. If so, return nil otherwise return at most limit failures.
 //
 //nolint:revive
-func (p *PropertyAssertion[T]) Accepts(tr tr.Trace) Failure {
+func (p *PropertyAssertion[T]) Accepts(tr tr.Trace, limit uint) []Failure {
+	var failures []Failure
 	// Determine height of enclosing module
 	height := tr.Height(p.Context)
 	// Iterate every row in the module
-	for k := uint(0); k < height; k++ {
+	for k := uint(0); k < height && uint(len(failures)) < limit; k++ {
 		// Check whether property holds (or was undefined)
 		if !p.Property.TestAt(int(k), tr) {
 			// Evaluation failure
-			return &AssertionFailure{p.Handle, p.Property, k}
+			failures = append(failures, &AssertionFailure{p.Handle, p.Property, k})
 		}
 	}
-	// All good
-	return nil
+	// Done
+	return failures
 }
 
 // Lisp converts this constraint into an S-Expression.
@@ -14,24 +14,33 @@ import (
 // give rise to "trace expansion".  That is where the initial trace provided by
 // the user is expanded by determining the value of all computed columns.
 type ComputedColumn[E sc.Evaluable] struct {
-	target sc.Column
+	// The column being computed.
+	Target sc.Column
 	// The computation which accepts a given trace and computes
 	// the value of this column at a given row.
-	expr E
+	Expr E
 }
 
-// NewComputedColumn constructs a new computed column with a given name and
-// determining expression.  More specifically, that expression is used to
-// compute the values for this column during trace expansion.
-func NewComputedColumn[E sc.Evaluable](context trace.Context, name string, expr E) *ComputedColumn[E] {
-	column := sc.NewColumn(context, name, &sc.FieldType{})
-	// FIXME: Determine computed columns type?
+// NewComputedColumn constructs a new computed column with a given name, type,
+// display mode and determining expression.  More specifically, that expression
+// is used to compute the values for this column during trace expansion.
+func NewComputedColumn[E sc.Evaluable](context trace.Context, name string, datatype sc.Type,
+	display trace.Display, expr E) *ComputedColumn[E] {
+	column := sc.NewColumn(context, name, datatype)
+	column.Display = display
+	//
 	return &ComputedColumn[E]{column, expr}
 }
 
 // Name returns the name of this computed column.
 func (p *ComputedColumn[E]) Name() string {
-	return p.target.Name
+	return p.Target.Name
+}
+
+// Expression returns the expression which determines the values of this
+// computed column.
+func (p *ComputedColumn[E]) Expression() sc.Evaluable {
+	return p.Expr
 }
 
 // ============================================================================
@@ -40,13 +49,12 @@ func (p *ComputedColumn[E]) Name() string {
 
 // Context returns the evaluation context for this computed column.
 func (p *ComputedColumn[E]) Context() trace.Context {
-	return p.target.Context
+	return p.Target.Context
 }
 
 // Columns returns the columns declared by this computed column.
 func (p *ComputedColumn[E]) Columns() util.Iterator[sc.Column] {
-	// TODO: figure out appropriate type for computed column
-	return util.NewUnitIterator[sc.Column](p.target)
+	return util.NewUnitIterator[sc.Column](p.Target)
 }
 
 // IsComputed Determines whether or not this declaration is computed (which it
@@ -67,7 +75,7 @@ func (p *ComputedColumn[E]) RequiredSpillage() uint {
 	// (i.e. start) of a trace.  This is because padding is always inserted at
 	// the front, never the back.  As such, it is the maximum positive shift
 	// which determines how much spillage is required for this comptuation.
-	return p.expr.Bounds().End
+	return p.Expr.Bounds().End
 }
 
 // ComputeColumns computes the values of columns defined by this assignment.
@@ -75,20 +83,20 @@ func (p *ComputedColumn[E]) RequiredSpillage() uint {
 // evaluating a given expression on each row.
 func (p *ComputedColumn[E]) ComputeColumns(tr trace.Trace) ([]trace.ArrayColumn, error) {
 	// Determine multiplied height
-	height := tr.Height(p.target.Context)
+	height := tr.Height(p.Target.Context)
 	// Make space for computed data
 	data := util.NewFrArray(height, 256)
 	// Expand the trace
 	for i := uint(0); i < data.Len(); i++ {
-		val := p.expr.EvalAt(int(i), tr)
+		val := p.Expr.EvalAt(int(i), tr)
 		data.Set(i, val)
 	}
 	// Determine padding value.  A negative row index is used here to ensure
 	// that all columns return their padding value which is then used to compute
 	// the padding value for *this* column.
-	padding := p.expr.EvalAt(-1, tr)
+	padding := p.Expr.EvalAt(-1, tr)
 	// Construct column
-	col := trace.NewArrayColumn(p.target.Context, p.Name(), data, padding)
+	col := trace.NewArrayColumn(p.Target.Context, p.Name(), data, padding)
 	// Done
 	return []trace.ArrayColumn{col}, nil
 }
@@ -96,7 +104,7 @@ func (p *ComputedColumn[E]) ComputeColumns(tr trace.Trace) ([]trace.ArrayColumn,
 // Dependencies returns the set of columns that this assignment depends upon.
 // That can include both input columns, as well as other computed columns.
 func (p *ComputedColumn[E]) Dependencies() []uint {
-	return *p.expr.RequiredColumns()
+	return *p.Expr.RequiredColumns()
 }
 
 // ============================================================================
@@ -108,7 +116,7 @@ func (p *ComputedColumn[E]) Dependencies() []uint {
 func (p *ComputedColumn[E]) Lisp(schema sc.Schema) sexp.SExp {
 	col := sexp.NewSymbol("computed")
 	name := sexp.NewSymbol(p.Columns().Next().QualifiedName(schema))
-	expr := p.expr.Lisp(schema)
+	expr := p.Expr.Lisp(schema)
 
 	return sexp.NewList([]sexp.SExp{col, name, expr})
 }
@@ -20,11 +20,14 @@ type DataColumn struct {
 	// true for the input columns for any valid trace and, furthermore, every
 	// computed column should have values of this type.
 	DataType sc.Type
+	// Determines how values in this column should be rendered (e.g. when
+	// printing a trace, or reporting a failure).
+	ColumnDisplay trace.Display
 }
 
 // NewDataColumn constructs a new data column with a given name.
-func NewDataColumn(context trace.Context, name string, base sc.Type) *DataColumn {
-	return &DataColumn{context, name, base}
+func NewDataColumn(context trace.Context, name string, base sc.Type, display trace.Display) *DataColumn {
+	return &DataColumn{context, name, base, display}
 }
 
 // Context returns the evaluation context for this column.
@@ -47,6 +50,11 @@ func (p *DataColumn) Type() sc.Type {
 	return p.DataType
 }
 
+// Display returns how values in this column should be rendered.
+func (p *DataColumn) Display() trace.Display {
+	return p.ColumnDisplay
+}
+
 // ============================================================================
 // Declaration Interface
 // ============================================================================
@@ -55,6 +63,8 @@ func (p *DataColumn) Type() sc.Type {
 func (p *DataColumn) Columns() util.Iterator[sc.Column] {
 	// Datacolumns always have a multiplier of 1.
 	column := sc.NewColumn(p.TraceContext, p.ColumnName, p.DataType)
+	column.Display = p.ColumnDisplay
+	//
 	return util.NewUnitIterator[sc.Column](column)
 }
 
@@ -77,6 +87,11 @@ func (p *DataColumn) Lisp(schema sc.Schema) sexp.SExp {
 	datatype := sexp.NewSymbol(p.DataType.String())
 	multiplier := sexp.NewSymbol(fmt.Sprintf("x%d", p.TraceContext.LengthMultiplier()))
 	def := sexp.NewList([]sexp.SExp{name, datatype, multiplier})
+	// Include display (if not the default)
+	if p.ColumnDisplay.Kind != trace.DISPLAY_HEX {
+		def.Append(sexp.NewSymbol(":display"))
+		def.Append(sexp.NewSymbol(fmt.Sprintf(":%s", p.ColumnDisplay.String())))
+	}
 	//
 	return sexp.NewList([]sexp.SExp{col, def})
 }
@@ -0,0 +1,144 @@
+package assignment
+
+import (
+	"encoding/gob"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	sc "github.com/consensys/go-corset/pkg/schema"
+	"github.com/consensys/go-corset/pkg/trace"
+	"github.com/consensys/go-corset/pkg/util"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+// FixedColumn describes a column whose values are fixed by the schema itself,
+// rather than being determined from a trace.  Fixed columns are used, for
+// example, to hold constant tables which are the target of lookup constraints.
+// A fixed column always resides in its own module, since the height of that
+// module is determined by the number of values in the column.  Furthermore,
+// the first value is used for padding.
+type FixedColumn struct {
+	// The column being defined.
+	Target sc.Column
+	// The values of this column.
+	Values []fr.Element
+}
+
+// NewFixedColumn constructs a new fixed column with a given name, type,
+// display mode and (non-empty) set of values.
+func NewFixedColumn(context trace.Context, name string, datatype sc.Type, display trace.Display,
+	values []fr.Element) *FixedColumn {
+	column := sc.NewColumn(context, name, datatype)
+	column.Display = display
+	// Sanity check
+	if len(values) == 0 {
+		panic("fixed column requires at least one value")
+	}
+	//
+	return &FixedColumn{column, values}
+}
+
+// Name returns the name of this fixed column.
+func (p *FixedColumn) Name() string {
+	return p.Target.Name
+}
+
+// Height returns the number of rows in the module holding this fixed column
+// (excluding any padding), which is determined by the number of values.
+func (p *FixedColumn) Height() uint {
+	return uint(len(p.Values))
+}
+
+// ============================================================================
+// Declaration Interface
+// ============================================================================
+
+// Context returns the evaluation context for this fixed column.
+func (p *FixedColumn) Context() trace.Context {
+	return p.Target.Context
+}
+
+// Columns returns the columns declared by this fixed column.
+func (p *FixedColumn) Columns() util.Iterator[sc.Column] {
+	return util.NewUnitIterator[sc.Column](p.Target)
+}
+
+// IsComputed Determines whether or not this declaration is computed (which it
+// is, since its values are not given in the trace).
+func (p *FixedColumn) IsComputed() bool {
+	return true
+}
+
+// ============================================================================
+// Assignment Interface
+// ============================================================================
+
+// RequiredSpillage returns the minimum amount of spillage required to ensure
+// valid traces are accepted in the presence of arbitrary padding.
+func (p *FixedColumn) RequiredSpillage() uint {
+	return uint(0)
+}
+
+// ComputeColumns computes the values of columns defined by this assignment.
+// Since the values of a fixed column are known in advance, this simply copies
+// them into a new column.  Observe that any spillage already applied to the
+// enclosing module is accounted for by padding at the front.
+func (p *FixedColumn) ComputeColumns(tr trace.Trace) ([]trace.ArrayColumn, error) {
+	var (
+		height  = tr.Height(p.Target.Context)
+		nvalues = uint(len(p.Values))
+	)
+	// Sanity check
+	if height < nvalues {
+		return nil, fmt.Errorf("fixed column %s exceeds module height (%d > %d)", p.Name(), nvalues, height)
+	}
+	//
+	data := util.NewFrArray(height, p.Target.DataType.BitWidth())
+	spillage := height - nvalues
+	//
+	for i := uint(0); i < spillage; i++ {
+		data.Set(i, p.Values[0])
+	}
+	//
+	for i, v := range p.Values {
+		data.Set(spillage+uint(i), v)
+	}
+	// Construct column
+	col := trace.NewArrayColumn(p.Target.Context, p.Name(), data, p.Values[0])
+	// Done
+	return []trace.ArrayColumn{col}, nil
+}
+
+// Dependencies returns the set of columns that this assignment depends upon,
+// of which there are none.
+func (p *FixedColumn) Dependencies() []uint {
+	return nil
+}
+
+// ============================================================================
+// Lispify Interface
+// ============================================================================
+
+// Lisp converts this schema element into a simple S-Expression, for example
+// so it can be printed.
+func (p *FixedColumn) Lisp(schema sc.Schema) sexp.SExp {
+	values := make([]sexp.SExp, len(p.Values))
+	//
+	for i, v := range p.Values {
+		values[i] = sexp.NewSymbol(v.String())
+	}
+	//
+	return sexp.NewList([]sexp.SExp{
+		sexp.NewSymbol("fixed"),
+		sexp.NewSymbol(p.Columns().Next().QualifiedName(schema)),
+		sexp.NewList(values),
+	})
+}
+
+// ============================================================================
+// Encoding / Decoding
+// ============================================================================
+
+func init() {
+	gob.Register(sc.Declaration(&FixedColumn{}))
+}
@@ -82,6 +82,13 @@ func (p *Interleaving) ComputeColumns(trace tr.Trace) ([]tr.ArrayColumn, error)
 		// Update byte width
 		bit_width = max(bit_width, ith.DataType.BitWidth())
 	}
+	// Negative values of signed columns require the full width of a field
+	// element, as determined by the source data.
+	if p.Target.DataType.AsInt() != nil {
+		for _, src := range p.Sources {
+			bit_width = max(bit_width, trace.Column(src).Data().BitWidth())
+		}
+	}
 	// Determine interleaving width
 	width := uint(len(p.Sources))
 	// Following division should always produce whole value because the length
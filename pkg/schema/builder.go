@@ -73,6 +73,8 @@ func (tb TraceBuilder) Build(columns []trace.RawColumn) (trace.Trace, []error) {
 		// Critical failure
 		return nil, errs
 	} else if tb.expand {
+		// Determine height of modules fixed by the schema
+		initialiseFixedModules(tr, tb.schema)
 		// Apply spillage
 		applySpillage(tr, tb.schema)
 		// Expand trace
@@ -101,6 +103,11 @@ type columnKey struct {
 }
 
 func (tb TraceBuilder) initialiseTrace(cols []trace.RawColumn) (*trace.ArrayTrace, []error) {
+	// Decode column data (where this is read lazily).  Errors here indicate the
+	// trace file could not be read, or is corrupted, and are unrecoverable.
+	if err := trace.DecodeColumns(cols); err != nil {
+		return nil, []error{err}
+	}
 	// Initialise modules
 	modules, modmap := tb.initialiseTraceModules()
 	// Initialise columns
@@ -226,6 +233,22 @@ func validateTraceColumns(schema Schema, tr *trace.ArrayTrace) (error, []error)
 	return nil, warnings
 }
 
+// initialiseFixedModules sets the height of any module holding a fixed
+// assignment, where this is not already determined by the trace.  Such modules
+// typically have no input columns and, hence, their height must be set before
+// spillage and padding are applied.
+func initialiseFixedModules(tr *trace.ArrayTrace, schema Schema) {
+	for iter := schema.Assignments(); iter.HasNext(); {
+		if ith, ok := iter.Next().(FixedAssignment); ok {
+			mid := ith.Context().Module()
+			//
+			if tr.Modules().Nth(mid).Height() == math.MaxUint {
+				tr.SetHeight(mid, ith.Height())
+			}
+		}
+	}
+}
+
 // applySpillage pads each module with its given level of spillage
 func applySpillage(tr *trace.ArrayTrace, schema Schema) {
 	n := tr.Modules().Count()
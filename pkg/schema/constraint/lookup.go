@@ -3,7 +3,9 @@ package constraint
 import (
 	"encoding/binary"
 	"fmt"
+	"strings"
 
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/go-corset/pkg/schema"
 	sc "github.com/consensys/go-corset/pkg/schema"
 	"github.com/consensys/go-corset/pkg/trace"
@@ -13,16 +15,144 @@ import (
 
 // LookupFailure provides structural information about a failing lookup constraint.
 type LookupFailure struct {
-	Msg string
+	// Handle of the failing constraint
+	Handle string
+	// Context in which the source columns are evaluated.
+	SourceContext trace.Context
+	// Context in which the target columns are evaluated.
+	TargetContext trace.Context
+	// Source expressions of the failing lookup
+	Sources []sc.Evaluable
+	// Target expressions of the failing lookup
+	Targets []sc.Evaluable
+	// Target selector of the failing lookup (if applicable).
+	TargetSelector util.Option[sc.Evaluable]
+	// Row of the source columns which could not be found in the target columns.
+	Row uint
+	// Tuple of values obtained from evaluating the source expressions on the
+	// failing row.
+	Tuple []fr.Element
+	// Index of the target rows, shared between all failures of the same
+	// constraint.
+	index *lookupIndex
 }
 
 // Message provides a suitable error message
 func (p *LookupFailure) Message() string {
-	return p.Msg
+	var tuple strings.Builder
+	//
+	for i, ith := range p.Tuple {
+		if i != 0 {
+			tuple.WriteString(", ")
+		}
+		//
+		tuple.WriteString(fmt.Sprintf("0x%s", ith.Text(16)))
+	}
+	//
+	return fmt.Sprintf("lookup \"%s\" failed (row %d) for source tuple (%s)", p.Handle, p.Row, tuple.String())
+}
+
+// Location returns the handle of the failing constraint, along with the
+// (source) row on which it failed.
+func (p *LookupFailure) Location() (string, uint) {
+	return p.Handle, p.Row
+}
+
+// Context returns the evaluation context of the source columns of the failing
+// lookup.
+func (p *LookupFailure) Context(schema sc.Schema) trace.Context {
+	return p.SourceContext
+}
+
+// RequiredCells identifies the (source) cells required to evaluate the failing
+// lookup at the failing row.
+func (p *LookupFailure) RequiredCells(tr trace.Trace) *util.AnySortedSet[trace.CellRef] {
+	return requiredCellsAt(int(p.Row), p.Sources, tr)
+}
+
+// Closest identifies the target row which most closely matches the failing
+// source tuple, along with the number of matching columns.  Specifically, this
+// is the first target row with the most columns matching the source tuple.  If
+// there are no (selected) target rows, then false is returned.
+func (p *LookupFailure) Closest() (uint, uint, bool) {
+	if p.index == nil || p.index.rows == 0 {
+		return 0, 0, false
+	}
+	//
+	var (
+		counts  = make(map[uint]uint)
+		closest = p.index.first
+		matches = uint(0)
+	)
+	// Count matching columns for those rows matching at least one column
+	for j, val := range p.Tuple {
+		for _, row := range p.index.columns[j][val] {
+			counts[row]++
+		}
+	}
+	// Check whether closer than before
+	for row, count := range counts {
+		if count > matches || (count == matches && row < closest) {
+			closest, matches = row, count
+		}
+	}
+	//
+	return closest, matches, true
+}
+
+// TargetCells identifies the cells required to evaluate the target expressions
+// at the given (target) row.  This is useful, for example, for displaying the
+// closest matching target row.
+func (p *LookupFailure) TargetCells(row uint, tr trace.Trace) *util.AnySortedSet[trace.CellRef] {
+	return requiredCellsAt(int(row), p.Targets, tr)
 }
 
 func (p *LookupFailure) String() string {
-	return p.Msg
+	return p.Message()
+}
+
+// lookupIndex indexes the selected rows of the target expressions for a given
+// lookup by the value of each expression.  This allows the closest target row
+// for a failing source tuple to be determined without scanning every target
+// row.
+type lookupIndex struct {
+	// Rows (in ascending order) on which each target expression evaluates to a
+	// given value.
+	columns []map[fr.Element][]uint
+	// Number of selected target rows.
+	rows uint
+	// First selected target row.
+	first uint
+}
+
+// Construct the index for the target expressions of a given lookup.
+func newLookupIndex[E schema.Evaluable](p *LookupConstraint[E], tr trace.Trace) *lookupIndex {
+	var (
+		height = tr.Height(p.TargetContext)
+		index  = &lookupIndex{make([]map[fr.Element][]uint, len(p.Targets)), 0, 0}
+	)
+	//
+	for j := range p.Targets {
+		index.columns[j] = make(map[fr.Element][]uint)
+	}
+	//
+	for i := uint(0); i < height; i++ {
+		// Ignore unselected rows
+		if !isSelected(int(i), p.TargetSelector, tr) {
+			continue
+		} else if index.rows == 0 {
+			index.first = i
+		}
+		//
+		for j, target := range p.Targets {
+			val := target.EvalAt(int(i), tr)
+			index.columns[j][val] = append(index.columns[j][val], i)
+		}
+		//
+		index.rows++
+	}
+	//
+	return index
 }
 
 // LookupConstraint (sometimes also called an inclusion constraint) constrains
@@ -33,6 +163,11 @@ func (p *LookupFailure) String() string {
 // same module, and likewise for target modules.  However, the source columns
 // can be in a different module from the target columns.
 //
+// Lookup constraints may also be guarded by selectors.  When a source selector
+// is given, only those source rows where it is non-zero must be matched.
+// Likewise, when a target selector is given, only those target rows where it
+// is non-zero are considered for matching.
+//
 // Lookup constraints are typically used to "connect" modules together.  We can
 // think of them (in some ways) as being a little like function calls.  In this
 // analogy, the source module is making a "function call" into the target
@@ -54,43 +189,119 @@ type LookupConstraint[E schema.Evaluable] struct {
 	// Targets returns the target expressions which are used to lookup into the
 	// target expressions.
 	Targets []E
+	// SourceSelector (if applicable) determines which source rows must be
+	// contained within the targets.
+	SourceSelector util.Option[E]
+	// TargetSelector (if applicable) determines which target rows are
+	// considered part of the target set.
+	TargetSelector util.Option[E]
 }
 
 // NewLookupConstraint creates a new lookup constraint with a given handle.
+// Either selector can be empty, in which case all rows are considered.
 func NewLookupConstraint[E schema.Evaluable](handle string, source trace.Context,
-	target trace.Context, sources []E, targets []E) *LookupConstraint[E] {
+	target trace.Context, sources []E, targets []E, sourceSelector util.Option[E],
+	targetSelector util.Option[E]) *LookupConstraint[E] {
 	if len(targets) != len(sources) {
 		panic("differeng number of target / source lookup columns")
 	}
 
-	return &LookupConstraint[E]{handle, source, target, sources, targets}
+	return &LookupConstraint[E]{handle, source, target, sources, targets, sourceSelector, targetSelector}
+}
+
+// Name returns the handle of this constraint, along with the context of its
+// source columns.
+func (p *LookupConstraint[E]) Name() (string, trace.Context) {
+	return p.Handle, p.SourceContext
 }
 
 // Accepts checks whether a lookup constraint into the target columns holds for
-// all rows of the source columns.
+// all rows of the source columns.  If not, return at most limit failures.
 //
 //nolint:revive
-func (p *LookupConstraint[E]) Accepts(tr trace.Trace) schema.Failure {
+func (p *LookupConstraint[E]) Accepts(tr trace.Trace, limit uint) []schema.Failure {
+	var (
+		failures []schema.Failure
+		// Index of target rows, constructed only when needed.
+		index *lookupIndex
+	)
 	// Determine height of enclosing module for source columns
 	src_height := tr.Height(p.SourceContext)
 	tgt_height := tr.Height(p.TargetContext)
 	//
 	rows := util.NewHashSet[util.BytesKey](tgt_height)
-	// Add all target columns to the set
+	// Add all (selected) target columns to the set
 	for i := 0; i < int(tgt_height); i++ {
-		ith_bytes := evalExprsAt(i, p.Targets, tr)
-		rows.Insert(util.NewBytesKey(ith_bytes))
+		if isSelected(i, p.TargetSelector, tr) {
+			ith_bytes := evalExprsAt(i, p.Targets, tr)
+			rows.Insert(util.NewBytesKey(ith_bytes))
+		}
 	}
-	// Check all source columns are contained
-	for i := 0; i < int(src_height); i++ {
+	// Check all (selected) source columns are contained
+	for i := 0; i < int(src_height) && uint(len(failures)) < limit; i++ {
+		if !isSelected(i, p.SourceSelector, tr) {
+			continue
+		}
+		//
 		ith_bytes := evalExprsAt(i, p.Sources, tr)
 		// Check whether contained.
 		if !rows.Contains(util.NewBytesKey(ith_bytes)) {
-			return &LookupFailure{fmt.Sprintf("lookup \"%s\" failed (row %d)", p.Handle, i)}
+			if index == nil {
+				index = newLookupIndex(p, tr)
+			}
+			//
+			failures = append(failures, p.failureAt(uint(i), index, tr))
 		}
 	}
 	//
-	return nil
+	return failures
+}
+
+// Construct a suitable failure for a given (source) row on which this lookup
+// does not hold.
+func (p *LookupConstraint[E]) failureAt(row uint, index *lookupIndex, tr trace.Trace) *LookupFailure {
+	sources := make([]sc.Evaluable, len(p.Sources))
+	targets := make([]sc.Evaluable, len(p.Targets))
+	tuple := make([]fr.Element, len(p.Sources))
+	selector := util.None[sc.Evaluable]()
+	//
+	for i, source := range p.Sources {
+		sources[i] = source
+		tuple[i] = source.EvalAt(int(row), tr)
+	}
+	//
+	for i, target := range p.Targets {
+		targets[i] = target
+	}
+	//
+	if p.TargetSelector.HasValue() {
+		selector = util.Some[sc.Evaluable](p.TargetSelector.Unwrap())
+	}
+	//
+	return &LookupFailure{p.Handle, p.SourceContext, p.TargetContext, sources, targets, selector, row, tuple, index}
+}
+
+// Determine whether a given row is selected by an (optional) selector.  Rows
+// are always selected when there is no selector.
+func isSelected[E schema.Evaluable](k int, selector util.Option[E], tr trace.Trace) bool {
+	if selector.HasValue() {
+		val := selector.Unwrap().EvalAt(k, tr)
+		return !val.IsZero()
+	}
+	//
+	return true
+}
+
+// Determine the set of cells required to evaluate a given set of expressions
+// on a given row.
+func requiredCellsAt[E schema.Evaluable](k int, exprs []E, tr trace.Trace) *util.AnySortedSet[trace.CellRef] {
+	cells := util.NewAnySortedSet[trace.CellRef]()
+	//
+	for _, e := range exprs {
+		cells.InsertSorted(e.RequiredCells(k, tr))
+	}
+	//
+	return cells
 }
 
 func evalExprsAt[E schema.Evaluable](k int, sources []E, tr trace.Trace) []byte {
@@ -128,11 +339,23 @@ func (p *LookupConstraint[E]) Lisp(schema sc.Schema) sexp.SExp {
 	for i := 0; i < len(p.Targets); i++ {
 		targets.Append(p.Targets[i].Lisp(schema))
 	}
-	// Done
-	return sexp.NewList([]sexp.SExp{
+	list := sexp.NewList([]sexp.SExp{
 		sexp.NewSymbol("lookup"),
 		sexp.NewSymbol(p.Handle),
-		targets,
-		sources,
 	})
+	// Selectors (if applicable)
+	if p.SourceSelector.HasValue() {
+		list.Append(sexp.NewSymbol(":guard"))
+		list.Append(p.SourceSelector.Unwrap().Lisp(schema))
+	}
+	//
+	if p.TargetSelector.HasValue() {
+		list.Append(sexp.NewSymbol(":target-guard"))
+		list.Append(p.TargetSelector.Unwrap().Lisp(schema))
+	}
+	// Done
+	list.Append(targets)
+	list.Append(sources)
+	//
+	return list
 }
@@ -50,13 +50,14 @@ func (p *PermutationConstraint) RequiredSpillage() uint {
 }
 
 // Accepts checks whether a permutation holds between the source and
-// target columns.
-func (p *PermutationConstraint) Accepts(tr trace.Trace) sc.Failure {
+// target columns.  Observe that at most one failure is ever reported, since a
+// permutation does not fail on any specific row.
+func (p *PermutationConstraint) Accepts(tr trace.Trace, limit uint) []sc.Failure {
 	// Slice out data
 	src := sliceColumns(p.Sources, tr)
 	dst := sliceColumns(p.Targets, tr)
 	// Sanity check whether column exists
-	if util.ArePermutationOf(dst, src) {
+	if limit == 0 || util.ArePermutationOf(dst, src) {
 		// Success
 		return nil
 	}
@@ -67,7 +68,7 @@ func (p *PermutationConstraint) Accepts(tr trace.Trace) sc.Failure {
 	msg := fmt.Sprintf("Target columns (%s) not permutation of source columns (%s)",
 		dst_names, src_names)
 	// Done
-	return &PermutationFailure{msg}
+	return []sc.Failure{&PermutationFailure{msg}}
 }
 
 // Lisp converts this schema element into a simple S-Expression, for example
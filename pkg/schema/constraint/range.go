@@ -7,6 +7,7 @@ import (
 	"github.com/consensys/go-corset/pkg/schema"
 	sc "github.com/consensys/go-corset/pkg/schema"
 	"github.com/consensys/go-corset/pkg/trace"
+	"github.com/consensys/go-corset/pkg/util"
 	"github.com/consensys/go-corset/pkg/util/sexp"
 )
 
@@ -26,6 +27,22 @@ func (p *RangeFailure) Message() string {
 	return fmt.Sprintf("expression \"%s\" out-of-bounds (row %d)", p.Handle, p.Row)
 }
 
+// RequiredCells identifies the cells required to evaluate the failing constraint at the failing row.
+func (p *RangeFailure) RequiredCells(trace trace.Trace) *util.AnySortedSet[trace.CellRef] {
+	return p.Expr.RequiredCells(int(p.Row), trace)
+}
+
+// Location returns the handle of the failing constraint, along with the row on
+// which it failed.
+func (p *RangeFailure) Location() (string, uint) {
+	return p.Handle, p.Row
+}
+
+// Context returns the evaluation context of the failing constraint.
+func (p *RangeFailure) Context(schema sc.Schema) trace.Context {
+	return p.Expr.Context(schema)
+}
+
 func (p *RangeFailure) String() string {
 	return p.Message()
 }
@@ -62,25 +79,32 @@ func (p *RangeConstraint[E]) BoundedAtMost(bound uint) bool {
 	return p.Bound.Cmp(&n) <= 0
 }
 
+// Name returns the handle of this constraint, along with its evaluation
+// context.
+func (p *RangeConstraint[E]) Name() (string, trace.Context) {
+	return p.Handle, p.Context
+}
+
 // Accepts checks whether a range constraint holds on every row of a table. If so, return
-// nil otherwise return an error.
+// nil otherwise return at most limit failures.
 //
 //nolint:revive
-func (p *RangeConstraint[E]) Accepts(tr trace.Trace) schema.Failure {
+func (p *RangeConstraint[E]) Accepts(tr trace.Trace, limit uint) []schema.Failure {
+	var failures []schema.Failure
 	// Determine height of enclosing module
 	height := tr.Height(p.Context)
 	// Iterate every row
-	for k := 0; k < int(height); k++ {
+	for k := 0; k < int(height) && uint(len(failures)) < limit; k++ {
 		// Get the value on the kth row
 		kth := p.Expr.EvalAt(k, tr)
 		// Perform the range check
 		if kth.Cmp(&p.Bound) >= 0 {
 			// Evaluation failure
-			return &RangeFailure{p.Handle, p.Expr, uint(k)}
+			failures = append(failures, &RangeFailure{p.Handle, p.Expr, uint(k)})
 		}
 	}
-	// All good
-	return nil
+	// Done
+	return failures
 }
 
 // Lisp converts this schema element into a simple S-Expression, for example so
@@ -2,6 +2,7 @@ package constraint
 
 import (
 	"fmt"
+	"strings"
 
 	sc "github.com/consensys/go-corset/pkg/schema"
 	tr "github.com/consensys/go-corset/pkg/trace"
@@ -81,6 +82,17 @@ func (p *VanishingFailure) RequiredCells(trace tr.Trace) *util.AnySortedSet[tr.C
 	return p.Constraint.RequiredCells(int(p.Row), trace)
 }
 
+// Location returns the handle of the failing constraint, along with the row on
+// which it failed.
+func (p *VanishingFailure) Location() (string, uint) {
+	return p.Handle, p.Row
+}
+
+// Context returns the evaluation context of the failing constraint.
+func (p *VanishingFailure) Context(schema sc.Schema) tr.Context {
+	return p.Constraint.Context(schema)
+}
+
 func (p *VanishingFailure) String() string {
 	return p.Message()
 }
@@ -99,9 +111,10 @@ type VanishingConstraint[T sc.Testable] struct {
 	// constrained expression itself.
 	Context tr.Context
 	// Indicates (when empty) a global constraint that applies to all rows.
-	// Otherwise, indicates a local constraint which applies to the specific row
-	// given.
-	Domain util.Option[int]
+	// Otherwise, indicates a local constraint which applies only to the
+	// specific rows given.  Negative rows are taken from the end of the trace,
+	// meaning that -1 represents the last row.
+	Domain util.Option[[]int]
 	// The actual Constraint itself (e.g. an expression which
 	// should evaluate to zero, etc)
 	Constraint T
@@ -109,39 +122,71 @@ type VanishingConstraint[T sc.Testable] struct {
 
 // NewVanishingConstraint constructs a new vanishing constraint!
 func NewVanishingConstraint[T sc.Testable](handle string, context tr.Context,
-	domain util.Option[int], constraint T) *VanishingConstraint[T] {
+	domain util.Option[[]int], constraint T) *VanishingConstraint[T] {
 	return &VanishingConstraint[T]{handle, context, domain, constraint}
 }
 
+// Name returns the handle of this constraint, along with its evaluation
+// context.
+func (p *VanishingConstraint[T]) Name() (string, tr.Context) {
+	return p.Handle, p.Context
+}
+
 // Accepts checks whether a vanishing constraint evaluates to zero on every row
-// of a table.  If so, return nil otherwise return an error.
+// of a table.  If so, return nil otherwise return at most limit failures.
 //
 //nolint:revive
-func (p *VanishingConstraint[T]) Accepts(tr tr.Trace) sc.Failure {
+func (p *VanishingConstraint[T]) Accepts(tr tr.Trace, limit uint) []sc.Failure {
 	if p.Domain.IsEmpty() {
 		// Global Constraint
-		return HoldsGlobally(p.Handle, p.Context, p.Constraint, tr)
+		return HoldsGlobally(p.Handle, p.Context, p.Constraint, tr, limit)
 	}
-	// Extract domain
-	domain := p.Domain.Unwrap()
 	// Local constraint
-	var start uint
-	// Handle negative domains
-	if domain < 0 {
+	return HoldsOnDomain(p.Handle, p.Context, p.Domain.Unwrap(), p.Constraint, tr, limit)
+}
+
+// HoldsOnDomain checks whether a given expression vanishes (i.e. evaluates to
+// zero) for a given set of rows in a trace.  Negative rows are calculated from
+// the end of the trace (e.g. -1 is the last row).  Rows which do not exist in
+// the trace are ignored, as are duplicates (e.g. rows 0 and -1 for a trace of
+// height 1).  If the expression does not vanish, report an appropriate error
+// for each failing row (up to the given limit).
+func HoldsOnDomain[T sc.Testable](handle string, ctx tr.Context, domain []int, constraint T, tr tr.Trace,
+	limit uint) []sc.Failure {
+	var (
+		failures []sc.Failure
 		// Determine height of enclosing module
-		height := tr.Height(p.Context)
+		height = int(tr.Height(ctx))
+		// Rows already checked
+		checked = make(map[int]bool)
+	)
+	//
+	for _, row := range domain {
 		// Negative rows calculated from end of trace.
-		start = height + uint(domain)
-	} else {
-		start = uint(domain)
+		if row < 0 {
+			row += height
+		}
+		// Check specific row (if it exists)
+		if row < 0 || row >= height || checked[row] {
+			continue
+		} else if uint(len(failures)) >= limit {
+			break
+		} else if err := HoldsLocally(uint(row), handle, constraint, tr); err != nil {
+			failures = append(failures, err)
+		}
+		//
+		checked[row] = true
 	}
-	// Check specific row
-	return HoldsLocally(start, p.Handle, p.Constraint, tr)
+	// Done
+	return failures
 }
 
 // HoldsGlobally checks whether a given expression vanishes (i.e. evaluates to
-// zero) for all rows of a trace.  If not, report an appropriate error.
-func HoldsGlobally[T sc.Testable](handle string, ctx tr.Context, constraint T, tr tr.Trace) sc.Failure {
+// zero) for all rows of a trace.  If not, report an appropriate error for each
+// failing row (up to the given limit).
+func HoldsGlobally[T sc.Testable](handle string, ctx tr.Context, constraint T, tr tr.Trace,
+	limit uint) []sc.Failure {
+	var failures []sc.Failure
 	// Determine height of enclosing module
 	height := tr.Height(ctx)
 	// Determine well-definedness bounds for this constraint
@@ -149,14 +194,14 @@ func HoldsGlobally[T sc.Testable](handle string, ctx tr.Context, constraint T, t
 	// Sanity check enough rows
 	if bounds.End < height {
 		// Check all in-bounds values
-		for k := bounds.Start; k < (height - bounds.End); k++ {
+		for k := bounds.Start; k < (height-bounds.End) && uint(len(failures)) < limit; k++ {
 			if err := HoldsLocally(k, handle, constraint, tr); err != nil {
-				return err
+				failures = append(failures, err)
 			}
 		}
 	}
-	// Success
-	return nil
+	// Done
+	return failures
 }
 
 // HoldsLocally checks whether a given constraint holds (e.g. vanishes) on a
@@ -184,14 +229,7 @@ func (p *VanishingConstraint[T]) Lisp(schema sc.Schema) sexp.SExp {
 	}
 	// Handle attributes
 	if p.Domain.HasValue() {
-		domain := p.Domain.Unwrap()
-		if domain == 0 {
-			name = fmt.Sprintf("%s:first", name)
-		} else if domain == -1 {
-			name = fmt.Sprintf("%s:last", name)
-		} else {
-			panic(fmt.Sprintf("domain value %d not supported for local constraint", domain))
-		}
+		name = fmt.Sprintf("%s:%s", name, domainString(p.Domain.Unwrap()))
 	}
 	// Determine multiplier
 	multiplier := fmt.Sprintf("x%d", p.Context.LengthMultiplier())
@@ -202,3 +240,29 @@ func (p *VanishingConstraint[T]) Lisp(schema sc.Schema) sexp.SExp {
 		p.Constraint.Lisp(schema),
 	})
 }
+
+// Construct a human-readable representation of a given domain.  For backwards
+// compatibility, the common cases of the first and last rows are given names.
+func domainString(domain []int) string {
+	var builder strings.Builder
+	//
+	if len(domain) == 1 && domain[0] == 0 {
+		return "first"
+	} else if len(domain) == 1 && domain[0] == -1 {
+		return "last"
+	}
+	//
+	builder.WriteString("{")
+	//
+	for i, row := range domain {
+		if i != 0 {
+			builder.WriteString(",")
+		}
+		//
+		builder.WriteString(fmt.Sprintf("%d", row))
+	}
+	//
+	builder.WriteString("}")
+	//
+	return builder.String()
+}
@@ -0,0 +1,147 @@
+package schema
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/consensys/go-corset/pkg/util"
+)
+
+// FailureSummary groups together all of the row failures arising from a given
+// constraint, such that they can be reported in a compact form.  In particular,
+// the failing rows are grouped into contiguous spans.
+type FailureSummary struct {
+	// Module enclosing the failing constraint ("" for the root module, or when
+	// this cannot be determined).
+	Module string
+	// Handle of the failing constraint
+	Handle string
+	// Failures contains each of the failures for this constraint, sorted by
+	// row.
+	Failures []RowFailure
+	// Spans contains the (inclusive) ranges of contiguous failing rows, sorted
+	// in ascending order.
+	Spans []util.Pair[uint, uint]
+}
+
+// Count returns the number of failing rows in this summary.
+func (p *FailureSummary) Count() uint {
+	return uint(len(p.Failures))
+}
+
+// First returns the first failing row in this summary.
+func (p *FailureSummary) First() uint {
+	return p.Spans[0].Left
+}
+
+// Last returns the last failing row in this summary.
+func (p *FailureSummary) Last() uint {
+	return p.Spans[len(p.Spans)-1].Right
+}
+
+// Message provides a suitable error message summarising all failures, where at
+// most maxSpans spans of rows are listed.
+func (p *FailureSummary) Message(maxSpans uint) string {
+	var builder strings.Builder
+	//
+	for i, span := range p.Spans {
+		if i != 0 {
+			builder.WriteString(", ")
+		}
+		//
+		if uint(i) == maxSpans {
+			builder.WriteString("...")
+			break
+		} else if span.Left == span.Right {
+			builder.WriteString(fmt.Sprintf("%d", span.Left))
+		} else {
+			builder.WriteString(fmt.Sprintf("%d..%d", span.Left, span.Right))
+		}
+	}
+	//
+	return fmt.Sprintf("\"%s\" failed on %d row(s) (first %d, last %d): %s", p.QualifiedHandle(), p.Count(),
+		p.First(), p.Last(), builder.String())
+}
+
+// QualifiedHandle returns the handle of the failing constraint, qualified by
+// its enclosing module (unless this is the root module).
+func (p *FailureSummary) QualifiedHandle() string {
+	if p.Module == "" {
+		return p.Handle
+	}
+	//
+	return fmt.Sprintf("%s.%s", p.Module, p.Handle)
+}
+
+// SummariseFailures groups row failures together by the constraint from which
+// they arose, as identified by its enclosing module and handle.  Failures which
+// do not arise on a specific row (e.g. for permutation constraints) are
+// returned separately.  Summaries are returned in the order in which their
+// constraints are first encountered.
+func SummariseFailures(schema Schema, failures []Failure) ([]FailureSummary, []Failure) {
+	var (
+		summaries []FailureSummary
+		others    []Failure
+		index     = make(map[util.Pair[string, string]]int)
+	)
+	// Group failures by module and handle
+	for _, f := range failures {
+		if rf, ok := f.(RowFailure); ok {
+			handle, _ := rf.Location()
+			key := util.NewPair(moduleOfFailure(schema, rf), handle)
+			//
+			if i, ok := index[key]; ok {
+				summaries[i].Failures = append(summaries[i].Failures, rf)
+			} else {
+				index[key] = len(summaries)
+				summaries = append(summaries, FailureSummary{key.Left, handle, []RowFailure{rf}, nil})
+			}
+		} else {
+			others = append(others, f)
+		}
+	}
+	// Determine spans for each group
+	for i := range summaries {
+		summaries[i].Spans = groupFailingRows(summaries[i].Failures)
+	}
+	//
+	return summaries, others
+}
+
+// Determine the name of the module enclosing a given failure, or "" if this
+// cannot be determined.
+func moduleOfFailure(schema Schema, failure RowFailure) string {
+	ctx := failure.Context(schema)
+	//
+	if ctx.IsVoid() || ctx.IsConflicted() {
+		return ""
+	}
+	//
+	return schema.Modules().Nth(ctx.Module()).Name
+}
+
+// Sort the given failures by row, and then group them into contiguous spans.
+func groupFailingRows(failures []RowFailure) []util.Pair[uint, uint] {
+	var spans []util.Pair[uint, uint]
+	//
+	slices.SortStableFunc(failures, func(l RowFailure, r RowFailure) int {
+		_, lrow := l.Location()
+		_, rrow := r.Location()
+		//
+		return cmp.Compare(lrow, rrow)
+	})
+	//
+	for _, f := range failures {
+		_, row := f.Location()
+		// Check whether extends the last span
+		if n := len(spans); n > 0 && spans[n-1].Right+1 >= row {
+			spans[n-1].Right = max(spans[n-1].Right, row)
+		} else {
+			spans = append(spans, util.NewPair(row, row))
+		}
+	}
+	//
+	return spans
+}
@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dot renders a given graph in the Graphviz DOT language.  This produces two
+// digraphs: "<name>_modules" connecting modules through lookups and
+// permutations; and "<name>_columns" connecting columns through assignments,
+// where columns are clustered by module.  Computed columns are shown as
+// ellipses, and input columns as boxes.
+func Dot(name string, graph *Graph) string {
+	var builder strings.Builder
+	// Module graph
+	builder.WriteString(fmt.Sprintf("digraph %s {\n", dotId(name+"_modules")))
+	//
+	for i, m := range graph.Modules {
+		builder.WriteString(fmt.Sprintf("  m%d [label=%s];\n", i, dotId(moduleTitle(m))))
+	}
+	//
+	for _, e := range graph.ModuleEdges {
+		label := e.Kind
+		//
+		if e.Handle != "" {
+			label = fmt.Sprintf("%s %s", e.Kind, e.Handle)
+		}
+		//
+		builder.WriteString(fmt.Sprintf("  m%d -> m%d [label=%s, style=%s];\n", e.Source, e.Target, dotId(label),
+			dotStyle(e.Kind)))
+	}
+	//
+	builder.WriteString("}\n")
+	// Column graph
+	builder.WriteString(fmt.Sprintf("digraph %s {\n", dotId(name+"_columns")))
+	//
+	for i, m := range graph.Modules {
+		var nodes []string
+		//
+		for j, c := range graph.Columns {
+			if c.Module == uint(i) {
+				shape := "box"
+				//
+				if c.Computed {
+					shape = "ellipse"
+				}
+				//
+				nodes = append(nodes, fmt.Sprintf("    c%d [label=%s, shape=%s];\n", j, dotId(c.Name), shape))
+			}
+		}
+		// Omit modules without columns
+		if len(nodes) > 0 {
+			builder.WriteString(fmt.Sprintf("  subgraph cluster_%d {\n", i))
+			builder.WriteString(fmt.Sprintf("    label=%s;\n", dotId(moduleTitle(m))))
+			builder.WriteString(strings.Join(nodes, ""))
+			builder.WriteString("  }\n")
+		}
+	}
+	//
+	for _, e := range graph.ColumnEdges {
+		builder.WriteString(fmt.Sprintf("  c%d -> c%d [label=%s];\n", e.Source, e.Target, dotId(e.Kind)))
+	}
+	//
+	builder.WriteString("}\n")
+	//
+	return builder.String()
+}
+
+// Quote a given string for use as an identifier (or label) in DOT.
+func dotId(text string) string {
+	text = strings.ReplaceAll(text, "\\", "\\\\")
+	return fmt.Sprintf("\"%s\"", strings.ReplaceAll(text, "\"", "\\\""))
+}
+
+// Determine the line style for a given kind of module edge.
+func dotStyle(kind string) string {
+	if kind == PERMUTATION {
+		return "dashed"
+	}
+	//
+	return "solid"
+}
+
+func moduleTitle(name string) string {
+	if name == "" {
+		return "(root)"
+	}
+	//
+	return name
+}
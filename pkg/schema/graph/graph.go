@@ -0,0 +1,141 @@
+package graph
+
+import (
+	"github.com/consensys/go-corset/pkg/air"
+	"github.com/consensys/go-corset/pkg/hir"
+	"github.com/consensys/go-corset/pkg/mir"
+	sc "github.com/consensys/go-corset/pkg/schema"
+	"github.com/consensys/go-corset/pkg/schema/assignment"
+)
+
+// Kinds of edge between modules.
+const (
+	// LOOKUP indicates a lookup from a source module into a target module.
+	LOOKUP = "lookup"
+	// PERMUTATION indicates that columns in the target module are a
+	// permutation of columns in the source module.
+	PERMUTATION = "permutation"
+)
+
+// Kinds of edge between columns, which identify the kind of assignment used to
+// compute the target column.
+const (
+	// COMPUTED indicates a column computed from an expression.
+	COMPUTED = "computed"
+	// COMPUTATION indicates a column computed by a native function.
+	COMPUTATION = "computation"
+	// DECOMPOSITION indicates a column arising from a byte decomposition.
+	DECOMPOSITION = "decomposition"
+	// INTERLEAVING indicates a column interleaved from its sources.
+	INTERLEAVING = "interleaving"
+	// LEXICOGRAPHIC_SORT indicates a column used to enforce a lexicographic
+	// sort.
+	LEXICOGRAPHIC_SORT = "lexicographic-sort"
+	// SORTED_PERMUTATION indicates a column which is a sorted permutation of
+	// its sources.
+	SORTED_PERMUTATION = "sorted-permutation"
+)
+
+// Graph captures the dependencies within a given schema at two levels.  At the
+// module level, edges indicate how modules are connected through lookups and
+// permutations.  At the column level, edges indicate which columns each
+// computed column depends upon.  Modules and columns are identified by their
+// index within the schema.
+type Graph struct {
+	// Names of all modules in the schema ("" for the root module).
+	Modules []string `json:"modules"`
+	// Edges between modules.
+	ModuleEdges []Edge `json:"module_edges"`
+	// Columns of the schema.
+	Columns []Column `json:"columns"`
+	// Edges from the columns on which each computed column depends, to that
+	// column.
+	ColumnEdges []Edge `json:"column_edges"`
+}
+
+// Column describes a given column in the graph.
+type Column struct {
+	// Name of the column.
+	Name string `json:"name"`
+	// Index of the enclosing module.
+	Module uint `json:"module"`
+	// Indicates whether the column is computed, or an input column.
+	Computed bool `json:"computed"`
+}
+
+// Edge describes a directed edge between two modules, or between two columns.
+type Edge struct {
+	// Kind of edge (e.g. "lookup").
+	Kind string `json:"kind"`
+	// Handle of the constraint from which this edge arises (if applicable).
+	Handle string `json:"handle,omitempty"`
+	// Index of the source module (or column).
+	Source uint `json:"source"`
+	// Index of the target module (or column).
+	Target uint `json:"target"`
+}
+
+// Build constructs the dependency graph for a given schema (at any level).
+func Build(schema sc.Schema) *Graph {
+	var graph = &Graph{make([]string, 0), make([]Edge, 0), make([]Column, 0), make([]Edge, 0)}
+	//
+	for iter := schema.Modules(); iter.HasNext(); {
+		graph.Modules = append(graph.Modules, iter.Next().Name)
+	}
+	// Declarations are iterated in the order their columns are allocated.
+	for iter := schema.Declarations(); iter.HasNext(); {
+		decl := iter.Next()
+		//
+		for cols := decl.Columns(); cols.HasNext(); {
+			index := uint(len(graph.Columns))
+			column := cols.Next()
+			graph.Columns = append(graph.Columns, Column{column.Name, column.Context.Module(), decl.IsComputed()})
+			//
+			if a, ok := decl.(sc.Assignment); ok {
+				for _, dep := range a.Dependencies() {
+					graph.ColumnEdges = append(graph.ColumnEdges, Edge{kindOf(a), "", dep, index})
+				}
+			}
+		}
+		// Permutations connect their source module with their target module.
+		if p, ok := decl.(*assignment.SortedPermutation); ok {
+			source := sc.ContextOfColumns(p.Sources, schema).Module()
+			graph.ModuleEdges = append(graph.ModuleEdges, Edge{PERMUTATION, "", source, p.Context().Module()})
+		}
+	}
+	//
+	for iter := schema.Constraints(); iter.HasNext(); {
+		switch c := iter.Next().(type) {
+		case hir.LookupConstraint:
+			graph.addLookup(c.Handle, c.SourceContext.Module(), c.TargetContext.Module())
+		case mir.LookupConstraint:
+			graph.addLookup(c.Handle, c.SourceContext.Module(), c.TargetContext.Module())
+		case air.LookupConstraint:
+			graph.addLookup(c.Handle, c.SourceContext.Module(), c.TargetContext.Module())
+		}
+	}
+	//
+	return graph
+}
+
+func (p *Graph) addLookup(handle string, source uint, target uint) {
+	p.ModuleEdges = append(p.ModuleEdges, Edge{LOOKUP, handle, source, target})
+}
+
+// Determine the kind of edge arising from a given assignment.
+func kindOf(a sc.Assignment) string {
+	switch a.(type) {
+	case *assignment.Computation:
+		return COMPUTATION
+	case *assignment.ByteDecomposition:
+		return DECOMPOSITION
+	case *assignment.Interleaving:
+		return INTERLEAVING
+	case *assignment.LexicographicSort:
+		return LEXICOGRAPHIC_SORT
+	case *assignment.SortedPermutation:
+		return SORTED_PERMUTATION
+	default:
+		return COMPUTED
+	}
+}
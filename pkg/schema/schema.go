@@ -83,11 +83,36 @@ type Assignment interface {
 	Dependencies() []uint
 }
 
+// FixedAssignment is an assignment whose values are fixed by the schema itself,
+// rather than being determined from a trace.  As such, it determines the height
+// of its enclosing module, which cannot otherwise be determined from the trace.
+type FixedAssignment interface {
+	Assignment
+
+	// Height returns the number of rows assigned by this assignment, excluding
+	// any padding.
+	Height() uint
+}
+
 // Constraint represents an element which can "accept" a trace, or either reject
 // with an error (or eventually perhaps report a warning).
 type Constraint interface {
 	Lispifiable
-	Accepts(tr.Trace) Failure
+	// Accepts checks whether this constraint holds for a given trace, returning
+	// one or more failures if not.  The second argument limits the number of
+	// failures which will be reported, such that checking stops once the limit
+	// is reached.
+	Accepts(tr.Trace, uint) []Failure
+}
+
+// NamedConstraint represents a constraint which can be identified by a handle
+// within its enclosing module.  This is useful, for example, when reporting the
+// outcome of checking each constraint.
+type NamedConstraint interface {
+	Constraint
+	// Name returns the handle of this constraint, along with the evaluation
+	// context which identifies its enclosing module.
+	Name() (string, tr.Context)
 }
 
 // Failure embodies structured information about a failing constraint.
@@ -97,6 +122,18 @@ type Failure interface {
 	Message() string
 }
 
+// RowFailure embodies a failure which arises on a specific row of a trace (e.g.
+// a vanishing constraint which does not vanish on some row).
+type RowFailure interface {
+	Failure
+	// Location returns the handle of the failing constraint, along with the
+	// row on which it failed.
+	Location() (string, uint)
+	// Context returns the evaluation context (i.e. enclosing module) of the
+	// failing constraint.
+	Context(Schema) tr.Context
+}
+
 // Evaluable captures something which can be evaluated on a given table row to
 // produce an evaluation point.  For example, expressions in the
 // Mid-Level or Arithmetic-Level IR can all be evaluated at rows of a
@@ -184,11 +221,14 @@ type Column struct {
 	Name string
 	// Returns the expected type of data in this column
 	DataType Type
+	// Determines how values in this column should be rendered (e.g. when
+	// printing a trace).  This has no semantic meaning.
+	Display tr.Display
 }
 
 // NewColumn constructs a new column
 func NewColumn(context tr.Context, name string, datatype Type) Column {
-	return Column{context, name, datatype}
+	return Column{context, name, datatype, tr.NewDisplay(tr.DISPLAY_HEX)}
 }
 
 // QualifiedName returns the fully qualified name of this column
@@ -72,16 +72,17 @@ func ContextOfColumns(cols []uint, schema Schema) tr.Context {
 // whether or not the given trace adheres to the schema constraints.  A trace
 // can fail to adhere to the schema for a variety of reasons, such as having a
 // constraint which does not hold.  Observe that this does not check assertions
-// within the schema hold.
+// within the schema hold.  The limit determines the maximum number of failures
+// reported for any given constraint.
 //
 //nolint:revive
-func Accepts(batchsize uint, schema Schema, trace tr.Trace) []Failure {
+func Accepts(batchsize uint, limit uint, schema Schema, trace tr.Trace) []Failure {
 	errors := make([]Failure, 0)
 	// Initialise batch number (for debugging purposes)
 	batch := uint(0)
 	// Process constraints in batches
 	for iter := schema.Constraints(); iter.HasNext(); {
-		errs := processConstraintBatch("Constraint", batch, batchsize, iter, trace)
+		errs := processConstraintBatch("Constraint", batch, batchsize, limit, iter, trace)
 		errors = append(errors, errs...)
 		// Increment batch number
 		batch++
@@ -91,14 +92,16 @@ func Accepts(batchsize uint, schema Schema, trace tr.Trace) []Failure {
 }
 
 // Asserts determines whether or not this schema will "assert" a given trace.
-// That is, whether or not the given trace adheres to the schema assertions.
-func Asserts(batchsize uint, schema Schema, trace tr.Trace) []Failure {
+// That is, whether or not the given trace adheres to the schema assertions.  The
+// limit determines the maximum number of failures reported for any given
+// assertion.
+func Asserts(batchsize uint, limit uint, schema Schema, trace tr.Trace) []Failure {
 	errors := make([]Failure, 0)
 	// Initialise batch number (for debugging purposes)
 	batch := uint(0)
 	// Process assertions in batches
 	for iter := schema.Assertions(); iter.HasNext(); {
-		errs := processConstraintBatch("Assertion", batch, batchsize, iter, trace)
+		errs := processConstraintBatch("Assertion", batch, batchsize, limit, iter, trace)
 		errors = append(errors, errs...)
 		// Increment batch number
 		batch++
@@ -108,10 +111,10 @@ func Asserts(batchsize uint, schema Schema, trace tr.Trace) []Failure {
 }
 
 // Process a given set of constraints in a single batch whilst recording all constraint failures.
-func processConstraintBatch(logtitle string, batch uint, batchsize uint, iter util.Iterator[Constraint],
-	trace tr.Trace) []Failure {
+func processConstraintBatch(logtitle string, batch uint, batchsize uint, limit uint,
+	iter util.Iterator[Constraint], trace tr.Trace) []Failure {
 	n := uint(0)
-	c := make(chan Failure, 1024)
+	c := make(chan []Failure, 1024)
 	errors := make([]Failure, 0)
 	stats := util.NewPerfStats()
 	// Launch at most 100 go-routines.
@@ -121,15 +124,13 @@ func processConstraintBatch(logtitle string, batch uint, batchsize uint, iter ut
 		// Launch checker for constraint
 		go func() {
 			// Send outcome back
-			c <- ith.Accepts(trace)
+			c <- ith.Accepts(trace, limit)
 		}()
 	}
 	//
 	for i := uint(0); i < n; i++ {
 		// Read from channel
-		if e := <-c; e != nil {
-			errors = append(errors, e)
-		}
+		errors = append(errors, <-c...)
 	}
 	// Log stats about this batch
 	stats.Log(fmt.Sprintf("%s batch %d", logtitle, batch))
@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"cmp"
 	"encoding/gob"
 	"fmt"
 	"math/big"
@@ -15,6 +16,9 @@ type Type interface {
 	// AsUint accesses this type as an unsigned integer.  If this type is not an
 	// unsigned integer, then this returns nil.
 	AsUint() *UintType
+	// AsInt accesses this type as a signed integer.  If this type is not a
+	// signed integer, then this returns nil.
+	AsInt() *IntType
 	// AsField accesses this type as a field element.  If this type is not a
 	// field element, then this returns nil.
 	AsField() *FieldType
@@ -25,8 +29,8 @@ type Type interface {
 	// Return the minimum number of bits required represent any element of this type.
 	BitWidth() uint
 	// Compare two types, returning: a negative value if this type is "below"
-	// the other; 0 if they are equal, a positive value if this type is "above"
-	// the other.
+	// the other; 0 if they are equal (or incomparable), a positive value if
+	// this type is "above" the other.
 	Cmp(Type) int
 	// Check whether subtypes another
 	SubtypeOf(Type) bool
@@ -61,6 +65,12 @@ func (p *UintType) AsUint() *UintType {
 	return p
 }
 
+// AsInt accesses this type assuming it is an Int.  Since this is not the case,
+// this returns nil.
+func (p *UintType) AsInt() *IntType {
+	return nil
+}
+
 // AsField accesses this type assuming it is a Field.  Since this is not the
 // case, this returns nil.
 func (p *UintType) AsField() *FieldType {
@@ -118,15 +128,145 @@ func (p *UintType) SubtypeOf(other Type) bool {
 
 // Cmp compares two types, returning: a negative value if this type is "below"
 // the other; 0 if they are equal, a positive value if this type is "above" the
-// other.
+// other.  Unsigned types are ordered by bitwidth, and are below the field type.
+// Signed types are compared as for IntType.Cmp (i.e. such that comparison is
+// antisymmetric).
 func (p *UintType) Cmp(other Type) int {
-	panic("todo")
+	switch o := other.(type) {
+	case *UintType:
+		return cmp.Compare(p.NumOfBits, o.NumOfBits)
+	case *IntType:
+		return -o.Cmp(p)
+	default:
+		return -1
+	}
 }
 
 func (p *UintType) String() string {
 	return fmt.Sprintf("u%d", p.NumOfBits)
 }
 
+// IntType represents a signed integer encoded using a given number of bits.
+// For example, the type "s8" holds values in the range -128..127.  Observe that
+// values are not stored in two's complement form.  Rather, negative values are
+// represented by their additive inverse in the field (e.g. -1 is represented as
+// p-1 for prime p) and, hence, require the full width of a field element to be
+// stored.
+type IntType struct {
+	// The number of bits this type represents (e.g. 8 for s8, etc).
+	NumOfBits uint
+	// The offset which maps all values in this type onto the range [0,2^n)
+	// (e.g. 2^7 for s8, etc).
+	ValueOffset fr.Element
+	// The numeric bound of all values in this type, after the offset has been
+	// applied (e.g. 2^8 for s8, etc).
+	ValueBound fr.Element
+}
+
+// NewIntType constructs a new signed integer type for a given (non-zero) bit
+// width.
+func NewIntType(nbits uint) *IntType {
+	var offset, bound big.Int
+	// Compute 2^(n-1) and 2^n
+	offset.Exp(big.NewInt(2), big.NewInt(int64(nbits-1)), nil)
+	bound.Exp(big.NewInt(2), big.NewInt(int64(nbits)), nil)
+	//
+	p := &IntType{NumOfBits: nbits}
+	p.ValueOffset.SetBigInt(&offset)
+	p.ValueBound.SetBigInt(&bound)
+	//
+	return p
+}
+
+// AsUint accesses this type assuming it is a Uint.  Since this is not the
+// case, this returns nil.
+func (p *IntType) AsUint() *UintType {
+	return nil
+}
+
+// AsInt accesses this type assuming it is an Int.  Since this is the case, this
+// just returns itself.
+func (p *IntType) AsInt() *IntType {
+	return p
+}
+
+// AsField accesses this type assuming it is a Field.  Since this is not the
+// case, this returns nil.
+func (p *IntType) AsField() *FieldType {
+	return nil
+}
+
+// ByteWidth returns the number of bytes required represent any element of this
+// type.
+func (p *IntType) ByteWidth() uint {
+	return (p.NumOfBits + 7) / 8
+}
+
+// Accept determines whether a given value is an element of this type.  For
+// example, -128 is an element of the type s8 whilst 128 is not.  This holds
+// when the value, after applying the offset, is within the bound.
+func (p *IntType) Accept(val fr.Element) bool {
+	var shifted fr.Element
+	//
+	shifted.Add(&val, &p.ValueOffset)
+	//
+	return shifted.Cmp(&p.ValueBound) < 0
+}
+
+// BitWidth returns the bitwidth of this type.  For example, the bitwidth of the
+// type s8 is 8.
+func (p *IntType) BitWidth() uint {
+	return p.NumOfBits
+}
+
+// Offset returns the offset which maps all values in this type onto the range
+// [0,Bound()).  For example, the offset of s8 is 128.
+func (p *IntType) Offset() fr.Element {
+	return p.ValueOffset
+}
+
+// Bound determines the bound for all values in this type, after the offset
+// has been applied.  For example, the bound of s8 is 256.
+func (p *IntType) Bound() fr.Element {
+	return p.ValueBound
+}
+
+// SubtypeOf checks whether this subtypes another
+func (p *IntType) SubtypeOf(other Type) bool {
+	if other.AsField() != nil {
+		return true
+	} else if o, ok := other.(*IntType); ok {
+		return p.NumOfBits == o.NumOfBits
+	}
+
+	return false
+}
+
+// Cmp compares two types, returning: a negative value if this type is "below"
+// the other; 0 if they are equal (or incomparable), a positive value if this
+// type is "above" the other.  Signed types are ordered by bitwidth.  A signed
+// type is above an unsigned type only when it holds every value of that type
+// (e.g. s9 is above u8), otherwise they are incomparable (e.g. s8 and u8).
+func (p *IntType) Cmp(other Type) int {
+	switch o := other.(type) {
+	case *IntType:
+		return cmp.Compare(p.NumOfBits, o.NumOfBits)
+	case *UintType:
+		// An unsigned type requires an extra bit to be represented as signed.
+		if p.NumOfBits > o.NumOfBits {
+			return 1
+		}
+		// Neither holds every value of the other.
+		return 0
+	default:
+		return -1
+	}
+}
+
+func (p *IntType) String() string {
+	return fmt.Sprintf("s%d", p.NumOfBits)
+}
+
 // FieldType is the type of raw field elements (normally for a prime field).
 type FieldType struct {
 }
@@ -137,6 +277,12 @@ func (p *FieldType) AsUint() *UintType {
 	return nil
 }
 
+// AsInt accesses this type assuming it is an Int.  Since this is not the case,
+// this returns nil.
+func (p *FieldType) AsInt() *IntType {
+	return nil
+}
+
 // AsField accesses this type assuming it is a Field.  Since this is the case,
 // this just returns itself.
 func (p *FieldType) AsField() *FieldType {
@@ -164,7 +310,11 @@ func (p *FieldType) SubtypeOf(other Type) bool {
 // the other; 0 if they are equal, a positive value if this type is "above" the
 // other.
 func (p *FieldType) Cmp(other Type) int {
-	panic("todo")
+	if other.AsField() != nil {
+		return 0
+	}
+	// The field type is above all other types
+	return 1
 }
 
 // Accept determines whether a given value is an element of this type.  In
@@ -178,10 +328,13 @@ func (p *FieldType) String() string {
 }
 
 // Join computes the Least Upper Bound of two types.  For example, the lub of u16
-// and u128 is u128, etc.
+// and u128 is u128, etc.  Joining a signed and an unsigned type produces a
+// signed type large enough to hold both (e.g. the lub of s8 and u8 is s9).
 func Join(lhs Type, rhs Type) Type {
 	if lhs.AsField() != nil || rhs.AsField() != nil {
 		return &FieldType{}
+	} else if lhs.AsInt() != nil || rhs.AsInt() != nil {
+		return joinSigned(lhs, rhs)
 	}
 	//
 	uLhs := lhs.AsUint()
@@ -194,11 +347,28 @@ func Join(lhs Type, rhs Type) Type {
 	return uRhs
 }
 
+// Join two integer types, at least one of which is signed.
+func joinSigned(lhs Type, rhs Type) Type {
+	var (
+		lbits = lhs.BitWidth()
+		rbits = rhs.BitWidth()
+	)
+	// An unsigned type requires an extra bit to be represented as signed.
+	if lhs.AsUint() != nil {
+		lbits++
+	} else if rhs.AsUint() != nil {
+		rbits++
+	}
+	//
+	return NewIntType(max(lbits, rbits))
+}
+
 // ============================================================================
 // Encoding / Decoding
 // ============================================================================
 
 func init() {
 	gob.Register(Type(&UintType{}))
+	gob.Register(Type(&IntType{}))
 	gob.Register(Type(&FieldType{}))
 }
@@ -0,0 +1,66 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/consensys/go-corset/pkg/trace/csv"
+)
+
+func Test_CsvReader_01(t *testing.T) {
+	CsvRoundTripCheck(t, "m1.X,m1.Y,m2.A\n1,5,1\n2,6,\n")
+}
+
+func Test_CsvReader_02(t *testing.T) {
+	CsvRoundTripCheck(t, "A,B\n0,1606938044258990275541962092341162602522202993782792835301376\n")
+}
+
+func Test_CsvReader_03(t *testing.T) {
+	cols, err := csv.FromBytes([]byte("A,B\n0x10,1\n0xff,\n"))
+	//
+	if err != nil {
+		t.Fatal(err)
+	} else if cols[0].Data.BitWidth() != 8 || cols[1].Data.BitWidth() != 1 {
+		t.Errorf("incorrect bitwidths inferred")
+	} else if cols[0].Data.Len() != 2 || cols[1].Data.Len() != 1 {
+		t.Errorf("incorrect heights inferred")
+	}
+}
+
+func Test_CsvReader_04(t *testing.T) {
+	CsvReaderErrorCheck(t, "A,B\n1,\n2,3\n", "B[1]")
+}
+
+func Test_CsvReader_05(t *testing.T) {
+	CsvReaderErrorCheck(t, "A,B\n1,2\n2,x\n", "B[1]")
+}
+
+// CsvRoundTripCheck checks that reading a given CSV trace and then writing it
+// back produces the original trace.
+func CsvRoundTripCheck(t *testing.T, input string) {
+	cols, err := csv.FromBytes([]byte(input))
+	//
+	if err != nil {
+		t.Fatal(err)
+	}
+	//
+	output, err := csv.ToBytes(cols)
+	//
+	if err != nil {
+		t.Fatal(err)
+	} else if string(output) != input {
+		t.Errorf("expected %q, got %q", input, string(output))
+	}
+}
+
+// CsvReaderErrorCheck checks that reading a given (malformed) CSV trace
+// produces an error which contains the given text.
+func CsvReaderErrorCheck(t *testing.T, input string, expected string) {
+	_, err := csv.FromBytes([]byte(input))
+	//
+	if err == nil {
+		t.Fatalf("malformed trace accepted")
+	} else if !strings.Contains(err.Error(), expected) {
+		t.Errorf("error \"%s\" does not contain \"%s\"", err.Error(), expected)
+	}
+}
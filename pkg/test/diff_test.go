@@ -0,0 +1,65 @@
+package test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/consensys/go-corset/pkg/trace"
+	"github.com/consensys/go-corset/pkg/trace/json"
+)
+
+func Test_TraceDiff_01(t *testing.T) {
+	TraceDiffCheck(t, `{"A": [1, 2]}`, `{"A": [1, 2]}`, 10)
+}
+
+func Test_TraceDiff_02(t *testing.T) {
+	TraceDiffCheck(t, `{"A": [1, 2], "B": [3]}`, `{"A": [1, 2], "C": [3]}`, 10,
+		traceDiff{"B", trace.DIFF_REMOVED, 0, nil}, traceDiff{"C", trace.DIFF_ADDED, 0, nil})
+}
+
+func Test_TraceDiff_03(t *testing.T) {
+	TraceDiffCheck(t, `{"m.A": [1, 2, 3, 4]}`, `{"m.A": [1, 0, 3, 0]}`, 10,
+		traceDiff{"m.A", trace.DIFF_CHANGED, 2, []uint{1, 3}})
+}
+
+func Test_TraceDiff_04(t *testing.T) {
+	TraceDiffCheck(t, `{"m.A": [1, 2, 3, 4]}`, `{"m.A": [0, 0, 0]}`, 2,
+		traceDiff{"m.A", trace.DIFF_CHANGED, 3, []uint{0, 1}})
+}
+
+func Test_TraceDiff_05(t *testing.T) {
+	TraceDiffCheck(t, `{"m.A": [1, 2]}`, `{"m.A": [1, 2, 3]}`, 10,
+		traceDiff{"m.A", trace.DIFF_CHANGED, 0, nil})
+}
+
+type traceDiff struct {
+	name  string
+	kind  uint
+	count uint
+	rows  []uint
+}
+
+// TraceDiffCheck checks that comparing two (JSON) traces produces the expected
+// differences.
+func TraceDiffCheck(t *testing.T, oldTrace string, newTrace string, limit uint, expected ...traceDiff) {
+	oldCols, err1 := json.FromBytes([]byte(oldTrace))
+	newCols, err2 := json.FromBytes([]byte(newTrace))
+	//
+	if err1 != nil || err2 != nil {
+		t.Fatalf("invalid trace")
+	}
+	//
+	diffs := trace.DiffColumns(oldCols, newCols, limit)
+	//
+	if len(diffs) != len(expected) {
+		t.Fatalf("expected %d differences, got %d", len(expected), len(diffs))
+	}
+	//
+	for i, diff := range diffs {
+		actual := traceDiff{diff.Name(), diff.Kind, diff.Count, diff.Rows}
+		//
+		if !reflect.DeepEqual(actual, expected[i]) {
+			t.Errorf("expected %v, got %v", expected[i], actual)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/go-corset/pkg/trace"
+)
+
+func Test_Display_01(t *testing.T) {
+	DisplayCheck(t, trace.NewDisplay(trace.DISPLAY_HEX), 31, "0x1f")
+}
+
+func Test_Display_02(t *testing.T) {
+	DisplayCheck(t, trace.NewDisplay(trace.DISPLAY_DEC), 31, "31")
+}
+
+func Test_Display_03(t *testing.T) {
+	DisplayCheck(t, trace.NewDisplay(trace.DISPLAY_BYTES), 0, "00")
+}
+
+func Test_Display_04(t *testing.T) {
+	DisplayCheck(t, trace.NewDisplay(trace.DISPLAY_BYTES), 0x11f, "01 1f")
+}
+
+func Test_Display_05(t *testing.T) {
+	DisplayCheck(t, trace.NewDisplay(trace.DISPLAY_OPCODE), 0x01, "ADD")
+}
+
+func Test_Display_06(t *testing.T) {
+	DisplayCheck(t, trace.NewDisplay(trace.DISPLAY_OPCODE), 0x7f, "PUSH32")
+}
+
+func Test_Display_07(t *testing.T) {
+	// Not a valid opcode
+	DisplayCheck(t, trace.NewDisplay(trace.DISPLAY_OPCODE), 0x0c, "0xc")
+}
+
+func Test_Display_08(t *testing.T) {
+	// Out of range for an opcode
+	DisplayCheck(t, trace.NewDisplay(trace.DISPLAY_OPCODE), 0x100, "0x100")
+}
+
+func Test_Display_09(t *testing.T) {
+	for _, name := range []string{"hex", "dec", "bytes", "opcode"} {
+		if display, ok := trace.ParseDisplay(name); !ok || display.String() != name {
+			t.Errorf("failed parsing display %s", name)
+		}
+	}
+	//
+	if _, ok := trace.ParseDisplay("binary"); ok {
+		t.Errorf("parsed unknown display binary")
+	}
+}
+
+func Test_Display_10(t *testing.T) {
+	display := trace.NewEnumDisplay(testEnum())
+	//
+	DisplayCheck(t, display, 0x00, "STOP")
+	DisplayCheck(t, display, 0x60, "PUSH1")
+}
+
+func Test_Display_11(t *testing.T) {
+	// Not a member
+	DisplayCheck(t, trace.NewEnumDisplay(testEnum()), 0x03, "0x3")
+}
+
+func Test_Display_12(t *testing.T) {
+	if name := trace.NewEnumDisplay(testEnum()).String(); name != "OPCODE" {
+		t.Errorf("enum display gave name %s (expected OPCODE)", name)
+	}
+}
+
+func Test_Display_13(t *testing.T) {
+	display := trace.NewDisplay(trace.DISPLAY_HEX)
+	display.Signed = true
+	//
+	DisplayCheck(t, display, 0x1f, "0x1f")
+	DisplayCheck(t, display, 0, "0x0")
+	DisplayNegativeCheck(t, display, 1, "-0x1")
+	DisplayNegativeCheck(t, display, 128, "-0x80")
+}
+
+func Test_Display_14(t *testing.T) {
+	display := trace.NewDisplay(trace.DISPLAY_DEC)
+	display.Signed = true
+	//
+	DisplayCheck(t, display, 31, "31")
+	DisplayNegativeCheck(t, display, 31, "-31")
+}
+
+// Construct a simple enumeration for testing.
+func testEnum() *trace.Enumeration {
+	var stop, push1 fr.Element
+	//
+	push1.SetUint64(0x60)
+	//
+	return &trace.Enumeration{Name: "OPCODE", Members: []string{"STOP", "PUSH1"}, Values: []fr.Element{stop, push1}}
+}
+
+// DisplayCheck checks that a given value is rendered as expected by a given
+// display mode.
+func DisplayCheck(t *testing.T, display trace.Display, value uint64, expected string) {
+	var val fr.Element
+	//
+	val.SetUint64(value)
+	//
+	if actual := display.Format(val); actual != expected {
+		t.Errorf("display %s of %d gave \"%s\" (expected \"%s\")", display.String(), value, actual, expected)
+	}
+}
+
+// DisplayNegativeCheck checks that the negation of a given value is rendered as
+// expected by a given display mode.
+func DisplayNegativeCheck(t *testing.T, display trace.Display, value uint64, expected string) {
+	var val fr.Element
+	//
+	val.SetUint64(value)
+	val.Neg(&val)
+	//
+	if actual := display.Format(val); actual != expected {
+		t.Errorf("display %s of -%d gave \"%s\" (expected \"%s\")", display.String(), value, actual, expected)
+	}
+}
@@ -0,0 +1,144 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/consensys/go-corset/pkg/corset/doc"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+const docSource = `(defpurefun ((vanishes! :@loob) x) x)
+(module m1)
+;; Columns of m1
+(defcolumns
+  ;; The stamp
+  STAMP
+  (CT :u8 :display :dec) ;; The counter
+  (ARR :u16 :array [1:4]))
+(definterleaved Z (STAMP CT))
+
+;; lint:ignore trivial-constraint
+;; First row
+(defconstraint first (:domain {0}) (vanishes! STAMP))
+
+(defconstraint heartbeat (:guard STAMP)
+  (vanishes! (- CT (shift CT -1) 1)))
+;; Perspective
+(defperspective p1 STAMP ((A :binary)))
+(defconstraint pc (:perspective p1) (vanishes! A))
+
+(module m2)
+(defcolumns X)
+;; Lookup into m1
+(deflookup l1 (m1.CT) (X))
+`
+
+func Test_Doc_Extract_01(t *testing.T) {
+	modules := docExtract(t, docSource)
+	//
+	if len(modules) != 2 || modules[0].Name != "m1" || modules[1].Name != "m2" {
+		t.Fatalf("unexpected modules %v", modules)
+	}
+	// Check columns
+	m1 := modules[0]
+	docCheckColumn(t, m1.Columns, 0, doc.Column{Name: "STAMP", Doc: "The stamp", Type: "𝔽", Multiplier: 1,
+		Display: "hex"})
+	docCheckColumn(t, m1.Columns, 1, doc.Column{Name: "CT", Doc: "The counter", Type: "u8", Multiplier: 1,
+		Display: "dec"})
+	docCheckColumn(t, m1.Columns, 2, doc.Column{Name: "ARR", Doc: "Columns of m1", Type: "u16", Array: "[1:4]",
+		Multiplier: 1, Display: "hex"})
+	docCheckColumn(t, m1.Columns, 3, doc.Column{Name: "Z", Type: "𝔽", Multiplier: 2, Display: "hex",
+		Computed: true})
+	// Check constraints
+	if len(m1.Constraints) != 3 {
+		t.Fatalf("unexpected constraints %v", m1.Constraints)
+	}
+	//
+	docCheck(t, m1.Constraints[0].Doc, "First row")
+	docCheck(t, m1.Constraints[0].Domain, "{0}")
+	docCheck(t, m1.Constraints[0].Source, "(defconstraint first (:domain {0}) (vanishes! STAMP))")
+	docCheck(t, m1.Constraints[1].Doc, "")
+	docCheck(t, m1.Constraints[1].Guard, "STAMP")
+	docCheck(t, m1.Constraints[2].Perspective, "p1")
+	// Check perspectives
+	if len(m1.Perspectives) != 1 || len(m1.Perspectives[0].Columns) != 1 {
+		t.Fatalf("unexpected perspectives %v", m1.Perspectives)
+	}
+	//
+	docCheck(t, m1.Perspectives[0].Doc, "Perspective")
+	docCheck(t, m1.Perspectives[0].Selector, "STAMP")
+	// Check lookups
+	m2 := modules[1]
+	//
+	if len(m2.Lookups) != 1 {
+		t.Fatalf("unexpected lookups %v", m2.Lookups)
+	}
+	//
+	docCheck(t, m2.Lookups[0].Doc, "Lookup into m1")
+	docCheck(t, m2.Lookups[0].SourceModule, "m2")
+	docCheck(t, m2.Lookups[0].TargetModule, "m1")
+}
+
+func Test_Doc_Filename_01(t *testing.T) {
+	// Filenames of modules cannot collide with the root module, or the index
+	docCheck(t, doc.Filename(""), "_root")
+	docCheck(t, doc.Filename("root"), "root")
+	docCheck(t, doc.Filename("_root"), "__root")
+	docCheck(t, doc.Filename(doc.INDEX), "_index")
+}
+
+func Test_Doc_Markdown_01(t *testing.T) {
+	modules := docExtract(t, docSource)
+	//
+	docCheckContains(t, doc.MarkdownIndex(modules), "| [`m1`](m1.md) | 4 | 1 | 3 | 0 |")
+	docCheckContains(t, doc.Markdown(modules[0]), "| `CT` | `u8` |  | 1 | dec | input | The counter |",
+		"- Guard: `STAMP`", "### `pc`")
+	docCheckContains(t, doc.Markdown(modules[1]), "- Target: [`m1`](m1.md)")
+}
+
+func Test_Doc_Html_01(t *testing.T) {
+	modules := docExtract(t, docSource)
+	//
+	docCheckContains(t, doc.HTMLIndex(modules), "<a href=\"m2.html\"><code>m2</code></a>")
+	docCheckContains(t, doc.HTML(modules[0]), "<a href=\"#perspective-p1\"><code>p1</code></a>",
+		"<li>Domain: <code>{0}</code></li>")
+	docCheckContains(t, doc.HTML(modules[1]), "<li>Target: <a href=\"m1.html\"><code>m1</code></a></li>")
+}
+
+// ===================================================================
+// Test Helpers
+// ===================================================================
+
+func docExtract(t *testing.T, source string) []*doc.Module {
+	srcfile := sexp.NewSourceFile("test.lisp", []byte(source))
+	modules, errs := doc.Extract(false, []*sexp.SourceFile{srcfile})
+	//
+	for _, err := range errs {
+		t.Fatalf("unexpected error: %s", err.Message())
+	}
+	//
+	return modules
+}
+
+func docCheckColumn(t *testing.T, columns []doc.Column, index int, expected doc.Column) {
+	if index >= len(columns) {
+		t.Errorf("missing column %s", expected.Name)
+	} else if columns[index] != expected {
+		t.Errorf("expected column %v, got %v", expected, columns[index])
+	}
+}
+
+func docCheck(t *testing.T, actual string, expected string) {
+	if actual != expected {
+		t.Errorf("expected \"%s\", got \"%s\"", expected, actual)
+	}
+}
+
+func docCheckContains(t *testing.T, actual string, expected ...string) {
+	for _, e := range expected {
+		if !strings.Contains(actual, e) {
+			t.Errorf("expected \"%s\" in:\n%s", e, actual)
+		}
+	}
+}
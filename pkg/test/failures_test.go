@@ -0,0 +1,212 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/consensys/go-corset/pkg/air"
+	"github.com/consensys/go-corset/pkg/corset"
+	sc "github.com/consensys/go-corset/pkg/schema"
+	"github.com/consensys/go-corset/pkg/schema/constraint"
+	"github.com/consensys/go-corset/pkg/trace/json"
+	"github.com/consensys/go-corset/pkg/util"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+func Test_Failures_01(t *testing.T) {
+	failures := vanishingFailures("c1", 3)
+	FailuresCheck(t, failures, []util.Pair[uint, uint]{{Left: 3, Right: 3}})
+}
+
+func Test_Failures_02(t *testing.T) {
+	failures := vanishingFailures("c1", 3, 4, 5, 7)
+	FailuresCheck(t, failures, []util.Pair[uint, uint]{{Left: 3, Right: 5}, {Left: 7, Right: 7}})
+}
+
+func Test_Failures_03(t *testing.T) {
+	failures := vanishingFailures("c1", 9, 1, 8, 2, 0)
+	FailuresCheck(t, failures, []util.Pair[uint, uint]{{Left: 0, Right: 2}, {Left: 8, Right: 9}})
+}
+
+func Test_Failures_04(t *testing.T) {
+	failures := vanishingFailures("c1", 1, 3)
+	failures = append(failures, vanishingFailures("c2", 2)...)
+	failures = append(failures, &constraint.PermutationFailure{})
+	//
+	summaries, others := sc.SummariseFailures(nil, failures)
+	//
+	if len(summaries) != 2 || len(others) != 1 {
+		t.Fatalf("incorrect summarisation (%d summaries, %d others)", len(summaries), len(others))
+	} else if summaries[0].Handle != "c1" || summaries[0].Count() != 2 {
+		t.Errorf("incorrect summary for c1")
+	} else if summaries[1].Handle != "c2" || summaries[1].Count() != 1 {
+		t.Errorf("incorrect summary for c2")
+	}
+}
+
+func Test_Failures_05(t *testing.T) {
+	failures := vanishingFailures("c1", 1, 3, 5)
+	summaries, _ := sc.SummariseFailures(nil, failures)
+	//
+	expected := "\"c1\" failed on 3 row(s) (first 1, last 5): 1, 3, ..."
+	//
+	if msg := summaries[0].Message(2); msg != expected {
+		t.Errorf("incorrect message \"%s\" (expected \"%s\")", msg, expected)
+	}
+}
+
+func Test_Failures_06(t *testing.T) {
+	// Rows 2 and 4 of the source are not contained in the target (where row 0
+	// is padding).  The closest target row for source row 2 is the first
+	// matching one column (i.e. row 1), whilst for source row 4 it is row 2.
+	failures := lookupFailures(t, `{"X": [1, 1, 4, 5], "Y": [2, 3, 5, 9], "m2.A": [1, 4, 4], "m2.B": [2, 9, 5]}`)
+	//
+	if len(failures) != 2 {
+		t.Fatalf("incorrect failures %v", failures)
+	}
+	//
+	lookupCheckClosest(t, failures[0], 2, 1, 1)
+	lookupCheckClosest(t, failures[1], 4, 2, 1)
+}
+
+func Test_Failures_07(t *testing.T) {
+	// Constraints with the same handle in different modules are summarised
+	// separately.
+	schema, failures := checkFailures(t, `(defpurefun ((vanishes! :@loob) x) x)
+(defcolumns X)
+(defconstraint c1 () (vanishes! X))
+(module m1)
+(defcolumns Y)
+(defconstraint c1 () (vanishes! Y))`, `{"X": [1, 1], "m1.Y": [0, 1]}`)
+	//
+	summaries, _ := sc.SummariseFailures(schema, failures)
+	counts := make(map[string]uint)
+	//
+	for _, s := range summaries {
+		counts[s.QualifiedHandle()] = s.Count()
+	}
+	//
+	if len(summaries) != 2 || counts["c1"] != 2 || counts["m1.c1"] != 1 {
+		t.Errorf("incorrect summarisation %v", counts)
+	}
+}
+
+func Test_Failures_08(t *testing.T) {
+	// Constraints generated for computed columns do not clash with user
+	// constraints of the same name.
+	schema, failures := checkFailures(t, `(defpurefun ((vanishes! :@loob) x) x)
+(defcolumns X)
+(defcomputedcolumn D (* 2 X))
+(defconstraint D () (vanishes! (* X (- D 2))))`, `{"X": [2]}`)
+	//
+	handles := make(map[string]bool)
+	//
+	for iter := schema.Constraints(); iter.HasNext(); {
+		if c, ok := iter.Next().(sc.NamedConstraint); ok {
+			handle, _ := c.Name()
+			handles[handle] = true
+		}
+	}
+	//
+	if !handles["D"] || !handles["D:computed"] {
+		t.Errorf("incorrect constraint handles %v", handles)
+	} else if summaries, _ := sc.SummariseFailures(schema, failures); len(summaries) != 1 || summaries[0].Handle != "D" {
+		t.Errorf("incorrect summarisation %v", summaries)
+	}
+}
+
+// FailuresCheck checks that a set of failures for a single constraint is
+// summarised into the expected spans.
+func FailuresCheck(t *testing.T, failures []sc.Failure, spans []util.Pair[uint, uint]) {
+	summaries, others := sc.SummariseFailures(nil, failures)
+	//
+	if len(summaries) != 1 || len(others) != 0 {
+		t.Fatalf("incorrect summarisation (%d summaries, %d others)", len(summaries), len(others))
+	}
+	//
+	summary := summaries[0]
+	//
+	if summary.Count() != uint(len(failures)) {
+		t.Errorf("incorrect failure count %d (expected %d)", summary.Count(), len(failures))
+	} else if len(summary.Spans) != len(spans) {
+		t.Fatalf("incorrect spans %v (expected %v)", summary.Spans, spans)
+	}
+	//
+	for i, span := range spans {
+		if summary.Spans[i] != span {
+			t.Errorf("incorrect spans %v (expected %v)", summary.Spans, spans)
+		}
+	}
+	//
+	if summary.First() != spans[0].Left || summary.Last() != spans[len(spans)-1].Right {
+		t.Errorf("incorrect first / last rows (%d, %d)", summary.First(), summary.Last())
+	}
+}
+
+// Construct failures of a (constant) vanishing constraint on the given rows.
+func vanishingFailures(handle string, rows ...uint) []sc.Failure {
+	failures := make([]sc.Failure, len(rows))
+	test := constraint.ZeroTest[air.Expr]{Expr: air.NewConst64(1)}
+	//
+	for i, row := range rows {
+		failures[i] = &constraint.VanishingFailure{Handle: handle, Constraint: test, Row: row}
+	}
+	//
+	return failures
+}
+
+const lookupSource = `(defcolumns X Y)
+(deflookup l1 (m2.A m2.B) (X Y))
+(module m2)
+(defcolumns A B)`
+
+// Determine the failures arising from a simple lookup on a given trace.
+func lookupFailures(t *testing.T, trace string) []*constraint.LookupFailure {
+	var failures []*constraint.LookupFailure
+	//
+	_, fs := checkFailures(t, lookupSource, trace)
+	//
+	for _, f := range fs {
+		if lf, ok := f.(*constraint.LookupFailure); ok {
+			failures = append(failures, lf)
+		}
+	}
+	//
+	return failures
+}
+
+// Compile a given source file, and determine the failures arising from
+// checking a given trace against it.
+func checkFailures(t *testing.T, source string, trace string) (sc.Schema, []sc.Failure) {
+	var (
+		srcfile    = sexp.NewSourceFile("test.lisp", []byte(source))
+		schema, es = corset.CompileSourceFile(false, false, srcfile)
+	)
+	//
+	if len(es) > 0 {
+		t.Fatalf("unexpected errors %v", es)
+	}
+	//
+	columns, err := json.FromBytes([]byte(trace))
+	//
+	if err != nil {
+		t.Fatal(err)
+	}
+	//
+	tr, errs := sc.NewTraceBuilder(schema).Padding(0).Build(columns)
+	//
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	//
+	return schema, sc.Accepts(100, 100, schema, tr)
+}
+
+func lookupCheckClosest(t *testing.T, failure *constraint.LookupFailure, row uint, closest uint, matches uint) {
+	r, m, ok := failure.Closest()
+	//
+	if failure.Row != row {
+		t.Errorf("incorrect failing row %d (expected %d)", failure.Row, row)
+	} else if !ok || r != closest || m != matches {
+		t.Errorf("incorrect closest row %d matching %d (expected %d matching %d)", r, m, closest, matches)
+	}
+}
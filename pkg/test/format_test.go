@@ -0,0 +1,164 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/go-corset/pkg/corset"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+func Test_Format_01(t *testing.T) {
+	FormatCheck(t, "(defcolumns   A   B)\n\n\n\n(defconstraint c1 ()  (vanishes! A))",
+		"(defcolumns A B)\n\n(defconstraint c1 () (vanishes! A))\n")
+}
+
+func Test_Format_02(t *testing.T) {
+	// Comments are preserved
+	FormatCheck(t, ";; header\n(defcolumns A ; first\n  B)\n;; footer",
+		";; header\n(defcolumns\n  A ; first\n  B)\n;; footer\n")
+}
+
+func Test_Format_03(t *testing.T) {
+	// Column declarations are aligned
+	FormatCheck(t, "(defcolumns (X :u8) (LONGER :i16 :display :hex) (Y :binary@prove) Z (W :u32) (V :u64))",
+		`(defcolumns
+  (X      :u8)
+  (LONGER :i16 :display :hex)
+  (Y      :binary@prove)
+  Z
+  (W      :u32)
+  (V      :u64))
+`)
+}
+
+func Test_Format_04(t *testing.T) {
+	// Bodies of constraints are indented, and branches of if are aligned
+	FormatCheck(t, "(defconstraint long-constraint-name (:guard STAMP) (if (is-zero COUNTER) (will-inc! STAMP 1) "+
+		"(will-remain-constant! STAMP_AND_COUNTER)))",
+		`(defconstraint long-constraint-name (:guard STAMP)
+  (if (is-zero COUNTER)
+      (will-inc! STAMP 1)
+      (will-remain-constant! STAMP_AND_COUNTER)))
+`)
+}
+
+func Test_Format_05(t *testing.T) {
+	// Constants are aligned
+	FormatCheck(t, "(defconst ONE 1 TWO 2 ONE_HUNDRED 100 THOUSAND 1000 MILLION 1000000 BILLION 1000000000)",
+		`(defconst
+  ONE         1
+  TWO         2
+  ONE_HUNDRED 100
+  THOUSAND    1000
+  MILLION     1000000
+  BILLION     1000000000)
+`)
+}
+
+func Test_Format_06(t *testing.T) {
+	// Comments within expressions are preserved
+	FormatCheck(t, "(defconstraint c1 () (* ;; first\n A\n ;; second\n B))",
+		`(defconstraint c1 ()
+  (* ;; first
+     A
+     ;; second
+     B))
+`)
+}
+
+func Test_Format_07(t *testing.T) {
+	// Columns of a perspective are aligned
+	FormatCheck(t, "(defperspective perspective-one SELECTOR ((FIRST :binary) (SECOND :u16) (THIRD_COLUMN :u32) "+
+		"(FOURTH_COLUMN :u8) (FIFTH :u8)))",
+		`(defperspective perspective-one SELECTOR
+  ((FIRST         :binary)
+   (SECOND        :u16)
+   (THIRD_COLUMN  :u32)
+   (FOURTH_COLUMN :u8)
+   (FIFTH         :u8)))
+`)
+}
+
+// Check that formatting all valid test files preserves their meaning, and that
+// formatting is idempotent.
+func Test_Format_Files(t *testing.T) {
+	filenames, err := filepath.Glob(fmt.Sprintf("%s/*.lisp", TestDir))
+	//
+	if err != nil {
+		t.Fatal(err)
+	}
+	//
+	for _, filename := range filenames {
+		bytes, err := os.ReadFile(filename)
+		//
+		if err != nil {
+			t.Fatal(err)
+		}
+		//
+		FormatFileCheck(t, sexp.NewSourceFile(filename, bytes))
+	}
+	//
+	FormatFileCheck(t, sexp.NewSourceFile("stdlib.lisp", []byte(corset.STDLIB)))
+}
+
+// ===================================================================
+// Test Helpers
+// ===================================================================
+
+// FormatCheck checks that formatting a given input produces the expected output,
+// and that formatting this output again leaves it unchanged.
+func FormatCheck(t *testing.T, input string, expected string) {
+	actual := formatString(t, input)
+	//
+	if actual != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, actual)
+	} else if again := formatString(t, actual); again != actual {
+		t.Errorf("formatting not idempotent:\n%s\nthen:\n%s", actual, again)
+	}
+}
+
+// FormatFileCheck checks that formatting a given source file does not change
+// its terms, and is idempotent.  Source files which cannot be parsed are
+// ignored.
+func FormatFileCheck(t *testing.T, srcfile *sexp.SourceFile) {
+	original, _, err := srcfile.ParseAll()
+	//
+	if err != nil {
+		return
+	}
+	//
+	formatted := formatString(t, string(srcfile.Contents()))
+	terms, _, err := sexp.NewSourceFile(srcfile.Filename(), []byte(formatted)).ParseAll()
+	//
+	if err != nil {
+		t.Errorf("%s: formatted file failed to parse (%s)", srcfile.Filename(), err.Message())
+		return
+	} else if len(terms) != len(original) {
+		t.Errorf("%s: formatted file has %d terms, expected %d", srcfile.Filename(), len(terms), len(original))
+		return
+	}
+	//
+	for i := range terms {
+		if terms[i].String(false) != original[i].String(false) {
+			t.Errorf("%s: formatted term %s, expected %s", srcfile.Filename(), terms[i].String(false),
+				original[i].String(false))
+		}
+	}
+	//
+	if again := formatString(t, formatted); again != formatted {
+		t.Errorf("%s: formatting not idempotent:\n%s\nthen:\n%s", srcfile.Filename(), formatted, again)
+	}
+}
+
+func formatString(t *testing.T, input string) string {
+	output, err := corset.FormatSourceFile(sexp.NewSourceFile("test.lisp", []byte(input)), 80)
+	//
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Message())
+	}
+	//
+	return output
+}
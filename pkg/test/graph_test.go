@@ -0,0 +1,111 @@
+package test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/consensys/go-corset/pkg/corset"
+	"github.com/consensys/go-corset/pkg/hir"
+	"github.com/consensys/go-corset/pkg/schema/graph"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+const graphSource = `(module m1)
+(defcolumns STAMP (CT :u8))
+(definterleaved Z (STAMP CT))
+(defpermutation (S) ((+ CT)))
+
+(module m2)
+(defcolumns X)
+(deflookup l1 (m1.CT) (X))
+`
+
+func Test_Graph_Hir_01(t *testing.T) {
+	g := graph.Build(graphSchema(t))
+	//
+	graphCheckModuleEdge(t, g, graph.LOOKUP, "l1", "m2", "m1")
+	graphCheckModuleEdge(t, g, graph.PERMUTATION, "", "m1", "m1")
+	graphCheckColumnEdge(t, g, graph.INTERLEAVING, "STAMP", "Z")
+	graphCheckColumnEdge(t, g, graph.INTERLEAVING, "CT", "Z")
+	graphCheckColumnEdge(t, g, graph.SORTED_PERMUTATION, "CT", "S")
+	//
+	if len(g.ModuleEdges) != 2 || len(g.ColumnEdges) != 3 {
+		t.Errorf("unexpected edges %v, %v", g.ModuleEdges, g.ColumnEdges)
+	}
+	//
+	for _, c := range g.Columns {
+		if c.Computed != (c.Name == "Z" || c.Name == "S") {
+			t.Errorf("unexpected column %v", c)
+		}
+	}
+}
+
+func Test_Graph_Air_01(t *testing.T) {
+	g := graph.Build(graphSchema(t).LowerToMir().LowerToAir())
+	//
+	graphCheckModuleEdge(t, g, graph.LOOKUP, "l1", "m2", "m1")
+	graphCheckModuleEdge(t, g, graph.PERMUTATION, "", "m1", "m1")
+	graphCheckColumnEdge(t, g, graph.INTERLEAVING, "STAMP", "Z")
+	graphCheckColumnEdge(t, g, graph.SORTED_PERMUTATION, "CT", "S")
+}
+
+func Test_Graph_Dot_01(t *testing.T) {
+	dot := graph.Dot("hir", graph.Build(graphSchema(t)))
+	//
+	docCheckContains(t, dot, "digraph \"hir_modules\" {", "m2 -> m1 [label=\"lookup l1\", style=solid];",
+		"m1 -> m1 [label=\"permutation\", style=dashed];", "digraph \"hir_columns\" {", "label=\"m1\";",
+		"c0 [label=\"STAMP\", shape=box];", "c3 [label=\"Z\", shape=ellipse];", "c0 -> c3 [label=\"interleaving\"];")
+}
+
+func Test_Graph_Json_01(t *testing.T) {
+	var (
+		g      = graph.Build(graphSchema(t))
+		result graph.Graph
+	)
+	//
+	bytes, err := json.Marshal(g)
+	//
+	if err != nil {
+		t.Fatal(err)
+	} else if err = json.Unmarshal(bytes, &result); err != nil {
+		t.Fatal(err)
+	}
+	//
+	graphCheckModuleEdge(t, &result, graph.LOOKUP, "l1", "m2", "m1")
+	graphCheckColumnEdge(t, &result, graph.SORTED_PERMUTATION, "CT", "S")
+}
+
+// ===================================================================
+// Test Helpers
+// ===================================================================
+
+func graphSchema(t *testing.T) *hir.Schema {
+	srcfile := sexp.NewSourceFile("test.lisp", []byte(graphSource))
+	schema, errs := corset.CompileSourceFile(false, false, srcfile)
+	//
+	for _, err := range errs {
+		t.Fatalf("unexpected error: %s", err.Message())
+	}
+	//
+	return schema
+}
+
+func graphCheckModuleEdge(t *testing.T, g *graph.Graph, kind string, handle string, source string, target string) {
+	for _, e := range g.ModuleEdges {
+		if e.Kind == kind && e.Handle == handle && g.Modules[e.Source] == source && g.Modules[e.Target] == target {
+			return
+		}
+	}
+	//
+	t.Errorf("missing %s edge %s -> %s in %v", kind, source, target, g.ModuleEdges)
+}
+
+func graphCheckColumnEdge(t *testing.T, g *graph.Graph, kind string, source string, target string) {
+	for _, e := range g.ColumnEdges {
+		if e.Kind == kind && g.Columns[e.Source].Name == source && g.Columns[e.Target].Name == target {
+			return
+		}
+	}
+	//
+	t.Errorf("missing %s edge %s -> %s in %v", kind, source, target, g.ColumnEdges)
+}
@@ -146,6 +146,30 @@ func Test_Invalid_Constant_17(t *testing.T) {
 	CheckInvalid(t, "constant_invalid_17")
 }
 
+func Test_Invalid_Constant_18(t *testing.T) {
+	CheckInvalid(t, "constant_invalid_18")
+}
+
+func Test_Invalid_Constant_19(t *testing.T) {
+	CheckInvalid(t, "constant_invalid_19")
+}
+
+func Test_Invalid_Constant_20(t *testing.T) {
+	CheckInvalid(t, "constant_invalid_20")
+}
+
+func Test_Invalid_Constant_21(t *testing.T) {
+	CheckInvalid(t, "constant_invalid_21")
+}
+
+func Test_Invalid_Constant_22(t *testing.T) {
+	CheckInvalid(t, "constant_invalid_22")
+}
+
+func Test_Invalid_Constant_23(t *testing.T) {
+	CheckInvalid(t, "constant_invalid_23")
+}
+
 // ===================================================================
 // Alias Tests
 // ===================================================================
@@ -211,6 +235,190 @@ func Test_Invalid_Property_02(t *testing.T) {
 	CheckInvalid(t, "property_invalid_02")
 }
 
+// ===================================================================
+// Domain Tests
+// ===================================================================
+
+func Test_Invalid_Domain_01(t *testing.T) {
+	CheckInvalid(t, "domain_invalid_01")
+}
+
+func Test_Invalid_Domain_02(t *testing.T) {
+	CheckInvalid(t, "domain_invalid_02")
+}
+
+func Test_Invalid_Domain_03(t *testing.T) {
+	CheckInvalid(t, "domain_invalid_03")
+}
+
+func Test_Invalid_Domain_04(t *testing.T) {
+	CheckInvalid(t, "domain_invalid_04")
+}
+
+func Test_Invalid_Domain_05(t *testing.T) {
+	CheckInvalid(t, "domain_invalid_05")
+}
+
+func Test_Invalid_Domain_06(t *testing.T) {
+	CheckInvalid(t, "domain_invalid_06")
+}
+
+func Test_Invalid_Domain_07(t *testing.T) {
+	CheckInvalid(t, "domain_invalid_07")
+}
+
+// ===================================================================
+// Include Tests
+// ===================================================================
+
+func Test_Invalid_Include_01(t *testing.T) {
+	CheckInvalid(t, "include_invalid_01")
+}
+
+func Test_Invalid_Include_02(t *testing.T) {
+	CheckInvalid(t, "include_invalid_02")
+}
+
+func Test_Invalid_Include_03(t *testing.T) {
+	CheckInvalid(t, "include_invalid_03")
+}
+
+func Test_Invalid_Include_04(t *testing.T) {
+	CheckInvalid(t, "include_invalid_04")
+}
+
+func Test_Invalid_Include_05(t *testing.T) {
+	CheckInvalidIncluded(t, "include_invalid_05", "include_invalid_05_lib")
+}
+
+func Test_Invalid_Include_06(t *testing.T) {
+	CheckInvalidIncluded(t, "include_invalid_06", "include_invalid_06_lib")
+}
+
+func Test_Invalid_Include_07(t *testing.T) {
+	CheckInvalid(t, "include_invalid_07")
+}
+
+// ===================================================================
+// Macro Tests
+// ===================================================================
+
+func Test_Invalid_Macro_01(t *testing.T) {
+	CheckInvalid(t, "macro_invalid_01")
+}
+
+func Test_Invalid_Macro_02(t *testing.T) {
+	CheckInvalid(t, "macro_invalid_02")
+}
+
+func Test_Invalid_Macro_03(t *testing.T) {
+	CheckInvalid(t, "macro_invalid_03")
+}
+
+func Test_Invalid_Macro_04(t *testing.T) {
+	CheckInvalid(t, "macro_invalid_04")
+}
+
+func Test_Invalid_Macro_05(t *testing.T) {
+	CheckInvalid(t, "macro_invalid_05")
+}
+
+func Test_Invalid_Macro_06(t *testing.T) {
+	CheckInvalid(t, "macro_invalid_06")
+}
+
+func Test_Invalid_Macro_07(t *testing.T) {
+	CheckInvalid(t, "macro_invalid_07")
+}
+
+func Test_Invalid_Macro_08(t *testing.T) {
+	CheckInvalid(t, "macro_invalid_08")
+}
+
+func Test_Invalid_Macro_09(t *testing.T) {
+	CheckInvalid(t, "macro_invalid_09")
+}
+
+// ===================================================================
+// Module Template Tests
+// ===================================================================
+
+func Test_Invalid_Template_01(t *testing.T) {
+	CheckInvalid(t, "template_invalid_01")
+}
+
+func Test_Invalid_Template_02(t *testing.T) {
+	CheckInvalid(t, "template_invalid_02")
+}
+
+func Test_Invalid_Template_03(t *testing.T) {
+	CheckInvalid(t, "template_invalid_03")
+}
+
+func Test_Invalid_Template_04(t *testing.T) {
+	CheckInvalid(t, "template_invalid_04")
+}
+
+func Test_Invalid_Template_05(t *testing.T) {
+	CheckInvalid(t, "template_invalid_05")
+}
+
+func Test_Invalid_Template_06(t *testing.T) {
+	CheckInvalid(t, "template_invalid_06")
+}
+
+// ===================================================================
+// Enumeration Tests
+// ===================================================================
+
+func Test_Invalid_Enum_01(t *testing.T) {
+	CheckInvalid(t, "enum_invalid_01")
+}
+
+func Test_Invalid_Enum_02(t *testing.T) {
+	CheckInvalid(t, "enum_invalid_02")
+}
+
+func Test_Invalid_Enum_03(t *testing.T) {
+	CheckInvalid(t, "enum_invalid_03")
+}
+
+// ===================================================================
+// Signed Type Tests
+// ===================================================================
+
+func Test_Invalid_Signed_01(t *testing.T) {
+	CheckInvalid(t, "signed_invalid_01")
+}
+
+func Test_Invalid_Signed_02(t *testing.T) {
+	CheckInvalid(t, "signed_invalid_02")
+}
+
+func Test_Invalid_Signed_03(t *testing.T) {
+	CheckInvalid(t, "signed_invalid_03")
+}
+
+// ===================================================================
+// Constant Assertion Tests
+// ===================================================================
+
+func Test_Invalid_ConstAssert_01(t *testing.T) {
+	CheckInvalid(t, "constassert_invalid_01")
+}
+
+func Test_Invalid_ConstAssert_02(t *testing.T) {
+	CheckInvalid(t, "constassert_invalid_02")
+}
+
+func Test_Invalid_ConstAssert_03(t *testing.T) {
+	CheckInvalid(t, "constassert_invalid_03")
+}
+
+func Test_Invalid_ConstAssert_04(t *testing.T) {
+	CheckInvalid(t, "constassert_invalid_04")
+}
+
 // ===================================================================
 // Shift Tests
 // ===================================================================
@@ -405,6 +613,22 @@ func Test_Invalid_Lookup_09(t *testing.T) {
 	CheckInvalid(t, "lookup_invalid_09")
 }
 
+func Test_Invalid_Lookup_10(t *testing.T) {
+	CheckInvalid(t, "lookup_invalid_10")
+}
+
+func Test_Invalid_Lookup_11(t *testing.T) {
+	CheckInvalid(t, "lookup_invalid_11")
+}
+
+func Test_Invalid_Lookup_12(t *testing.T) {
+	CheckInvalid(t, "lookup_invalid_12")
+}
+
+func Test_Invalid_Lookup_13(t *testing.T) {
+	CheckInvalid(t, "lookup_invalid_13")
+}
+
 // ===================================================================
 // Interleavings
 // ===================================================================
@@ -702,14 +926,57 @@ func Test_Invalid_Compute_07(t *testing.T) {
 	CheckInvalid(t, "compute_invalid_07")
 }
 
+// ===================================================================
+// Expression Computed Columns
+// ===================================================================
+
+func Test_Invalid_ComputedColumn_01(t *testing.T) {
+	CheckInvalid(t, "computedcolumn_invalid_01")
+}
+
+func Test_Invalid_ComputedColumn_02(t *testing.T) {
+	CheckInvalid(t, "computedcolumn_invalid_02")
+}
+
+func Test_Invalid_ComputedColumn_03(t *testing.T) {
+	CheckInvalid(t, "computedcolumn_invalid_03")
+}
+
+func Test_Invalid_ComputedColumn_04(t *testing.T) {
+	CheckInvalid(t, "computedcolumn_invalid_04")
+}
+
+func Test_Invalid_ComputedColumn_05(t *testing.T) {
+	CheckInvalid(t, "computedcolumn_invalid_05")
+}
+
+func Test_Invalid_ComputedColumn_06(t *testing.T) {
+	CheckInvalid(t, "computedcolumn_invalid_06")
+}
+
+func Test_Invalid_ComputedColumn_07(t *testing.T) {
+	CheckInvalid(t, "computedcolumn_invalid_07")
+}
+
+func Test_Invalid_ComputedColumn_08(t *testing.T) {
+	CheckInvalid(t, "computedcolumn_invalid_08")
+}
+
 // ===================================================================
 // Test Helpers
 // ===================================================================
 
 // Check that a given source file fails to compiler.
-// nolint
 func CheckInvalid(t *testing.T, test string) {
+	CheckInvalidIncluded(t, test, test)
+}
+
+// CheckInvalidIncluded checks that a given source file fails to compile, where
+// the expected errors arise in a given (possibly included) file.
+// nolint
+func CheckInvalidIncluded(t *testing.T, test string, included string) {
 	filename := fmt.Sprintf("%s/%s.lisp", InvalidTestDir, test)
+	errFilename := fmt.Sprintf("%s/%s.lisp", InvalidTestDir, included)
 	// Enable testing each trace in parallel
 	t.Parallel()
 	// Read constraints file
@@ -718,12 +985,18 @@ func CheckInvalid(t *testing.T, test string) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	// Read file containing expected errors
+	errBytes, err := os.ReadFile(errFilename)
+	// Check file read ok
+	if err != nil {
+		t.Fatal(err)
+	}
 	// Package up as source file
 	srcfile := sexp.NewSourceFile(filename, bytes)
 	// Parse terms into an HIR schema
 	_, errs := corset.CompileSourceFile(false, false, srcfile)
 	// Extract expected errors for comparison
-	expectedErrs, lineOffsets := extractExpectedErrors(bytes)
+	expectedErrs, lineOffsets := extractExpectedErrors(errBytes)
 	// Check program did not compile!
 	if len(errs) == 0 {
 		t.Fatalf("Error %s should not have compiled\n", filename)
@@ -737,7 +1010,8 @@ func CheckInvalid(t *testing.T, test string) {
 				expected := expectedErrs[i]
 				actual := errs[i]
 				// Check whether message OK
-				if expected.msg == actual.Message() && expected.span == actual.Span() {
+				if expected.msg == actual.Message() && expected.span == actual.Span() &&
+					actual.SourceFile().Filename() == errFilename {
 					continue
 				}
 			}
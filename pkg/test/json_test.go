@@ -0,0 +1,102 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/consensys/go-corset/pkg/trace/json"
+)
+
+func Test_JsonReader_01(t *testing.T) {
+	// Check declaration order is preserved
+	JsonReaderCheck(t, `{"m2.Z": [1], "m1.A": [2], "B": [3], "m1.C": [4]}`, "m2.Z", "m1.A", "B", "m1.C")
+}
+
+func Test_JsonReader_02(t *testing.T) {
+	// Check bitwidths are inferred
+	cols, err := json.FromBytes([]byte(`{"A": [0, 1], "B": [1, 255], "C": [65536], "D": [18446744073709551616], "E": []}`))
+	//
+	if err != nil {
+		t.Fatal(err)
+	}
+	//
+	for i, width := range []uint{1, 8, 17, 65, 1} {
+		if cols[i].Data.BitWidth() != width {
+			t.Errorf("column %s has bitwidth %d, expected %d", cols[i].Name, cols[i].Data.BitWidth(), width)
+		}
+	}
+	//
+	val := cols[3].Data.Get(0)
+	if val.String() != "18446744073709551616" {
+		t.Errorf("column D has value %s", val.String())
+	}
+}
+
+func Test_JsonReader_03(t *testing.T) {
+	JsonReaderErrorCheck(t, `{"m.X": [1, 2], "m.Y": [3, "x"]}`, `"m.Y"[1]`)
+}
+
+func Test_JsonReader_04(t *testing.T) {
+	JsonReaderErrorCheck(t, `{"m.X": [1, 2.5]}`, `"m.X"[1]`)
+}
+
+func Test_JsonReader_05(t *testing.T) {
+	JsonReaderErrorCheck(t, `{"m.X": [1], "m.X": [2]}`, `duplicate column "m.X"`)
+}
+
+func Test_JsonReader_06(t *testing.T) {
+	JsonReaderErrorCheck(t, `{"m.X": 1}`, `"m.X"`)
+}
+
+func Test_JsonReader_07(t *testing.T) {
+	var values []string
+	// Check values are preserved as the array is grown and widened
+	for i := uint64(0); i < 1000; i++ {
+		values = append(values, fmt.Sprintf("%d", i*i*i))
+	}
+	//
+	cols, err := json.FromBytes([]byte(fmt.Sprintf(`{"A": [%s]}`, strings.Join(values, ", "))))
+	//
+	if err != nil {
+		t.Fatal(err)
+	} else if cols[0].Data.Len() != 1000 || cols[0].Data.BitWidth() != 30 {
+		t.Fatalf("column A has length %d and bitwidth %d", cols[0].Data.Len(), cols[0].Data.BitWidth())
+	}
+	//
+	for i, value := range values {
+		if val := cols[0].Data.Get(uint(i)); val.String() != value {
+			t.Errorf("column A has value %s on row %d, expected %s", val.String(), i, value)
+		}
+	}
+}
+
+// JsonReaderCheck checks that a given JSON trace is read with columns in the
+// expected order.
+func JsonReaderCheck(t *testing.T, input string, names ...string) {
+	cols, err := json.FromBytes([]byte(input))
+	//
+	if err != nil {
+		t.Fatal(err)
+	} else if len(cols) != len(names) {
+		t.Fatalf("expected %d columns, got %d", len(names), len(cols))
+	}
+	//
+	for i, name := range names {
+		if cols[i].QualifiedName() != name {
+			t.Errorf("column %d named %s, expected %s", i, cols[i].QualifiedName(), name)
+		}
+	}
+}
+
+// JsonReaderErrorCheck checks that reading a given (malformed) JSON trace
+// produces an error which contains the given text.
+func JsonReaderErrorCheck(t *testing.T, input string, expected string) {
+	_, err := json.FromBytes([]byte(input))
+	//
+	if err == nil {
+		t.Fatalf("malformed trace accepted")
+	} else if !strings.Contains(err.Error(), expected) {
+		t.Errorf("error \"%s\" does not contain \"%s\"", err.Error(), expected)
+	}
+}
@@ -0,0 +1,148 @@
+package test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/consensys/go-corset/pkg/corset"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+func Test_Lint_01(t *testing.T) {
+	// No warnings
+	LintCheck(t, `(defcolumns X Y)
+(defconst ONE 1)
+(defpurefun (double x) (* 2 x))
+(defconstraint c1 () (vanishes! (- Y (double X) ONE)))`)
+}
+
+func Test_Lint_02(t *testing.T) {
+	LintCheck(t, `(defcolumns X Y Z)
+(defconst ONE 1 TWO 2)
+(defpurefun (double x) (* 2 x))
+(defconstraint c1 () (vanishes! (- Y X ONE)))`,
+		"1:unused-column", "2:unused-constant", "3:unused-function")
+}
+
+func Test_Lint_03(t *testing.T) {
+	// Columns used only in computations are unconstrained
+	LintCheck(t, `(defcolumns X Y Z)
+(defcomputedcolumn (W :u8) (+ X 1))
+(defproperty p1 (vanishes! Y))
+(defconstraint c1 () (vanishes! (- W Z)))`,
+		"1:unconstrained-column", "1:unconstrained-column")
+}
+
+func Test_Lint_04(t *testing.T) {
+	// Columns used via functions are constrained
+	LintCheck(t, `(defcolumns X Y)
+(defun (f) (- X Y))
+(defun (g) (f))
+(defconstraint c1 () (vanishes! (g)))`)
+}
+
+func Test_Lint_05(t *testing.T) {
+	LintCheck(t, `(defcolumns X Y)
+(defconstraint c1 () (let ((x X)) (let ((x Y)) (vanishes! x))))
+(defconstraint c2 () (let ((Y X)) (vanishes! Y)))
+(defpurefun (f x) (let ((x 1)) x))
+(defconstraint c3 () (vanishes! (- X (f Y))))`,
+		"2:shadowed-binding", "3:shadowed-binding", "4:shadowed-binding")
+}
+
+func Test_Lint_06(t *testing.T) {
+	LintCheck(t, `(defcolumns X Y)
+(defconst ONE 1)
+(defconstraint c1 () (vanishes! (- ONE 1)))
+(defconstraint c2 () (if (vanishes! X) (vanishes! (- Y 1)) (vanishes! (- Y 1))))
+(defconstraint c3 () (if (vanishes! X) (vanishes! Y) (vanishes! (- Y 1))))`,
+		"3:trivial-constraint", "4:identical-branches")
+}
+
+func Test_Lint_07(t *testing.T) {
+	// Suppression comments
+	LintCheck(t, `(defcolumns
+  X ;; lint:ignore unused-column
+  ;; lint:ignore unconstrained-column
+  Y
+  ;; lint:ignore unused-constant
+  Z)
+(defconst ONE 1 ;; lint:ignore
+  TWO 2
+  ;; lint:ignore
+  THREE 3)
+(defcomputedcolumn (W :u8) (+ Y 1))
+(defconstraint c1 () (vanishes! W))`,
+		"6:unused-column", "8:unused-constant")
+}
+
+func Test_Lint_08(t *testing.T) {
+	// Warnings are reported in modules
+	LintCheck(t, `(defconst ONE 1)
+(module m1)
+(defcolumns X Y)
+(defconst TWO 2)
+(defconstraint c1 () (vanishes! (- X ONE)))`,
+		"3:unused-column", "4:unused-constant")
+}
+
+func Test_Lint_09(t *testing.T) {
+	// Let expressions passed to functions
+	LintCheck(t, `(defcolumns X Y)
+(defconstraint c1 () (vanishes! (let ((A 1)) (- Y A))))
+(defconstraint c2 () (vanishes! (- X (let ((A Y)) A))))`)
+}
+
+func Test_Lint_10(t *testing.T) {
+	// Enumerations (including those produced by macros)
+	LintCheck(t, `(defmacro (defbits name) `+"`"+`(defenum ,name (OFF 0) (ON 1)))
+(defenum COLOUR (RED 0) (GREEN 1))
+(defbits FLAG)
+(defcolumns X)
+(defconstraint c () (vanishes! X))
+(defconst UNUSED 2)`,
+		"6:unused-constant")
+}
+
+func Test_Lint_11(t *testing.T) {
+	// Module template parameters
+	LintCheck(t, `(defmodule-template checker (N M)
+  (defcolumns X)
+  (defconstraint c () (vanishes! (* X (- X N)))))
+(module m1 checker 1 2)`)
+}
+
+// ===================================================================
+// Test Helpers
+// ===================================================================
+
+// LintCheck checks that linting a given source file (without the standard
+// library) produces exactly the expected warnings, each of which is given in
+// the form "line:kind".
+func LintCheck(t *testing.T, source string, expected ...string) {
+	var (
+		srcfile = sexp.NewSourceFile("test.lisp", []byte(lintPrelude+source))
+		actual  []string
+	)
+	//
+	warnings, errs := corset.LintSourceFiles(false, []*sexp.SourceFile{srcfile})
+	//
+	for _, err := range errs {
+		t.Errorf("unexpected error: %s", err.Message())
+	}
+	//
+	for _, w := range warnings {
+		line := w.Error.FirstEnclosingLine()
+		// Adjust line to account for prelude
+		actual = append(actual, fmt.Sprintf("%d:%s", line.Number()-1, w.Kind))
+	}
+	//
+	if !slices.Equal(actual, expected) {
+		t.Errorf("expected warnings %s, got %s", strings.Join(expected, ","), strings.Join(actual, ","))
+	}
+}
+
+// Prelude used for all lint tests, since the standard library is not included.
+const lintPrelude = "(defpurefun ((vanishes! :@loob) x) x)\n"
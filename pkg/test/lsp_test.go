@@ -0,0 +1,360 @@
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/consensys/go-corset/pkg/corset/lsp"
+)
+
+const lspSource = `(defpurefun ((vanishes! :@loob) x) x)
+(defcolumns A)
+(module m1)
+(defcolumns X (Y :u8))
+(defconst TWO 2)
+(defconstraint c1 () (vanishes! (- Y (* TWO X))))
+`
+
+func Test_Lsp_Diagnostics_01(t *testing.T) {
+	session := newLspSession(t, "(defcolumns X)\n(defconstraint c1 () (vanishes! Y))")
+	session.notify("textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": session.uri},
+		"contentChanges": []any{map[string]any{"text": lspSource}},
+	})
+	//
+	msgs := session.run()
+	diags := lspDiagnostics(t, msgs)
+	// Errors reported on opening
+	if len(diags) != 2 || len(diags[0]) != 2 {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+	//
+	lspCheckRange(t, diags[0][0].Range, 1, 32, 1, 33)
+	lspCheckRange(t, diags[0][1].Range, 1, 22, 1, 31)
+	// Errors cleared on change
+	if len(diags[1]) != 0 {
+		t.Fatalf("unexpected diagnostics %v", diags[1])
+	}
+}
+
+func Test_Lsp_Diagnostics_02(t *testing.T) {
+	session := newLspSession(t, "(defcolumns X)\n(defconstraint c1 () (vanishes! X)")
+	msgs := session.run()
+	diags := lspDiagnostics(t, msgs)
+	//
+	if len(diags) != 1 || len(diags[0]) != 1 {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+}
+
+func Test_Lsp_Diagnostics_03(t *testing.T) {
+	// Malformed module declarations are reported, rather than crashing the server
+	session := newLspSession(t, "(module (m1))\n(defcolumns X)")
+	session.request("textDocument/hover", session.position(1, 12))
+	msgs := session.run()
+	diags := lspDiagnostics(t, msgs)
+	//
+	if len(diags) != 1 || len(diags[0]) != 1 {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+	//
+	lspCheckRange(t, diags[0][0].Range, 0, 0, 0, 13)
+}
+
+func Test_Lsp_Definition_01(t *testing.T) {
+	session := newLspSession(t, lspSource)
+	session.request("textDocument/definition", session.position(5, 44))
+	session.request("textDocument/definition", session.position(5, 35))
+	session.request("textDocument/definition", session.position(5, 40))
+	session.request("textDocument/definition", session.position(5, 24))
+	session.request("textDocument/definition", session.position(5, 2))
+	//
+	results := lspResults(t, session.run())
+	// Check columns and constants
+	lspCheckLocation(t, session, results[0], 3, 12, 3, 13)
+	lspCheckLocation(t, session, results[1], 3, 14, 3, 21)
+	lspCheckLocation(t, session, results[2], 4, 14, 4, 15)
+	// Check functions
+	lspCheckLocation(t, session, results[3], 0, 14, 0, 23)
+	// Check nothing
+	if string(results[4]) != "null" {
+		t.Errorf("unexpected definition %s", results[4])
+	}
+}
+
+func Test_Lsp_Hover_01(t *testing.T) {
+	session := newLspSession(t, lspSource)
+	session.request("textDocument/hover", session.position(5, 35))
+	session.request("textDocument/hover", session.position(5, 40))
+	session.request("textDocument/hover", session.position(3, 12))
+	session.request("textDocument/hover", session.position(5, 24))
+	//
+	results := lspResults(t, session.run())
+	//
+	lspCheckHover(t, results[0], "column `m1.Y` `:u8`")
+	lspCheckHover(t, results[1], "constant `m1.TWO` = 2")
+	lspCheckHover(t, results[2], "column `m1.X` `:𝔽`")
+	lspCheckHover(t, results[3], "function `(defpurefun ((vanishes! :𝔽@loob) (x :𝔽)))`")
+}
+
+func Test_Lsp_Completion_01(t *testing.T) {
+	session := newLspSession(t, lspSource)
+	session.request("textDocument/completion", session.position(5, 44))
+	session.request("textDocument/completion", session.position(1, 12))
+	// Completion should continue working with a malformed document
+	session.notify("textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": session.uri},
+		"contentChanges": []any{map[string]any{"text": lspSource + "(defconstraint c2 () (vanishes! "}},
+	})
+	session.request("textDocument/completion", session.position(6, 32))
+	//
+	results := lspResults(t, session.run())
+	//
+	lspCheckCompletion(t, results[0], "TWO", "X", "Y", "vanishes!")
+	lspCheckCompletion(t, results[1], "A", "vanishes!")
+	lspCheckCompletion(t, results[2], "TWO", "X", "Y", "vanishes!")
+}
+
+func Test_Lsp_Completion_02(t *testing.T) {
+	// Module declarations within comments are ignored
+	session := newLspSession(t, lspSource+";; (module m2)\n")
+	session.request("textDocument/completion", session.position(7, 0))
+	//
+	results := lspResults(t, session.run())
+	//
+	lspCheckCompletion(t, results[0], "TWO", "X", "Y", "vanishes!")
+}
+
+func Test_Lsp_Exit_01(t *testing.T) {
+	var (
+		session = lspSession{t: t}
+		output  bytes.Buffer
+	)
+	// Exit without shutdown is an error
+	session.request("initialize", map[string]any{})
+	session.notify("exit", nil)
+	//
+	if err := lsp.NewServer(false, &session.input, &output).Run(); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+// ===================================================================
+// Test Helpers
+// ===================================================================
+
+// lspSession records a sequence of messages to be sent to the server.
+type lspSession struct {
+	t     *testing.T
+	uri   string
+	input bytes.Buffer
+	id    int
+}
+
+// lspMessage represents a message (i.e. response or notification) received
+// from the server.
+type lspMessage struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code int `json:"code"`
+	} `json:"error"`
+}
+
+type lspRange struct {
+	Start struct{ Line, Character int }
+	End   struct{ Line, Character int }
+}
+
+type lspDiagnostic struct {
+	Range   lspRange
+	Message string
+}
+
+// Construct a session which initialises the server and opens a document with
+// the given contents.
+func newLspSession(t *testing.T, contents string) *lspSession {
+	filename := filepath.Join(t.TempDir(), "test.lisp")
+	session := &lspSession{t: t, uri: "file://" + filepath.ToSlash(filename)}
+	//
+	session.request("initialize", map[string]any{})
+	session.notify("initialized", map[string]any{})
+	session.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": session.uri, "languageId": "corset", "version": 1, "text": contents},
+	})
+	//
+	return session
+}
+
+func (p *lspSession) request(method string, params any) {
+	p.id++
+	p.write(map[string]any{"jsonrpc": "2.0", "id": p.id, "method": method, "params": params})
+}
+
+func (p *lspSession) notify(method string, params any) {
+	p.write(map[string]any{"jsonrpc": "2.0", "method": method, "params": params})
+}
+
+func (p *lspSession) write(msg any) {
+	bytes, err := json.Marshal(msg)
+	//
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	//
+	fmt.Fprintf(&p.input, "Content-Length: %d\r\n\r\n%s", len(bytes), bytes)
+}
+
+func (p *lspSession) position(line int, character int) map[string]any {
+	return map[string]any{
+		"textDocument": map[string]any{"uri": p.uri},
+		"position":     map[string]any{"line": line, "character": character},
+	}
+}
+
+// Shutdown the server and run it over all messages in this session, returning
+// the messages received in response.
+func (p *lspSession) run() []lspMessage {
+	var (
+		output bytes.Buffer
+		msgs   []lspMessage
+	)
+	//
+	p.request("shutdown", nil)
+	p.notify("exit", nil)
+	//
+	if err := lsp.NewServer(false, &p.input, &output).Run(); err != nil {
+		p.t.Fatal(err)
+	}
+	// Parse responses
+	reader := bufio.NewReader(&output)
+	//
+	for {
+		var msg lspMessage
+		//
+		header, err := textproto.NewReader(reader).ReadMIMEHeader()
+		if err == io.EOF {
+			return msgs
+		} else if err != nil {
+			p.t.Fatal(err)
+		}
+		//
+		length, _ := strconv.Atoi(header.Get("Content-Length"))
+		content := make([]byte, length)
+		//
+		if _, err := io.ReadFull(reader, content); err != nil {
+			p.t.Fatal(err)
+		} else if err := json.Unmarshal(content, &msg); err != nil {
+			p.t.Fatal(err)
+		} else if msg.Error != nil {
+			p.t.Fatalf("unexpected error response (%d)", msg.Error.Code)
+		}
+		//
+		msgs = append(msgs, msg)
+	}
+}
+
+// Extract the results of all requests, excluding those for initialisation and
+// shutdown.
+func lspResults(t *testing.T, msgs []lspMessage) []json.RawMessage {
+	var results []json.RawMessage
+	//
+	for _, msg := range msgs {
+		if msg.ID != nil {
+			results = append(results, msg.Result)
+		}
+	}
+	//
+	if len(results) < 2 {
+		t.Fatalf("missing responses")
+	}
+	//
+	return results[1 : len(results)-1]
+}
+
+// Extract all diagnostics published for the test document.
+func lspDiagnostics(t *testing.T, msgs []lspMessage) [][]lspDiagnostic {
+	var diags [][]lspDiagnostic
+	//
+	for _, msg := range msgs {
+		if msg.Method == "textDocument/publishDiagnostics" {
+			var params struct{ Diagnostics []lspDiagnostic }
+			//
+			if err := json.Unmarshal(msg.Params, &params); err != nil {
+				t.Fatal(err)
+			}
+			//
+			diags = append(diags, params.Diagnostics)
+		}
+	}
+	//
+	return diags
+}
+
+func lspCheckRange(t *testing.T, r lspRange, startLine, startChar, endLine, endChar int) {
+	if r.Start.Line != startLine || r.Start.Character != startChar || r.End.Line != endLine ||
+		r.End.Character != endChar {
+		t.Errorf("expected range %d:%d-%d:%d, got %d:%d-%d:%d", startLine, startChar, endLine, endChar,
+			r.Start.Line, r.Start.Character, r.End.Line, r.End.Character)
+	}
+}
+
+func lspCheckLocation(t *testing.T, session *lspSession, result json.RawMessage, startLine, startChar, endLine,
+	endChar int) {
+	var locations []struct {
+		URI   string
+		Range lspRange
+	}
+	//
+	if err := json.Unmarshal(result, &locations); err != nil {
+		t.Fatal(err)
+	} else if len(locations) != 1 {
+		t.Fatalf("expected one location, got %s", result)
+	} else if locations[0].URI != session.uri {
+		t.Errorf("unexpected uri %s", locations[0].URI)
+	}
+	//
+	lspCheckRange(t, locations[0].Range, startLine, startChar, endLine, endChar)
+}
+
+func lspCheckHover(t *testing.T, result json.RawMessage, expected string) {
+	var hover struct{ Contents struct{ Value string } }
+	//
+	if err := json.Unmarshal(result, &hover); err != nil {
+		t.Fatal(err)
+	} else if hover.Contents.Value != expected {
+		t.Errorf("expected hover \"%s\", got \"%s\"", expected, hover.Contents.Value)
+	}
+}
+
+func lspCheckCompletion(t *testing.T, result json.RawMessage, expected ...string) {
+	var (
+		items  []struct{ Label string }
+		labels []string
+	)
+	//
+	if err := json.Unmarshal(result, &items); err != nil {
+		t.Fatal(err)
+	}
+	//
+	for _, item := range items {
+		labels = append(labels, item.Label)
+	}
+	//
+	slices.Sort(labels)
+	//
+	if !slices.Equal(labels, expected) {
+		t.Errorf("expected completions %s, got %s", strings.Join(expected, ","), strings.Join(labels, ","))
+	}
+}
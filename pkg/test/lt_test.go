@@ -0,0 +1,210 @@
+package test
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/go-corset/pkg/trace"
+	"github.com/consensys/go-corset/pkg/trace/lt"
+	"github.com/consensys/go-corset/pkg/util"
+)
+
+func Test_LtReader_01(t *testing.T) {
+	LtReaderCheck(t, v2Writer, nil, "A", "B")
+}
+
+func Test_LtReader_02(t *testing.T) {
+	LtReaderCheck(t, v2Writer, nil, "m1.A", "m1.B", "m2.C")
+}
+
+func Test_LtReader_03(t *testing.T) {
+	LtReaderCheck(t, v2Writer, func(mod string, col string) bool { return mod == "m1" }, "m1.A", "m2.C", "m1.B")
+}
+
+func Test_LtReader_04(t *testing.T) {
+	LtReaderCheck(t, v2Writer, func(mod string, col string) bool { return col == "D" }, "m1.A", "m2.C", "m1.B")
+}
+
+func Test_LtReader_05(t *testing.T) {
+	LtReaderCheck(t, lt.WriteBytesV1, nil, "A", "B")
+}
+
+func Test_LtReader_06(t *testing.T) {
+	LtReaderCheck(t, lt.WriteBytesV1, nil, "m1.A", "m1.B", "m2.C", "m2.D", "m3.E")
+}
+
+func Test_LtReader_07(t *testing.T) {
+	LtReaderCheck(t, compressedWriter, nil, "A", "B")
+}
+
+func Test_LtReader_08(t *testing.T) {
+	LtReaderCheck(t, compressedWriter, nil, "m1.A", "m1.B", "m2.C", "m2.D", "m3.E")
+}
+
+func Test_LtReader_09(t *testing.T) {
+	// Check corrupted column data is detected
+	data, err := ltV2Bytes(ltTestColumns("m1.A", "m2.B"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Corrupt last byte of column data
+	data[len(data)-1] ^= 0xff
+	//
+	if _, err := lt.FromBytes(data); err == nil {
+		t.Errorf("corrupted lt file accepted")
+	}
+}
+
+func Test_LtReader_11(t *testing.T) {
+	// Check corrupted column data is reported when read lazily
+	data, err := ltV2Bytes(ltTestColumns("m1.A", "m2.B"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Corrupt last byte of column data
+	data[len(data)-1] ^= 0xff
+	//
+	lazy, err := lt.FromReaderAt(bytes.NewReader(data), int64(len(data)), nil)
+	if err != nil {
+		t.Fatal(err)
+	} else if err = trace.DecodeColumns(lazy); err == nil {
+		t.Errorf("corrupted lt file accepted")
+	}
+}
+
+func Test_LtReader_12(t *testing.T) {
+	// Check the original (v1) format is written by default
+	LtReaderCheck(t, lt.WriteBytes, nil, "m1.A", "m2.B")
+	//
+	data, err := lt.ToBytes(ltTestColumns("m1.A", "m2.B"))
+	if err != nil {
+		t.Fatal(err)
+	} else if bytes.HasPrefix(data, lt.LT_MAGIC[:]) {
+		t.Errorf("versioned lt file written by default")
+	}
+}
+
+// LtReaderCheck checks that reading an lt file (both eagerly and lazily)
+// produces the columns originally written (modulo the given filter).
+func LtReaderCheck(t *testing.T, writer func([]trace.RawColumn, io.Writer) error, filter lt.ColumnFilter,
+	names ...string) {
+	var buf bytes.Buffer
+	//
+	columns := ltTestColumns(names...)
+	// Write columns
+	if err := writer(columns, &buf); err != nil {
+		t.Fatal(err)
+	}
+	//
+	data := buf.Bytes()
+	// Read columns eagerly
+	eager, err := lt.FromBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Read columns lazily
+	lazy, err := lt.FromReaderAt(bytes.NewReader(data), int64(len(data)), filter)
+	if err != nil {
+		t.Fatal(err)
+	} else if err = trace.DecodeColumns(lazy); err != nil {
+		t.Fatal(err)
+	}
+	// Eager reader ignores filter
+	ltColumnsCheck(t, columns, eager)
+	// Apply filter to original columns
+	if filter != nil {
+		columns = util.RemoveMatching(columns, func(c trace.RawColumn) bool { return !filter(c.Module, c.Name) })
+	}
+	//
+	ltColumnsCheck(t, columns, lazy)
+}
+
+// Construct some columns of different widths
+func ltTestColumns(names ...string) []trace.RawColumn {
+	columns := make([]trace.RawColumn, len(names))
+	//
+	for i, name := range names {
+		ints := make([]*big.Int, 3+i)
+		//
+		for j := range ints {
+			ints[j] = big.NewInt(int64(j+1) << (8 * i))
+		}
+		//
+		mod, col := "", name
+		//
+		if k := strings.IndexByte(name, '.'); k >= 0 {
+			mod, col = name[:k], name[k+1:]
+		}
+		//
+		columns[i] = trace.RawColumn{Module: mod, Name: col, Data: util.FrArrayFromBigInts(8*uint(i+1), ints)}
+	}
+	//
+	return columns
+}
+
+func v2Writer(columns []trace.RawColumn, buf io.Writer) error {
+	return lt.WriteBytesV2(columns, false, buf)
+}
+
+func ltV2Bytes(columns []trace.RawColumn) ([]byte, error) {
+	var buf bytes.Buffer
+	//
+	err := lt.WriteBytesV2(columns, false, &buf)
+	//
+	return buf.Bytes(), err
+}
+
+func compressedWriter(columns []trace.RawColumn, buf io.Writer) error {
+	return lt.WriteBytesV2(columns, true, buf)
+}
+
+func ltColumnsCheck(t *testing.T, expected []trace.RawColumn, actual []trace.RawColumn) {
+	if len(expected) != len(actual) {
+		t.Fatalf("expected %d columns, got %d", len(expected), len(actual))
+	}
+	//
+	for i := range expected {
+		if expected[i].QualifiedName() != actual[i].QualifiedName() {
+			t.Errorf("column %d named %s, expected %s", i, actual[i].QualifiedName(), expected[i].QualifiedName())
+		} else if expected[i].Data.Len() != actual[i].Data.Len() || expected[i].Data.BitWidth() != actual[i].Data.BitWidth() {
+			t.Errorf("column %s has incorrect dimensions", expected[i].QualifiedName())
+		}
+		//
+		for j := uint(0); j < expected[i].Data.Len(); j++ {
+			ej, aj := expected[i].Data.Get(j), actual[i].Data.Get(j)
+			if ej.Cmp(&aj) != 0 {
+				t.Errorf("column %s differs on row %d", expected[i].QualifiedName(), j)
+			}
+		}
+	}
+}
+
+func Test_LtReader_10(t *testing.T) {
+	// Check compressed lt files are decompressed
+	data, err := lt.ToBytes(ltTestColumns("m1.A", "m2.B"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	//
+	compressed, err := util.Compress(data, util.COMPRESSION_GZIP)
+	if err != nil {
+		t.Fatal(err)
+	} else if util.DetectCompression(compressed) != util.COMPRESSION_GZIP {
+		t.Fatalf("gzip compression not detected")
+	}
+	//
+	decompressor, err := util.DecompressReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	//
+	decompressed, err := io.ReadAll(decompressor)
+	if err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(data, decompressed) {
+		t.Errorf("decompressed lt file differs from original")
+	}
+}
@@ -0,0 +1,105 @@
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/consensys/go-corset/pkg/corset"
+	"github.com/consensys/go-corset/pkg/report"
+	sc "github.com/consensys/go-corset/pkg/schema"
+	"github.com/consensys/go-corset/pkg/trace/json"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+const REPORT_01_ACCEPTING = `{ "X": [1,2,3], "Y": [2,4,6], "m1.A": [1,3], "m1.B": [1,3] }`
+const REPORT_01_REJECTING = `{ "X": [1,2,17], "Y": [3,5,34], "m1.A": [1,4], "m1.B": [1,3] }`
+
+func Test_Report_01(t *testing.T) {
+	ReportCheck(t, "report_01", "accepting", REPORT_01_ACCEPTING, report.FORMAT_JSON)
+}
+
+func Test_Report_02(t *testing.T) {
+	ReportCheck(t, "report_01", "rejecting", REPORT_01_REJECTING, report.FORMAT_JSON)
+}
+
+func Test_Report_03(t *testing.T) {
+	ReportCheck(t, "report_01", "accepting", REPORT_01_ACCEPTING, report.FORMAT_JUNIT)
+}
+
+func Test_Report_04(t *testing.T) {
+	ReportCheck(t, "report_01", "rejecting", REPORT_01_REJECTING, report.FORMAT_JUNIT)
+}
+
+// ReportCheck checks a given trace against a given constraints file, and then
+// compares the resulting report (in the given format) against the expected
+// output held in the corresponding golden file.
+func ReportCheck(t *testing.T, test string, outcome string, trace string, format string) {
+	var (
+		buf      bytes.Buffer
+		filename = fmt.Sprintf("%s/%s.lisp", TestDir, test)
+		ext      = map[string]string{report.FORMAT_JSON: "json", report.FORMAT_JUNIT: "xml"}[format]
+		golden   = fmt.Sprintf("%s/%s.%s.%s", TestDir, test, outcome, ext)
+	)
+	// Read constraints file
+	source, err := os.ReadFile(filename)
+	//
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Compile constraints
+	schema, errs := corset.CompileSourceFile(false, false, sexp.NewSourceFile(filename, source))
+	//
+	if len(errs) > 0 {
+		t.Fatalf("Error parsing %s: %v\n", filename, errs)
+	}
+	// Check trace
+	output := reportCheck(t, schema, trace)
+	//
+	if err := output.Write(&buf, format); err != nil {
+		t.Fatal(err)
+	}
+	// Compare against golden file
+	expected, err := os.ReadFile(golden)
+	//
+	if err != nil {
+		t.Fatal(err)
+	} else if buf.String() != string(expected) {
+		t.Errorf("incorrect report for %s, expected:\n%s\nbut got:\n%s", golden, string(expected), buf.String())
+	}
+}
+
+// Check a trace against a given schema, recording the outcome in a report in
+// the same fashion as the check command.
+func reportCheck(t *testing.T, schema sc.Schema, trace string) *report.Report {
+	output := report.NewReport()
+	output.AddLevel("HIR", schema)
+	//
+	columns, err := json.FromBytes([]byte(trace))
+	//
+	if err != nil {
+		t.Fatal(err)
+	}
+	//
+	tr, errs := sc.NewTraceBuilder(schema).Padding(1).Build(columns)
+	output.AddErrors(true, "HIR", errs)
+	//
+	if tr == nil {
+		return output
+	}
+	// Check constraints
+	failures := sc.Accepts(100, 100, schema, tr)
+	output.AddChecked("HIR", schema.Constraints())
+	//
+	if len(failures) > 0 {
+		output.AddFailures("HIR", failures, tr, schema)
+		return output
+	}
+	// Check assertions
+	failures = sc.Asserts(100, 100, schema, tr)
+	output.AddChecked("HIR", schema.Assertions())
+	output.AddFailures("HIR", failures, tr, schema)
+	//
+	return output
+}
@@ -0,0 +1,215 @@
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/go-corset/pkg/corset"
+	sc "github.com/consensys/go-corset/pkg/schema"
+	"github.com/consensys/go-corset/pkg/trace"
+	"github.com/consensys/go-corset/pkg/trace/json"
+	"github.com/consensys/go-corset/pkg/trace/lt"
+	"github.com/consensys/go-corset/pkg/tracefile"
+	"github.com/consensys/go-corset/pkg/util"
+	"github.com/consensys/go-corset/pkg/util/sexp"
+)
+
+func Test_TraceFile_01(t *testing.T) {
+	columns := ltTestColumns("m1.A", "m1.B", "m2.C")
+	data, err := lt.ToBytes(columns)
+	//
+	if err != nil {
+		t.Fatal(err)
+	}
+	//
+	traceFileCheck(t, "trace.lt.gz", data, util.COMPRESSION_GZIP, nil, columns)
+}
+
+func Test_TraceFile_02(t *testing.T) {
+	columns := ltTestColumns("m1.A", "m1.B", "m2.C")
+	data := []byte(json.ToJsonString(columns))
+	//
+	traceFileCheck(t, "trace.json.gz", data, util.COMPRESSION_GZIP, nil, columns)
+}
+
+func Test_TraceFile_03(t *testing.T) {
+	columns := ltTestColumns("m1.A", "m1.B", "m2.C")
+	data := []byte(json.ToJsonString(columns))
+	filter := func(mod string, col string) bool { return mod == "m2" }
+	// Compression should be detected from magic bytes alone
+	traceFileCheck(t, "trace.json", data, util.COMPRESSION_GZIP, filter, columns[2:])
+}
+
+func Test_TraceFile_04(t *testing.T) {
+	traceFileFixtureCheck(t, "trace_01.lt.zst")
+}
+
+func Test_TraceFile_05(t *testing.T) {
+	traceFileFixtureCheck(t, "trace_01.json.zst")
+}
+
+func Test_TraceFile_06(t *testing.T) {
+	// Check corrupted zstd data is reported
+	data, err := os.ReadFile(fmt.Sprintf("%s/trace_01.json.zst", TestDir))
+	//
+	if err != nil {
+		t.Fatal(err)
+	}
+	//
+	for _, corrupted := range [][]byte{data[:len(data)/2], append(data[:len(data)-1:len(data)-1], ^data[len(data)-1])} {
+		filename := filepath.Join(t.TempDir(), "trace.json.zst")
+		//
+		if err := os.WriteFile(filename, corrupted, 0644); err != nil {
+			t.Fatal(err)
+		} else if _, err := tracefile.Read(filename, nil); err == nil {
+			t.Errorf("corrupted zstd trace accepted")
+		}
+	}
+}
+
+func Test_TraceFile_07(t *testing.T) {
+	// Check uncompressed lt files are read on demand
+	columns := ltTestColumns("m1.A", "m2.B")
+	data, err := ltV2Bytes(columns)
+	//
+	if err != nil {
+		t.Fatal(err)
+	}
+	//
+	filename := filepath.Join(t.TempDir(), "trace.lt")
+	//
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	//
+	lazy, closer, err := tracefile.Open(filename, nil)
+	//
+	if err != nil {
+		t.Fatal(err)
+	}
+	//
+	for _, col := range lazy {
+		if _, ok := col.Data.(*lt.LazyArray); !ok {
+			t.Errorf("column %s read eagerly", col.QualifiedName())
+		}
+	}
+	//
+	traceValuesCheck(t, columns, lazy)
+	//
+	if err := closer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_TraceFile_08(t *testing.T) {
+	// Check corrupted column data is reported when the trace is built
+	data, err := ltV2Bytes(ltTestColumns("m1.A", "m2.B"))
+	//
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Corrupt last byte of column data
+	data[len(data)-1] ^= 0xff
+	filename := filepath.Join(t.TempDir(), "trace.lt")
+	//
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	//
+	lazy, closer, err := tracefile.Open(filename, nil)
+	//
+	if err != nil {
+		t.Fatal(err)
+	}
+	//
+	defer closer.Close()
+	//
+	srcfile := sexp.NewSourceFile("test.lisp", []byte("(module m1)\n(defcolumns A)\n(module m2)\n(defcolumns B)"))
+	schema, errs := corset.CompileSourceFile(false, false, srcfile)
+	//
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	//
+	if tr, errs := sc.NewTraceBuilder(schema).Build(lazy); tr != nil || len(errs) == 0 {
+		t.Errorf("corrupted lt trace accepted")
+	}
+}
+
+func Test_TraceFile_09(t *testing.T) {
+	// Check zstd frames requiring an excessive window are rejected.  This frame
+	// declares a window of 2^28 bytes, followed by an empty (raw) last block.
+	frame := []byte{0x28, 0xb5, 0x2f, 0xfd, 0x00, 0x90, 0x01, 0x00, 0x00}
+	//
+	decompressor, err := util.DecompressReader(bytes.NewReader(frame))
+	//
+	if err != nil {
+		t.Fatal(err)
+	} else if _, err := io.ReadAll(decompressor); err == nil {
+		t.Errorf("zstd frame with excessive window accepted")
+	}
+}
+
+// ===================================================================
+// Test Helpers
+// ===================================================================
+
+// Write a trace file after compressing its contents, and then check it is read
+// back correctly.
+func traceFileCheck(t *testing.T, name string, data []byte, compression util.Compression, filter lt.ColumnFilter,
+	expected []trace.RawColumn) {
+	filename := filepath.Join(t.TempDir(), name)
+	compressed, err := util.Compress(data, compression)
+	//
+	if err != nil {
+		t.Fatal(err)
+	} else if err := os.WriteFile(filename, compressed, 0644); err != nil {
+		t.Fatal(err)
+	}
+	//
+	columns, err := tracefile.Read(filename, filter)
+	//
+	if err != nil {
+		t.Fatal(err)
+	}
+	//
+	traceValuesCheck(t, expected, columns)
+}
+
+// Check a (zstd compressed) trace file from the test directory is read
+// correctly.
+func traceFileFixtureCheck(t *testing.T, name string) {
+	columns, err := tracefile.Read(fmt.Sprintf("%s/%s", TestDir, name), nil)
+	//
+	if err != nil {
+		t.Fatal(err)
+	}
+	//
+	traceValuesCheck(t, ltTestColumns("m1.A", "m1.B", "m2.C"), columns)
+}
+
+// Check two sets of columns hold the same values.  Unlike ltColumnsCheck, this
+// ignores bitwidths since these are not preserved by all formats.
+func traceValuesCheck(t *testing.T, expected []trace.RawColumn, actual []trace.RawColumn) {
+	if len(expected) != len(actual) {
+		t.Fatalf("expected %d columns, got %d", len(expected), len(actual))
+	}
+	//
+	for i := range expected {
+		if expected[i].QualifiedName() != actual[i].QualifiedName() {
+			t.Errorf("column %d named %s, expected %s", i, actual[i].QualifiedName(), expected[i].QualifiedName())
+		} else if expected[i].Data.Len() != actual[i].Data.Len() {
+			t.Errorf("column %s has incorrect length", expected[i].QualifiedName())
+		} else {
+			for j := uint(0); j < expected[i].Data.Len(); j++ {
+				if ej, aj := expected[i].Data.Get(j), actual[i].Data.Get(j); ej.Cmp(&aj) != 0 {
+					t.Errorf("column %s differs on row %d", expected[i].QualifiedName(), j)
+				}
+			}
+		}
+	}
+}
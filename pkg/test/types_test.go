@@ -0,0 +1,43 @@
+package test
+
+import (
+	"testing"
+
+	sc "github.com/consensys/go-corset/pkg/schema"
+)
+
+func Test_Types_01(t *testing.T) {
+	TypesCmpCheck(t, sc.NewUintType(8), sc.NewUintType(16), -1)
+}
+
+func Test_Types_02(t *testing.T) {
+	TypesCmpCheck(t, sc.NewIntType(8), sc.NewIntType(16), -1)
+}
+
+func Test_Types_03(t *testing.T) {
+	TypesCmpCheck(t, sc.NewUintType(8), sc.NewIntType(9), -1)
+}
+
+func Test_Types_04(t *testing.T) {
+	TypesCmpCheck(t, sc.NewIntType(8), sc.NewUintType(8), 0)
+}
+
+func Test_Types_05(t *testing.T) {
+	TypesCmpCheck(t, sc.NewIntType(8), sc.NewUintType(16), 0)
+}
+
+func Test_Types_06(t *testing.T) {
+	TypesCmpCheck(t, sc.NewIntType(16), &sc.FieldType{}, -1)
+}
+
+// TypesCmpCheck checks that comparing two types gives the expected outcome, and
+// that comparing them in the opposite direction gives the opposite outcome.
+func TypesCmpCheck(t *testing.T, lhs sc.Type, rhs sc.Type, expected int) {
+	if c := lhs.Cmp(rhs); c != expected {
+		t.Errorf("%s.Cmp(%s) = %d, expected %d", lhs, rhs, c, expected)
+	}
+	//
+	if c := rhs.Cmp(lhs); c != -expected {
+		t.Errorf("%s.Cmp(%s) = %d, expected %d", rhs, lhs, c, -expected)
+	}
+}
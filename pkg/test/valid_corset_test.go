@@ -117,6 +117,10 @@ func Test_Constant_11(t *testing.T) {
 	Check(t, false, "constant_11")
 }
 
+func Test_Constant_12(t *testing.T) {
+	Check(t, false, "constant_12")
+}
+
 // ===================================================================
 // Alias Tests
 // ===================================================================
@@ -178,6 +182,18 @@ func Test_Domain_03(t *testing.T) {
 	Check(t, false, "domain_03")
 }
 
+func Test_Domain_04(t *testing.T) {
+	Check(t, false, "domain_04")
+}
+
+func Test_Domain_05(t *testing.T) {
+	Check(t, false, "domain_05")
+}
+
+func Test_Domain_06(t *testing.T) {
+	Check(t, false, "domain_06")
+}
+
 // ===================================================================
 // Block Tests
 // ===================================================================
@@ -433,6 +449,10 @@ func Test_Type_10(t *testing.T) {
 	Check(t, false, "type_10")
 }
 
+func Test_Type_11(t *testing.T) {
+	Check(t, false, "type_11")
+}
+
 // ===================================================================
 // Range Constraints
 // ===================================================================
@@ -621,6 +641,18 @@ func Test_Lookup_12(t *testing.T) {
 	Check(t, false, "lookup_12")
 }
 
+func Test_Lookup_13(t *testing.T) {
+	Check(t, false, "lookup_13")
+}
+
+func Test_Lookup_14(t *testing.T) {
+	Check(t, false, "lookup_14")
+}
+
+func Test_Lookup_15(t *testing.T) {
+	Check(t, false, "lookup_15")
+}
+
 // ===================================================================
 // Interleaving
 // ===================================================================
@@ -1001,6 +1033,134 @@ func Test_Compute_02(t *testing.T) {
 	Check(t, false, "compute_02")
 }
 
+// ===================================================================
+// Expression Computed Columns
+// ===================================================================
+
+func Test_ComputedColumn_01(t *testing.T) {
+	Check(t, false, "computedcolumn_01")
+}
+
+func Test_ComputedColumn_02(t *testing.T) {
+	Check(t, false, "computedcolumn_02")
+}
+
+func Test_ComputedColumn_03(t *testing.T) {
+	Check(t, false, "computedcolumn_03")
+}
+
+func Test_ComputedColumn_04(t *testing.T) {
+	Check(t, false, "computedcolumn_04")
+}
+
+func Test_ComputedColumn_05(t *testing.T) {
+	Check(t, false, "computedcolumn_05")
+}
+
+// ===================================================================
+// Includes
+// ===================================================================
+
+func Test_Include_01(t *testing.T) {
+	Check(t, false, "include_01")
+}
+
+func Test_Include_02(t *testing.T) {
+	Check(t, false, "include_02")
+}
+
+func Test_Include_03(t *testing.T) {
+	Check(t, false, "include_03")
+}
+
+// ===================================================================
+// Macros
+// ===================================================================
+
+func Test_Macro_01(t *testing.T) {
+	Check(t, false, "macro_01")
+}
+
+func Test_Macro_02(t *testing.T) {
+	Check(t, false, "macro_02")
+}
+
+func Test_Macro_03(t *testing.T) {
+	Check(t, false, "macro_03")
+}
+
+func Test_Macro_04(t *testing.T) {
+	Check(t, false, "macro_04")
+}
+
+func Test_Macro_05(t *testing.T) {
+	Check(t, false, "macro_05")
+}
+
+// ===================================================================
+// Module Templates
+// ===================================================================
+
+func Test_Template_01(t *testing.T) {
+	Check(t, false, "template_01")
+}
+
+func Test_Template_02(t *testing.T) {
+	Check(t, false, "template_02")
+}
+
+func Test_Template_03(t *testing.T) {
+	Check(t, false, "template_03")
+}
+
+// ===================================================================
+// Enumerations
+// ===================================================================
+
+func Test_Enum_01(t *testing.T) {
+	Check(t, false, "enum_01")
+}
+
+func Test_Enum_02(t *testing.T) {
+	Check(t, false, "enum_02")
+}
+
+func Test_Enum_03(t *testing.T) {
+	Check(t, false, "enum_03")
+}
+
+func Test_Enum_04(t *testing.T) {
+	Check(t, false, "enum_04")
+}
+
+// ===================================================================
+// Signed Types
+// ===================================================================
+
+func Test_Signed_01(t *testing.T) {
+	Check(t, false, "signed_01")
+}
+
+func Test_Signed_02(t *testing.T) {
+	Check(t, false, "signed_02")
+}
+
+func Test_Signed_03(t *testing.T) {
+	Check(t, false, "signed_03")
+}
+
+// ===================================================================
+// Constant Assertions
+// ===================================================================
+
+func Test_ConstAssert_01(t *testing.T) {
+	Check(t, false, "constassert_01")
+}
+
+func Test_ConstAssert_02(t *testing.T) {
+	Check(t, true, "constassert_02")
+}
+
 // ===================================================================
 // Native computations
 // ===================================================================
@@ -1146,11 +1306,11 @@ const MAX_PADDING uint = 7
 // expect to be accepted are accepted, and all traces that we expect
 // to be rejected are rejected.
 func Check(t *testing.T, stdlib bool, test string) {
-	filename := fmt.Sprintf("%s.lisp", test)
+	filename := fmt.Sprintf("%s/%s.lisp", TestDir, test)
 	// Enable testing each trace in parallel
 	t.Parallel()
 	// Read constraints file
-	bytes, err := os.ReadFile(fmt.Sprintf("%s/%s", TestDir, filename))
+	bytes, err := os.ReadFile(filename)
 	// Check test file read ok
 	if err != nil {
 		t.Fatal(err)
@@ -1234,9 +1394,9 @@ func checkTrace(t *testing.T, inputs []trace.RawColumn, expand bool, id traceId,
 		}
 	} else {
 		// Check Constraints
-		errs := sc.Accepts(100, schema, tr)
+		errs := sc.Accepts(100, 1, schema, tr)
 		// Check assertions
-		errs = append(errs, sc.Asserts(100, schema, tr)...)
+		errs = append(errs, sc.Asserts(100, 1, schema, tr)...)
 		// Determine whether trace accepted or not.
 		accepted := len(errs) == 0
 		// Process what happened versus what was supposed to happen.
@@ -72,6 +72,20 @@ func (p *ArrayTrace) FillColumn(cid uint, data util.FrArray, padding fr.Element)
 	col.fill(data, padding)
 }
 
+// SetHeight sets the height of a given module whose height is not yet
+// determined.  This is used for modules whose height is determined by the
+// schema itself, rather than by the columns of the trace.  This will panic if
+// the height of the module is already determined.
+func (p *ArrayTrace) SetHeight(module uint, height uint) {
+	mod := &p.modules[module]
+	//
+	if mod.height != math.MaxUint {
+		panic(fmt.Sprintf("module %s already has height %d", mod.name, mod.height))
+	}
+	//
+	mod.height = height
+}
+
 // Pad pads a given module with a given number of padding rows.
 func (p *ArrayTrace) Pad(module uint, n uint) {
 	p.modules[module].height += n
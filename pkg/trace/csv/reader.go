@@ -0,0 +1,97 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/go-corset/pkg/trace"
+	"github.com/consensys/go-corset/pkg/util"
+)
+
+// FromBytes parses a trace expressed in CSV form, where the first row gives the
+// (qualified) name of each column, and each subsequent row gives the values of
+// each column at that row.  Values can be given in decimal or (with a "0x"
+// prefix) hexadecimal.  Columns may have different heights, in which case
+// cells beyond the end of a column must be left empty.  The bitwidth of each
+// column is the smallest sufficient to hold all of its values.
+func FromBytes(data []byte) ([]trace.RawColumn, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	// Read header
+	names, err := reader.Read()
+	//
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	//
+	var (
+		columns  = make([][]fr.Element, len(names))
+		bitwidth = make([]uint, len(names))
+		ended    = make([]bool, len(names))
+	)
+	// Read rows
+	for row := 0; ; row++ {
+		cells, err := reader.Read()
+		//
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		//
+		for i, cell := range cells {
+			var (
+				element fr.Element
+				val     big.Int
+			)
+			//
+			if cell = strings.TrimSpace(cell); cell == "" {
+				ended[i] = true
+				continue
+			} else if ended[i] {
+				return nil, fmt.Errorf("%s[%d]: value follows end of column", names[i], row)
+			} else if _, ok := val.SetString(cell, 0); !ok {
+				return nil, fmt.Errorf("%s[%d]: invalid integer %s", names[i], row, cell)
+			}
+			//
+			element.SetBigInt(&val)
+			columns[i] = append(columns[i], element)
+			// Determine bitwidth (accounting for negative values)
+			element.BigInt(&val)
+			bitwidth[i] = max(bitwidth[i], uint(val.BitLen()))
+		}
+	}
+	// Construct columns
+	cols := make([]trace.RawColumn, len(names))
+	//
+	for i, name := range names {
+		data := util.NewFrArray(uint(len(columns[i])), max(1, bitwidth[i]))
+		//
+		for j, element := range columns[i] {
+			data.Set(uint(j), element)
+		}
+		//
+		mod, col := splitQualifiedColumnName(name)
+		cols[i] = trace.RawColumn{Module: mod, Name: col, Data: data}
+	}
+	// Done
+	return cols, nil
+}
+
+// SplitQualifiedColumnName splits a qualified column name into its module and
+// column components.
+func splitQualifiedColumnName(name string) (string, string) {
+	i := strings.Index(name, ".")
+	if i >= 0 {
+		// Split on "."
+		return name[0:i], name[i+1:]
+	}
+	// No module name given, therefore its in the prelude.
+	return "", name
+}
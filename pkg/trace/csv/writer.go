@@ -0,0 +1,49 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/csv"
+
+	"github.com/consensys/go-corset/pkg/trace"
+)
+
+// ToBytes converts a trace into CSV form.  The first row identifies the
+// (qualified) name of each column, and each subsequent row gives the values of
+// each column (in decimal) at that row.  Since columns may have different
+// heights, cells beyond the end of a column are left empty.
+func ToBytes(columns []trace.RawColumn) ([]byte, error) {
+	var (
+		buf    bytes.Buffer
+		writer = csv.NewWriter(&buf)
+		height = uint(0)
+		row    = make([]string, len(columns))
+	)
+	// Write header
+	for i, col := range columns {
+		row[i] = col.QualifiedName()
+		height = max(height, col.Data.Len())
+	}
+	//
+	if err := writer.Write(row); err != nil {
+		return nil, err
+	}
+	// Write rows
+	for i := uint(0); i < height; i++ {
+		for j, col := range columns {
+			if i < col.Data.Len() {
+				ith := col.Data.Get(i)
+				row[j] = ith.String()
+			} else {
+				row[j] = ""
+			}
+		}
+		//
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	// Done
+	writer.Flush()
+	//
+	return buf.Bytes(), writer.Error()
+}
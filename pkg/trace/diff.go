@@ -0,0 +1,107 @@
+package trace
+
+// DIFF_ADDED indicates a column which is present only in the new trace.
+const DIFF_ADDED = 0
+
+// DIFF_REMOVED indicates a column which is present only in the old trace.
+const DIFF_REMOVED = 1
+
+// DIFF_CHANGED indicates a column which is present in both traces, but whose
+// height and/or contents differ.
+const DIFF_CHANGED = 2
+
+// ColumnDiff describes how a given column differs between two traces.
+type ColumnDiff struct {
+	// Kind of difference (e.g. DIFF_ADDED, DIFF_CHANGED, etc).
+	Kind uint
+	// Column in the old trace (or nil if added).
+	Old *RawColumn
+	// Column in the new trace (or nil if removed).
+	New *RawColumn
+	// Total number of rows (common to both columns) whose values differ.
+	Count uint
+	// Rows (common to both columns) whose values differ.  This is limited to
+	// the first few such rows.
+	Rows []uint
+}
+
+// Name returns the qualified name of the column in question.
+func (p *ColumnDiff) Name() string {
+	if p.Old != nil {
+		return p.Old.QualifiedName()
+	}
+	//
+	return p.New.QualifiedName()
+}
+
+// HeightChanged determines whether or not the height of the column differs
+// between the two traces.
+func (p *ColumnDiff) HeightChanged() bool {
+	return p.Kind == DIFF_CHANGED && p.Old.Data.Len() != p.New.Data.Len()
+}
+
+// DiffColumns compares two sets of columns (e.g. from an old and new trace),
+// returning those which differ.  Columns are matched by their qualified name,
+// and the result is ordered according to the old trace, followed by any
+// columns added in the new trace.  For each column present in both, at most
+// limit differing rows are recorded (though all are counted).
+func DiffColumns(oldCols []RawColumn, newCols []RawColumn, limit uint) []ColumnDiff {
+	var (
+		diffs   []ColumnDiff
+		indices = make(map[string]int)
+		matched = make([]bool, len(newCols))
+	)
+	// Index new columns
+	for i := range newCols {
+		indices[newCols[i].QualifiedName()] = i
+	}
+	// Compare old columns against new columns
+	for i := range oldCols {
+		old := &oldCols[i]
+		//
+		if j, ok := indices[old.QualifiedName()]; !ok {
+			diffs = append(diffs, ColumnDiff{DIFF_REMOVED, old, nil, 0, nil})
+		} else if diff := diffColumn(old, &newCols[j], limit); diff != nil {
+			matched[j] = true
+			diffs = append(diffs, *diff)
+		} else {
+			matched[j] = true
+		}
+	}
+	// Identify added columns
+	for i := range newCols {
+		if !matched[i] {
+			diffs = append(diffs, ColumnDiff{DIFF_ADDED, nil, &newCols[i], 0, nil})
+		}
+	}
+	//
+	return diffs
+}
+
+// Compare two columns with the same name, returning nil if they are identical.
+func diffColumn(old *RawColumn, new *RawColumn, limit uint) *ColumnDiff {
+	var (
+		height = min(old.Data.Len(), new.Data.Len())
+		count  uint
+		rows   []uint
+	)
+	//
+	for i := uint(0); i < height; i++ {
+		ith_old := old.Data.Get(i)
+		ith_new := new.Data.Get(i)
+		//
+		if ith_old.Cmp(&ith_new) != 0 {
+			if count < limit {
+				rows = append(rows, i)
+			}
+			//
+			count++
+		}
+	}
+	//
+	if count == 0 && old.Data.Len() == new.Data.Len() {
+		return nil
+	}
+	//
+	return &ColumnDiff{DIFF_CHANGED, old, new, count, rows}
+}
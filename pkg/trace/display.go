@@ -0,0 +1,176 @@
+package trace
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+// Display determines how the values of a given column should be rendered in a
+// human-readable form (e.g. when printing a trace, or when reporting a failing
+// constraint).  This is purely cosmetic, and has no effect on the meaning of a
+// column.
+type Display struct {
+	// Kind of display (e.g. hexadecimal, decimal, etc).
+	Kind DisplayKind
+	// Enumeration used for rendering values symbolically (only when Kind is
+	// DISPLAY_ENUM).
+	Enum *Enumeration
+	// Signed indicates values should be interpreted as signed integers, such
+	// that those in the upper half of the field are rendered as negative
+	// values (e.g. -0x1).
+	Signed bool
+}
+
+// DisplayKind identifies the way in which values are rendered by a given
+// display.
+type DisplayKind uint8
+
+// DISPLAY_HEX renders values in hexadecimal notation (e.g. 0x1f).  This is
+// the default.
+const DISPLAY_HEX DisplayKind = 0
+
+// DISPLAY_DEC renders values in decimal notation (e.g. 31).
+const DISPLAY_DEC DisplayKind = 1
+
+// DISPLAY_BYTES renders values as a sequence of (big endian) bytes, each given
+// in hexadecimal notation (e.g. "01 1f").
+const DISPLAY_BYTES DisplayKind = 2
+
+// DISPLAY_OPCODE renders values as EVM opcode mnemonics (e.g. ADD), falling
+// back to hexadecimal notation for values which are not valid opcodes.
+const DISPLAY_OPCODE DisplayKind = 3
+
+// DISPLAY_ENUM renders values using the member names of a given enumeration.
+const DISPLAY_ENUM DisplayKind = 4
+
+// NewDisplay constructs a display of a given kind, where values are
+// interpreted as unsigned.  Enumeration displays should be constructed using
+// NewEnumDisplay instead.
+func NewDisplay(kind DisplayKind) Display {
+	return Display{kind, nil, false}
+}
+
+// NewEnumDisplay constructs a display which renders values using the member
+// names of a given enumeration (e.g. ADD), falling back to hexadecimal notation
+// for values which are not members.
+func NewEnumDisplay(enum *Enumeration) Display {
+	return Display{DISPLAY_ENUM, enum, false}
+}
+
+// Enumeration associates symbolic names with a set of distinct values.
+type Enumeration struct {
+	// Name of this enumeration.
+	Name string
+	// Names of the members of this enumeration.
+	Members []string
+	// Values of the members of this enumeration (in the same order as their
+	// names).
+	Values []fr.Element
+}
+
+// NameOf returns the name of the member with the given value, or false if no
+// such member exists.
+func (p *Enumeration) NameOf(val fr.Element) (string, bool) {
+	for i := range p.Values {
+		if p.Values[i].Equal(&val) {
+			return p.Members[i], true
+		}
+	}
+	//
+	return "", false
+}
+
+// ParseDisplay converts a display name (e.g. "hex", "dec", etc) into the
+// corresponding display mode.  If the name is not recognised, then false is
+// returned.
+func ParseDisplay(name string) (Display, bool) {
+	switch name {
+	case "hex":
+		return NewDisplay(DISPLAY_HEX), true
+	case "dec":
+		return NewDisplay(DISPLAY_DEC), true
+	case "bytes":
+		return NewDisplay(DISPLAY_BYTES), true
+	case "opcode":
+		return NewDisplay(DISPLAY_OPCODE), true
+	default:
+		return NewDisplay(DISPLAY_HEX), false
+	}
+}
+
+// Format a given value according to this display mode.
+func (p Display) Format(val fr.Element) string {
+	if p.Signed && val.LexicographicallyLargest() {
+		var neg fr.Element
+		// Render the magnitude of a negative value
+		neg.Neg(&val)
+		//
+		return fmt.Sprintf("-%s", p.format(neg))
+	}
+	//
+	return p.format(val)
+}
+
+func (p Display) format(val fr.Element) string {
+	switch p.Kind {
+	case DISPLAY_DEC:
+		return val.Text(10)
+	case DISPLAY_BYTES:
+		return formatBytes(val)
+	case DISPLAY_OPCODE:
+		if val.IsUint64() && val.Uint64() < 256 {
+			if mnemonic := EVM_OPCODES[val.Uint64()]; mnemonic != "" {
+				return mnemonic
+			}
+		}
+	case DISPLAY_ENUM:
+		if name, ok := p.Enum.NameOf(val); ok {
+			return name
+		}
+	}
+	// Default is hexadecimal
+	return fmt.Sprintf("0x%s", val.Text(16))
+}
+
+func (p Display) String() string {
+	switch p.Kind {
+	case DISPLAY_HEX:
+		return "hex"
+	case DISPLAY_DEC:
+		return "dec"
+	case DISPLAY_BYTES:
+		return "bytes"
+	case DISPLAY_OPCODE:
+		return "opcode"
+	case DISPLAY_ENUM:
+		return p.Enum.Name
+	default:
+		return fmt.Sprintf("display(%d)", p.Kind)
+	}
+}
+
+// Render a value as a space separated sequence of bytes, omitting any leading
+// zero bytes.
+func formatBytes(val fr.Element) string {
+	var (
+		builder strings.Builder
+		bytes   = val.Bytes()
+		start   = 0
+	)
+	// Skip leading zeros (but always print at least one byte)
+	for start < len(bytes)-1 && bytes[start] == 0 {
+		start++
+	}
+	//
+	for i := start; i < len(bytes); i++ {
+		if i != start {
+			builder.WriteString(" ")
+		}
+		//
+		builder.WriteString(fmt.Sprintf("%02x", bytes[i]))
+	}
+	//
+	return builder.String()
+}
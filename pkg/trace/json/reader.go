@@ -1,10 +1,17 @@
 package json
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"math/big"
+	"math/bits"
+	"strconv"
 	"strings"
 
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/go-corset/pkg/trace"
 	"github.com/consensys/go-corset/pkg/util"
 )
@@ -12,31 +19,172 @@ import (
 // FromBytes parses a trace expressed in JSON notation.  For example, {"X":
 // [0], "Y": [1]} is a trace containing one row of data each for two columns "X"
 // and "Y".
-func FromBytes(bytes []byte) ([]trace.RawColumn, error) {
-	var rawData map[string][]*big.Int
-	// Unmarshall
-	jsonErr := json.Unmarshal(bytes, &rawData)
-	if jsonErr != nil {
-		return nil, jsonErr
-	}
-	// Construct column data
-	cols := make([]trace.RawColumn, len(rawData))
-	index := 0
-	//
-	for name, rawInts := range rawData {
-		// Translate raw bigints into raw field elements
-		mod, col := splitQualifiedColumnName(name)
-		// TODO: support native field widths in column name.
-		data := util.FrArrayFromBigInts(256, rawInts)
-		// Construct column
-		cols[index] = trace.RawColumn{Module: mod, Name: col, Data: data}
+func FromBytes(data []byte) ([]trace.RawColumn, error) {
+	return FromReader(bytes.NewReader(data))
+}
+
+// FromReader parses a trace expressed in JSON notation from a given reader.
+// The trace is read as a stream of tokens, such that column data is written
+// directly into field arrays (i.e. without first constructing an intermediate
+// representation of the entire trace).  Columns are returned in the order they
+// are declared, and the bitwidth of each column is the smallest sufficient to
+// hold all of its values.  Malformed values are reported along with their path
+// in the trace (e.g. "mod.COL"[1234]).
+func FromReader(reader io.Reader) ([]trace.RawColumn, error) {
+	var (
+		decoder = json.NewDecoder(reader)
+		cols    []trace.RawColumn
+		names   = make(map[string]bool)
+	)
+	// Ensure numbers are not converted into floats
+	decoder.UseNumber()
+	// Read opening brace
+	if err := expectDelim(decoder, '{', ""); err != nil {
+		return nil, err
+	}
+	// Read columns
+	for decoder.More() {
+		token, err := decoder.Token()
+		//
+		if err != nil {
+			return nil, jsonError(decoder, "", err)
+		}
+		// Object keys are always strings
+		name := token.(string)
 		//
-		index++
+		if names[name] {
+			return nil, jsonError(decoder, "", fmt.Errorf("duplicate column \"%s\"", name))
+		}
+		//
+		data, err := readColumnData(decoder, name)
+		//
+		if err != nil {
+			return nil, err
+		}
+		// Construct column
+		mod, col := splitQualifiedColumnName(name)
+		cols = append(cols, trace.RawColumn{Module: mod, Name: col, Data: data})
+		names[name] = true
+	}
+	// Read closing brace
+	if err := expectDelim(decoder, '}', ""); err != nil {
+		return nil, err
+	}
+	// Check nothing follows
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, jsonError(decoder, "", errors.New("unexpected data after trace"))
 	}
 	// Done.
 	return cols, nil
 }
 
+// Read the data for a given column, which should be an array of integers.
+// Elements are written directly into a field array, whose capacity is doubled
+// as necessary.  Likewise, the array is reallocated whenever an element is
+// encountered which is wider than its bitwidth.
+func readColumnData(decoder *json.Decoder, name string) (util.FrArray, error) {
+	var (
+		data     = util.NewFrArray(0, 1)
+		height   uint
+		bitwidth uint = 1
+	)
+	//
+	if err := expectDelim(decoder, '[', fmt.Sprintf("\"%s\"", name)); err != nil {
+		return nil, err
+	}
+	//
+	for ; decoder.More(); height++ {
+		var (
+			element fr.Element
+			width   uint
+			path    = elementPath(name, height)
+		)
+		//
+		token, err := decoder.Token()
+		//
+		if err != nil {
+			return nil, jsonError(decoder, path, err)
+		}
+		//
+		number, ok := token.(json.Number)
+		//
+		if !ok {
+			return nil, jsonError(decoder, path, fmt.Errorf("expected integer, found %v", token))
+		}
+		// Fast path for (the common case of) small values
+		if val, err := strconv.ParseUint(string(number), 10, 64); err == nil {
+			element.SetUint64(val)
+			width = uint(bits.Len64(val))
+		} else if _, err := element.SetString(string(number)); err != nil {
+			return nil, jsonError(decoder, path, fmt.Errorf("invalid integer %s", number))
+		} else {
+			var val big.Int
+			//
+			element.BigInt(&val)
+			width = uint(val.BitLen())
+		}
+		// Reallocate array if it is either full, or too narrow for this element.
+		if height == data.Len() || width > bitwidth {
+			capacity := data.Len()
+			//
+			if height == capacity {
+				capacity = max(16, 2*capacity)
+			}
+			//
+			bitwidth = max(bitwidth, width)
+			data = reallocColumnData(data, height, capacity, bitwidth)
+		}
+		//
+		data.Set(height, element)
+	}
+	//
+	if err := expectDelim(decoder, ']', fmt.Sprintf("\"%s\"", name)); err != nil {
+		return nil, err
+	}
+	// Exclude unused capacity
+	return data.Slice(0, height), nil
+}
+
+// Allocate an array of a given capacity and bitwidth, which holds the first n
+// elements of a given array.
+func reallocColumnData(data util.FrArray, n uint, capacity uint, bitwidth uint) util.FrArray {
+	ndata := util.NewFrArray(capacity, bitwidth)
+	//
+	for i := uint(0); i < n; i++ {
+		ndata.Set(i, data.Get(i))
+	}
+	//
+	return ndata
+}
+
+// Construct the JSON path for a given element of a given column.
+func elementPath(name string, index uint) string {
+	return fmt.Sprintf("\"%s\"[%d]", name, index)
+}
+
+// Read the next token, which is expected to be a given delimiter.
+func expectDelim(decoder *json.Decoder, delim json.Delim, path string) error {
+	token, err := decoder.Token()
+	//
+	if err != nil {
+		return jsonError(decoder, path, err)
+	} else if token != delim {
+		return jsonError(decoder, path, fmt.Errorf("expected '%s', found %v", delim, token))
+	}
+	//
+	return nil
+}
+
+// Construct an error which identifies its position in the trace, both in terms
+// of its JSON path (if known) and its byte offset.
+func jsonError(decoder *json.Decoder, path string, err error) error {
+	if path == "" {
+		return fmt.Errorf("%s (offset %d)", err, decoder.InputOffset())
+	}
+	//
+	return fmt.Errorf("%s: %s (offset %d)", path, err, decoder.InputOffset())
+}
+
 // SplitQualifiedColumnName splits a qualified column name into its module and
 // column components.
 func splitQualifiedColumnName(name string) (string, string) {
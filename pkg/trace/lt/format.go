@@ -0,0 +1,217 @@
+package lt
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/consensys/go-corset/pkg/trace"
+)
+
+// The versioned (v2) LT format is laid out as follows (where all integers are
+// big endian):
+//
+//	magic    [8]byte  (i.e. LT_MAGIC)
+//	major    uint16
+//	minor    uint16
+//	nmodules uint32
+//	modules  [nmodules]{ length uint16, name [length]byte }
+//	ncolumns uint32
+//	columns  [ncolumns]{
+//	   module   uint32  (index into module table)
+//	   length   uint16
+//	   name     [length]byte
+//	   type     uint8   (e.g. LT_TYPE_UINT)
+//	   bitwidth uint16
+//	   flags    uint8   (e.g. LT_FLAG_COMPRESSED)
+//	   rows     uint32
+//	   size     uint64  (number of bytes of column data, as stored)
+//	   crc      uint32  (CRC32 of column data, as stored)
+//	}
+//	data     [ncolumns][size]byte
+//
+// Column data is stored using the minimal number of bytes per element required
+// for the given bitwidth, and may be compressed (using DEFLATE).  By contrast,
+// the original (v1) format has no magic number or version and consists only of
+// the column headers (qualified name, bytes per element and rows) followed by
+// the column data.
+
+// LT_MAGIC identifies a versioned (i.e. v2 or later) LT file.  Observe that v1
+// files start with a column count which, in practice, can never match this.
+var LT_MAGIC [8]byte = [8]byte{'z', 'k', 't', 'r', 'a', 'c', 'e', 0}
+
+// LT_MAJOR_VERSION gives the major version of the LT file format written by
+// this package.  Files with a different major version cannot be read.
+const LT_MAJOR_VERSION uint16 = 2
+
+// LT_MINOR_VERSION gives the minor version of the LT file format written by
+// this package.  Files with an older (or equal) minor version can be read.
+const LT_MINOR_VERSION uint16 = 0
+
+// LT_TYPE_UINT indicates a column holding unsigned integers of a given
+// bitwidth.
+const LT_TYPE_UINT uint8 = 0
+
+// LT_TYPE_FIELD indicates a column holding arbitrary field elements.
+const LT_TYPE_FIELD uint8 = 1
+
+// LT_FLAG_COMPRESSED indicates the column data is compressed.
+const LT_FLAG_COMPRESSED uint8 = 1
+
+// Check whether the given trace file is versioned (or not).
+func isVersioned(buf *bufio.Reader) bool {
+	magic, err := buf.Peek(len(LT_MAGIC))
+	//
+	return err == nil && bytes.Equal(magic, LT_MAGIC[:])
+}
+
+// Read the headers of a versioned (v2) trace file, along with the offset of the
+// first column's data.
+func readHeadersV2(buf io.Reader) ([]columnHeader, int64, error) {
+	var (
+		magic    [8]byte
+		major    uint16
+		minor    uint16
+		nmodules uint32
+		ncols    uint32
+	)
+	// Read magic & version
+	if err := binary.Read(buf, binary.BigEndian, &magic); err != nil {
+		return nil, 0, err
+	} else if err := binary.Read(buf, binary.BigEndian, &major); err != nil {
+		return nil, 0, err
+	} else if err := binary.Read(buf, binary.BigEndian, &minor); err != nil {
+		return nil, 0, err
+	} else if major != LT_MAJOR_VERSION || minor > LT_MINOR_VERSION {
+		return nil, 0, fmt.Errorf("incompatible lt file (was v%d.%d, but expected v%d.%d)", major, minor,
+			LT_MAJOR_VERSION, LT_MINOR_VERSION)
+	}
+	// Magic (8 bytes) + major (2 bytes) + minor (2 bytes)
+	offset := int64(12)
+	// Read module table
+	if err := binary.Read(buf, binary.BigEndian, &nmodules); err != nil {
+		return nil, 0, err
+	}
+	//
+	modules := make([]string, nmodules)
+	offset += 4
+	//
+	for i := range modules {
+		name, err := readString(buf)
+		//
+		if err != nil {
+			return nil, 0, err
+		}
+		//
+		modules[i] = name
+		offset += int64(2 + len(name))
+	}
+	// Read column headers
+	if err := binary.Read(buf, binary.BigEndian, &ncols); err != nil {
+		return nil, 0, err
+	}
+	//
+	headers := make([]columnHeader, ncols)
+	offset += 4
+	//
+	for i := range headers {
+		header, n, err := readColumnHeaderV2(buf, modules)
+		//
+		if err != nil {
+			return nil, 0, err
+		}
+		//
+		headers[i] = header
+		offset += n
+	}
+	//
+	return headers, offset, nil
+}
+
+// Read the header for a single column from a versioned (v2) trace file,
+// returning the number of bytes read.
+func readColumnHeaderV2(buf io.Reader, modules []string) (columnHeader, int64, error) {
+	var (
+		header   columnHeader
+		module   uint32
+		kind     uint8
+		bitwidth uint16
+		flags    uint8
+		rows     uint32
+		size     uint64
+		crc      uint32
+	)
+	//
+	if err := binary.Read(buf, binary.BigEndian, &module); err != nil {
+		return header, 0, err
+	} else if module >= uint32(len(modules)) {
+		return header, 0, fmt.Errorf("invalid module index %d", module)
+	}
+	//
+	name, err := readString(buf)
+	//
+	if err != nil {
+		return header, 0, err
+	}
+	//
+	for _, field := range []any{&kind, &bitwidth, &flags, &rows, &size, &crc} {
+		if err := binary.Read(buf, binary.BigEndian, field); err != nil {
+			return header, 0, err
+		}
+	}
+	//
+	switch kind {
+	case LT_TYPE_UINT:
+		header.bitwidth = uint(bitwidth)
+	case LT_TYPE_FIELD:
+		header.bitwidth = 256
+	default:
+		return header, 0, fmt.Errorf("column %s has unknown type %d", name, kind)
+	}
+	//
+	header.name = trace.QualifiedColumnName(modules[module], name)
+	header.width = byteWidthOf(header.bitwidth)
+	header.length = uint(rows)
+	header.size = uint(size)
+	header.compressed = flags&LT_FLAG_COMPRESSED != 0
+	header.checked = true
+	header.crc = crc
+	// Sanity check size of uncompressed data
+	if !header.compressed && header.size != header.width*header.length {
+		return header, 0, fmt.Errorf("column %s has invalid size", header.name)
+	}
+	// module (4 bytes) + name (2 + n bytes) + type (1 byte) + bitwidth (2 bytes)
+	// + flags (1 byte) + rows (4 bytes) + size (8 bytes) + crc (4 bytes)
+	return header, int64(26 + len(name)), nil
+}
+
+// Read a string prefixed with its length (as a uint16).
+func readString(buf io.Reader) (string, error) {
+	var n uint16
+	//
+	if err := binary.Read(buf, binary.BigEndian, &n); err != nil {
+		return "", err
+	}
+	//
+	bytes := make([]byte, n)
+	//
+	if _, err := io.ReadFull(buf, bytes); err != nil {
+		return "", err
+	}
+	//
+	return string(bytes), nil
+}
+
+// Determine the number of bytes required to hold an element of the given
+// bitwidth.
+func byteWidthOf(bitwidth uint) uint {
+	byteWidth := bitwidth / 8
+	//
+	if bitwidth%8 != 0 {
+		byteWidth++
+	}
+	//
+	return byteWidth
+}
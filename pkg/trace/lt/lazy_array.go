@@ -0,0 +1,112 @@
+package lt
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/go-corset/pkg/util"
+)
+
+// LazyArray is an array of field elements whose contents are decoded from an
+// underlying reader on first access.  Until then, only the header information
+// for the column is held in memory.  This allows very large trace files to be
+// opened without reading every column upfront, since columns which are never
+// accessed are never decoded.
+type LazyArray struct {
+	// Header for the column being read.
+	header columnHeader
+	// Provides access to the raw bytes of the column.
+	reader *io.SectionReader
+	// Ensures the column is decoded at most once (even when accessed
+	// concurrently).
+	once sync.Once
+	// Decoded column data (or nil if not yet decoded).
+	data util.FrArray
+	// Error arising from decoding the column (if any).
+	err error
+}
+
+func newLazyArray(header columnHeader, reader *io.SectionReader) *LazyArray {
+	return &LazyArray{header: header, reader: reader}
+}
+
+// Len returns the number of elements in this array.  This does not require the
+// array to be decoded.
+func (p *LazyArray) Len() uint {
+	return p.header.length
+}
+
+// BitWidth returns the number of bits required to store an element of this
+// array.  This does not require the array to be decoded.
+func (p *LazyArray) BitWidth() uint {
+	return p.header.bitwidth
+}
+
+// Get returns the element at the given index in this array.
+func (p *LazyArray) Get(index uint) fr.Element {
+	return p.decode().Get(index)
+}
+
+// Set the element at the given index in this array, overwriting the original
+// value.
+func (p *LazyArray) Set(index uint, element fr.Element) {
+	p.decode().Set(index, element)
+}
+
+// Clone makes clones of this array producing an otherwise identical copy.
+func (p *LazyArray) Clone() util.Array[fr.Element] {
+	return p.decode().Clone()
+}
+
+// Slice out a subregion of this array.
+func (p *LazyArray) Slice(start uint, end uint) util.Array[fr.Element] {
+	return p.decode().Slice(start, end)
+}
+
+// PadFront inserts a given number of copies of an element at the start of this
+// array, producing an updated array.
+func (p *LazyArray) PadFront(n uint, padding fr.Element) util.Array[fr.Element] {
+	return p.decode().PadFront(n, padding)
+}
+
+// Write out the contents of this array, assuming a minimal unit of 1 byte per
+// element.
+func (p *LazyArray) Write(w io.Writer) error {
+	return p.decode().Write(w)
+}
+
+// Decode the underlying column data (if not already done), returning an error
+// if the column data could not be read, or is corrupted.  This should be called
+// before the array is otherwise accessed, since the remaining methods have no
+// way to report such errors (see trace.Decodable).
+func (p *LazyArray) Decode() error {
+	p.once.Do(func() {
+		bytes := make([]byte, p.reader.Size())
+		//
+		if _, err := p.reader.ReadAt(bytes, 0); err != nil && err != io.EOF {
+			p.err = fmt.Errorf("failed reading column %s (%s)", p.header.name, err)
+		} else {
+			p.data, p.err = decodeColumnData(p.header, bytes)
+		}
+		// Ensure the array remains accessible, even when it cannot be decoded.
+		if p.err != nil {
+			p.data = util.NewFrArray(p.header.length, p.header.bitwidth)
+		}
+		// Allow the reader to be garbage collected
+		p.reader = nil
+	})
+	//
+	return p.err
+}
+
+// Access the decoded column data, decoding it first if necessary.  Since errors
+// cannot be reported here, a column which could not be decoded is treated as
+// though every element were zero.  Such errors are instead reported by Decode,
+// which is called when the trace is built (see schema.TraceBuilder).
+func (p *LazyArray) decode() util.FrArray {
+	_ = p.Decode()
+	//
+	return p.data
+}
@@ -1,8 +1,13 @@
 package lt
 
 import (
+	"bufio"
 	"bytes"
+	"compress/flate"
 	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
 	"strings"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
@@ -12,67 +17,171 @@ import (
 
 // FromBytes parses a byte array representing a given LT trace file into an
 // columns, or produces an error if the original file was malformed in some way.
+// Both the original (v1) format and the versioned (v2) format are supported.
 func FromBytes(data []byte) ([]trace.RawColumn, error) {
-	// Construct new bytes.Reader
-	buf := bytes.NewReader(data)
-	// Read Number of BytesColumns
-	var ncols uint32
-	if err := binary.Read(buf, binary.BigEndian, &ncols); err != nil {
+	// Read column headers
+	headers, offset, err := readHeaders(bufio.NewReader(bytes.NewReader(data)), int64(len(data)))
+	// Check for errors
+	if err != nil {
 		return nil, err
 	}
-	// Construct empty environment
-	headers := make([]columnHeader, ncols)
+	//
+	ncols := uint(len(headers))
 	columns := make([]trace.RawColumn, ncols)
-	// Read column headers
-	for i := uint32(0); i < ncols; i++ {
-		header, err := readColumnHeader(buf)
-		// Read column
-		if err != nil {
-			// Handle error
-			return nil, err
-		}
-		// Assign header
-		headers[i] = header
-	}
-	// Determine byte slices
-	offset := uint(len(data) - buf.Len())
 	c := make(chan util.Pair[uint, util.Array[fr.Element]], ncols)
+	errs := make(chan error, ncols)
 	// Dispatch go-routines
-	for i := uint(0); i < uint(ncols); i++ {
+	for i := uint(0); i < ncols; i++ {
 		ith := headers[i]
-		// Calculate length (in bytes) of this column
-		nbytes := ith.width * ith.length
 		// Dispatch go-routine
-		go func(i uint, offset uint) {
+		go func(i uint, offset int64) {
 			// Read column data
-			elements := readColumnData(ith, data[offset:offset+nbytes])
+			elements, err := decodeColumnData(ith, data[offset:offset+int64(ith.size)])
 			// Package result
+			errs <- err
 			c <- util.NewPair(i, elements)
 		}(i, offset)
 		// Update byte offset
-		offset += nbytes
+		offset += int64(ith.size)
 	}
 	// Collect results
-	for i := uint(0); i < uint(ncols); i++ {
+	for i := uint(0); i < ncols; i++ {
 		// Read packaged result from channel
 		res := <-c
+		// Check for errors
+		if e := <-errs; e != nil {
+			err = e
+		}
 		// Split qualified column name
 		mod, col := splitQualifiedColumnName(headers[res.Left].name)
 		// Construct appropriate slice
 		columns[res.Left] = trace.RawColumn{Module: mod, Name: col, Data: res.Right}
 	}
 	// Done
+	if err != nil {
+		return nil, err
+	}
+	//
+	return columns, nil
+}
+
+// FromReaderAt parses the headers of an LT trace file provided by a given
+// reader, producing columns whose data is decoded lazily (i.e. on first
+// access).  This avoids reading the entire trace file into memory at once,
+// which is important for very large traces.  Furthermore, an optional filter
+// can be given to identify which columns should be loaded (where nil indicates
+// all columns are loaded).  Observe that the reader must remain valid until the
+// returned columns are decoded (see trace.DecodeColumns).
+func FromReaderAt(reader io.ReaderAt, size int64, filter ColumnFilter) ([]trace.RawColumn, error) {
+	var columns []trace.RawColumn
+	// Read column headers
+	headers, offset, err := readHeaders(bufio.NewReader(io.NewSectionReader(reader, 0, size)), size)
+	// Check for errors
+	if err != nil {
+		return nil, err
+	}
+	// Construct (lazy) columns
+	for _, header := range headers {
+		nbytes := int64(header.size)
+		// Split qualified column name
+		mod, col := splitQualifiedColumnName(header.name)
+		// Check whether column required
+		if filter == nil || filter(mod, col) {
+			data := newLazyArray(header, io.NewSectionReader(reader, offset, nbytes))
+			columns = append(columns, trace.RawColumn{Module: mod, Name: col, Data: data})
+		}
+		// Update byte offset
+		offset += nbytes
+	}
+	// Done
 	return columns, nil
 }
 
+// ColumnFilter determines whether a given column (identified by its module and
+// name) should be loaded from a trace file, or not.
+type ColumnFilter = func(string, string) bool
+
 type columnHeader struct {
-	name   string
+	// Qualified name of the column
+	name string
+	// Number of rows in the column
 	length uint
-	width  uint
+	// Number of bytes used to store each element
+	width uint
+	// Number of bits required for each element
+	bitwidth uint
+	// Number of bytes used to store the column data (which differs from width
+	// * length for compressed columns).
+	size uint
+	// Indicates whether the column data is compressed, or not.
+	compressed bool
+	// Indicates whether the column has a checksum, or not.
+	checked bool
+	// Checksum (CRC32) of the column data (as stored).
+	crc uint32
+}
+
+// Read the headers of all columns in a given trace file, along with the offset
+// (in bytes) of the first column's data.  This checks the version of the trace
+// file and, furthermore, that the data for every column lies within the file.
+func readHeaders(buf *bufio.Reader, size int64) ([]columnHeader, int64, error) {
+	var (
+		headers []columnHeader
+		offset  int64
+		err     error
+	)
+	// Check for versioned file format
+	if isVersioned(buf) {
+		headers, offset, err = readHeadersV2(buf)
+	} else {
+		headers, offset, err = readHeadersV1(buf)
+	}
+	//
+	if err != nil {
+		return nil, 0, err
+	}
+	// Sanity check column data is present
+	end := offset
+	//
+	for _, header := range headers {
+		if end += int64(header.size); end > size {
+			return nil, 0, fmt.Errorf("column %s is truncated", header.name)
+		}
+	}
+	//
+	return headers, offset, err
+}
+
+// Read the headers of an (unversioned) v1 trace file.
+func readHeadersV1(buf io.Reader) ([]columnHeader, int64, error) {
+	var ncols uint32
+	// Read Number of BytesColumns
+	if err := binary.Read(buf, binary.BigEndian, &ncols); err != nil {
+		return nil, 0, err
+	}
+	// Construct empty environment
+	headers := make([]columnHeader, ncols)
+	// Track offset of column data (number of columns is 4 bytes).
+	offset := int64(4)
+	// Read column headers
+	for i := uint32(0); i < ncols; i++ {
+		header, err := readColumnHeader(buf)
+		// Read column
+		if err != nil {
+			// Handle error
+			return nil, 0, err
+		}
+		// Name length (2 bytes) + name + element width (1 byte) + length (4 bytes)
+		offset += int64(7 + len(header.name))
+		// Assign header
+		headers[i] = header
+	}
+	//
+	return headers, offset, nil
 }
 
 // Read the meta-data for a specific column in this trace file.
-func readColumnHeader(buf *bytes.Reader) (columnHeader, error) {
+func readColumnHeader(buf io.Reader) (columnHeader, error) {
 	var header columnHeader
 	// Qualified column name length
 	var nameLen uint16
@@ -82,7 +191,7 @@ func readColumnHeader(buf *bytes.Reader) (columnHeader, error) {
 	}
 	// Read column name bytes
 	name := make([]byte, nameLen)
-	if _, err := buf.Read(name); err != nil {
+	if _, err := io.ReadFull(buf, name); err != nil {
 		return header, err
 	}
 
@@ -101,13 +210,36 @@ func readColumnHeader(buf *bytes.Reader) (columnHeader, error) {
 	header.length = uint(length)
 	header.name = string(name)
 	header.width = uint(bytesPerElement)
+	header.bitwidth = header.width * 8
+	header.size = header.width * header.length
 	// Add new column
 	return header, nil
 }
 
+// Decode the data for a given column, as stored in the trace file.  This
+// involves checking the checksum and decompressing the data (where
+// applicable).
+func decodeColumnData(header columnHeader, data []byte) (util.FrArray, error) {
+	// Check integrity of column data
+	if header.checked && crc32.ChecksumIEEE(data) != header.crc {
+		return nil, fmt.Errorf("column %s is corrupted (checksum mismatch)", header.name)
+	}
+	// Decompress column data
+	if header.compressed {
+		reader := flate.NewReader(bytes.NewReader(data))
+		data = make([]byte, header.width*header.length)
+		//
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, fmt.Errorf("column %s is corrupted (%s)", header.name, err)
+		}
+	}
+	//
+	return readColumnData(header, data), nil
+}
+
 func readColumnData(header columnHeader, bytes []byte) util.FrArray {
 	// Construct array
-	data := util.NewFrArray(header.length, header.width*8)
+	data := util.NewFrArray(header.length, header.bitwidth)
 	// Handle special cases
 	switch header.width {
 	case 1:
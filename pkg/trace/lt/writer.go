@@ -2,11 +2,14 @@ package lt
 
 import (
 	"bytes"
+	"compress/flate"
 	"encoding/binary"
+	"hash/crc32"
 	"io"
 	"log"
 
 	"github.com/consensys/go-corset/pkg/trace"
+	"github.com/consensys/go-corset/pkg/util"
 )
 
 // ToBytes writes a given trace file as an array of bytes.
@@ -29,8 +32,87 @@ func ToBytesBuffer(columns []trace.RawColumn) (*bytes.Buffer, error) {
 	return &buf, nil
 }
 
-// WriteBytes a given trace file to an io.Writer.
+// WriteBytes a given trace file to an io.Writer using the original (v1)
+// format.  This remains the default format, since existing tools may not (yet)
+// support the versioned format.
 func WriteBytes(columns []trace.RawColumn, buf io.Writer) error {
+	return WriteBytesV1(columns, buf)
+}
+
+// WriteBytesV2 writes a given trace file to an io.Writer using the versioned
+// (v2) format.  Column data is optionally compressed.
+func WriteBytesV2(columns []trace.RawColumn, compress bool, buf io.Writer) error {
+	var (
+		modules = make(map[string]uint32)
+		names   []string
+		data    = make([][]byte, len(columns))
+		flags   uint8
+	)
+	// Construct module table
+	for _, col := range columns {
+		if _, ok := modules[col.Module]; !ok {
+			modules[col.Module] = uint32(len(names))
+			names = append(names, col.Module)
+		}
+	}
+	// Encode column data
+	for i, col := range columns {
+		var bytes bytes.Buffer
+		//
+		if err := encodeColumnData(col.Data, compress, &bytes); err != nil {
+			return err
+		}
+		//
+		data[i] = bytes.Bytes()
+	}
+	//
+	if compress {
+		flags = LT_FLAG_COMPRESSED
+	}
+	// Write magic & version
+	if err := writeAll(buf, LT_MAGIC, LT_MAJOR_VERSION, LT_MINOR_VERSION, uint32(len(names))); err != nil {
+		return err
+	}
+	// Write module table
+	for _, name := range names {
+		if err := writeString(buf, name); err != nil {
+			return err
+		}
+	}
+	// Write column headers
+	if err := writeAll(buf, uint32(len(columns))); err != nil {
+		return err
+	}
+	//
+	for i, col := range columns {
+		kind, bitwidth := LT_TYPE_UINT, col.Data.BitWidth()
+		//
+		if bitwidth > 252 {
+			kind, bitwidth = LT_TYPE_FIELD, 256
+		}
+		//
+		if err := writeAll(buf, modules[col.Module]); err != nil {
+			return err
+		} else if err := writeString(buf, col.Name); err != nil {
+			return err
+		} else if err := writeAll(buf, kind, uint16(bitwidth), flags, uint32(col.Data.Len()),
+			uint64(len(data[i])), crc32.ChecksumIEEE(data[i])); err != nil {
+			return err
+		}
+	}
+	// Write column data
+	for _, bytes := range data {
+		if _, err := buf.Write(bytes); err != nil {
+			return err
+		}
+	}
+	// Done
+	return nil
+}
+
+// WriteBytesV1 writes a given trace file to an io.Writer using the original
+// (unversioned) format.
+func WriteBytesV1(columns []trace.RawColumn, buf io.Writer) error {
 	ncols := len(columns)
 	// Write column count
 	if err := binary.Write(buf, binary.BigEndian, uint32(ncols)); err != nil {
@@ -54,10 +136,7 @@ func WriteBytes(columns []trace.RawColumn, buf io.Writer) error {
 			log.Fatal(err)
 		}
 		// Determine number of bytes required to hold element of this column.
-		byteWidth := data.BitWidth() / 8
-		if data.BitWidth()%8 != 0 {
-			byteWidth++
-		}
+		byteWidth := byteWidthOf(data.BitWidth())
 		// Write bytes per element
 		if err := binary.Write(buf, binary.BigEndian, uint8(byteWidth)); err != nil {
 			log.Fatal(err)
@@ -70,10 +149,66 @@ func WriteBytes(columns []trace.RawColumn, buf io.Writer) error {
 	// Write column data information
 	for i := 0; i < ncols; i++ {
 		col := columns[i]
-		if err := col.Data.Write(buf); err != nil {
+		if err := writeColumnData(col.Data, buf); err != nil {
 			return err
 		}
 	}
 	// Done
 	return nil
 }
+
+// Encode the data for a given column, optionally compressing it.
+func encodeColumnData(data util.FrArray, compress bool, buf io.Writer) error {
+	if !compress {
+		return writeColumnData(data, buf)
+	}
+	//
+	writer, err := flate.NewWriter(buf, flate.BestSpeed)
+	//
+	if err != nil {
+		return err
+	} else if err = writeColumnData(data, writer); err != nil {
+		return err
+	}
+	//
+	return writer.Close()
+}
+
+// Write out the data for a given column using the minimal number of bytes per
+// element required for the column's bitwidth.
+func writeColumnData(data util.FrArray, buf io.Writer) error {
+	byteWidth := min(32, byteWidthOf(data.BitWidth()))
+	//
+	for i := uint(0); i < data.Len(); i++ {
+		ith := data.Get(i)
+		bytes := ith.Bytes()
+		//
+		if _, err := buf.Write(bytes[32-byteWidth:]); err != nil {
+			return err
+		}
+	}
+	//
+	return nil
+}
+
+// Write a string prefixed with its length (as a uint16).
+func writeString(buf io.Writer, str string) error {
+	if err := binary.Write(buf, binary.BigEndian, uint16(len(str))); err != nil {
+		return err
+	}
+	//
+	_, err := buf.Write([]byte(str))
+	//
+	return err
+}
+
+// Write zero or more fixed-size values in big endian form.
+func writeAll(buf io.Writer, values ...any) error {
+	for _, v := range values {
+		if err := binary.Write(buf, binary.BigEndian, v); err != nil {
+			return err
+		}
+	}
+	//
+	return nil
+}
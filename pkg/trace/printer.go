@@ -15,6 +15,10 @@ type ColumnFilter = func(uint, Trace) bool
 // Highlighter identifies cells which should be highlighted.
 type Highlighter = func(CellRef, Trace) bool
 
+// DisplaySelector determines how the values of a given column should be
+// rendered in the print out.
+type DisplaySelector = func(uint, Trace) Display
+
 // Printer encapsulates various configuration options useful for printing out
 // traces in human-readable forms.
 type Printer struct {
@@ -28,6 +32,8 @@ type Printer struct {
 	colFilter ColumnFilter
 	// Which columns to highlight
 	highlighter Highlighter
+	// How to render the values of each column
+	displays DisplaySelector
 	// Determine maximum width to print
 	maxCellWidth uint
 	// Enable ANSI
@@ -44,8 +50,12 @@ func NewPrinter() *Printer {
 	emptyHighlighter := func(cell CellRef, t Trace) bool {
 		return false
 	}
+	// Show everything in hex by default
+	hexDisplay := func(col uint, t Trace) Display {
+		return NewDisplay(DISPLAY_HEX)
+	}
 	// Return an empty printer
-	return &Printer{0, math.MaxInt, 2, emptyFilter, emptyHighlighter, math.MaxUint, true}
+	return &Printer{0, math.MaxInt, 2, emptyFilter, emptyHighlighter, hexDisplay, math.MaxUint, true}
 }
 
 // Start configures the starting row for this printer.
@@ -88,6 +98,14 @@ func (p *Printer) Highlight(highlighter Highlighter) *Printer {
 	return p
 }
 
+// Displays configures how the values of each column should be rendered (e.g.
+// in hexadecimal, decimal, etc).  By default, all values are shown in
+// hexadecimal.
+func (p *Printer) Displays(displays DisplaySelector) *Printer {
+	p.displays = displays
+	return p
+}
+
 // MaxCellWidth sets the maximum width to use for the cell data.
 func (p *Printer) MaxCellWidth(width uint) *Printer {
 	p.maxCellWidth = width
@@ -128,30 +146,27 @@ func (p *Printer) Print(trace Trace) {
 	// Fill table
 	for i, col := range columns {
 		column := trace.Column(col)
+		display := p.displays(col, trace)
 		maxRow := min(end, column.Data().Len())
 		// Set columns names
 		tp.Set(0, uint(i+1), column.Name())
 		tp.SetEscape(0, uint(i+1), util.NewAnsiEscape().FgColour(util.TERM_WHITE).Build())
 		//
 		for row := start; row < maxRow; row++ {
-			var hex string
 			// Extract data for cell
 			jth := column.Data().Get(row)
 			// Determine text of cell
+			text := display.Format(jth)
 			highlight := p.highlighter(NewCellRef(col, int(row)), trace)
 			//
 			if highlight && !p.ansiEscapes {
 				// In a non-ANSI environment, use a marker "*" to identify which cells were depended upon.
-				hex = fmt.Sprintf("*0x%s", jth.Text(16))
-			} else {
-				if highlight {
-					tp.SetEscape(1+row-start, uint(i+1), highlightEscape)
-				}
-				//
-				hex = fmt.Sprintf("0x%s", jth.Text(16))
+				text = fmt.Sprintf("*%s", text)
+			} else if highlight {
+				tp.SetEscape(1+row-start, uint(i+1), highlightEscape)
 			}
 			//
-			tp.Set(1+row-start, uint(i+1), hex)
+			tp.Set(1+row-start, uint(i+1), text)
 		}
 	}
 	// Cap cells
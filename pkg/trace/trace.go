@@ -1,6 +1,8 @@
 package trace
 
 import (
+	"sync"
+
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/go-corset/pkg/util"
 )
@@ -52,6 +54,48 @@ func (p *RawColumn) QualifiedName() string {
 	return QualifiedColumnName(p.Module, p.Name)
 }
 
+// Decodable is implemented by column data which is decoded lazily from some
+// underlying source (e.g. a trace file held on disk).  Such data should be
+// decoded before it is otherwise accessed, since this is the only point at
+// which errors (e.g. arising from reading the source) can be reported.
+type Decodable interface {
+	// Decode the underlying data (if not already done), returning an error if
+	// the data could not be read, or is corrupted.
+	Decode() error
+}
+
+// DecodeColumns decodes the data of all lazily read columns (in parallel),
+// returning the first error encountered (if any).  Once this succeeds, the
+// columns no longer depend upon their underlying source (e.g. which can then
+// be closed).
+func DecodeColumns(columns []RawColumn) error {
+	var (
+		group sync.WaitGroup
+		errs  = make([]error, len(columns))
+	)
+	//
+	for i, col := range columns {
+		if arr, ok := col.Data.(Decodable); ok {
+			group.Add(1)
+			//
+			go func(i int) {
+				defer group.Done()
+				errs[i] = arr.Decode()
+			}(i)
+		}
+	}
+	//
+	group.Wait()
+	//
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	//
+	return nil
+}
+
 // CellRef identifies a unique cell within a given table.
 type CellRef struct {
 	// Column index for the cell
@@ -0,0 +1,190 @@
+package tracefile
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/consensys/go-corset/pkg/trace"
+	"github.com/consensys/go-corset/pkg/trace/csv"
+	"github.com/consensys/go-corset/pkg/trace/json"
+	"github.com/consensys/go-corset/pkg/trace/lt"
+	"github.com/consensys/go-corset/pkg/util"
+)
+
+// Read a trace file using a parser based on the extension of the filename.
+// Compressed trace files are identified by either their extension (e.g.
+// "trace.lt.gz") or their magic bytes, and are transparently decompressed.
+// Observe that uncompressed lt files are read directly from disk, such that
+// only the data of columns accepted by the filter is read.  Furthermore, an
+// optional filter can be given to identify which columns should be loaded
+// (where nil indicates all columns are loaded).
+func Read(filename string, filter lt.ColumnFilter) ([]trace.RawColumn, error) {
+	cols, closer, err := Open(filename, filter)
+	//
+	if err != nil {
+		return nil, err
+	}
+	// Read column data
+	err = trace.DecodeColumns(cols)
+	// All column data has now been read, hence the file can be closed.
+	if cerr := closer.Close(); err == nil {
+		err = cerr
+	}
+	//
+	return cols, err
+}
+
+// Open a trace file in the same fashion as Read, except that the data of
+// columns in uncompressed lt files is not read until it is first required (e.g.
+// when the trace is built).  Thus, the underlying file remains open until the
+// returned closer is closed, which should only be done once the columns are no
+// longer required.  Observe that errors arising from reading column data are
+// not reported here, but by trace.DecodeColumns (which is called by the trace
+// builder).
+func Open(filename string, filter lt.ColumnFilter) ([]trace.RawColumn, io.Closer, error) {
+	var (
+		bytes []byte
+		tr    []trace.RawColumn
+		err   error
+	)
+	// Check file extension
+	basename, _ := util.SplitCompressionExt(filename)
+	//
+	switch ext := path.Ext(basename); ext {
+	case ".csv":
+		// Read data file
+		if bytes, err = readCompressedFile(filename); err == nil {
+			if tr, err = csv.FromBytes(bytes); err == nil {
+				return filterColumns(tr, filter), nopCloser{}, nil
+			}
+		}
+	case ".json":
+		// Read data file
+		if bytes, err = readCompressedFile(filename); err == nil {
+			if tr, err = json.FromBytes(bytes); err == nil {
+				return filterColumns(tr, filter), nopCloser{}, nil
+			}
+		}
+	case ".lt":
+		return openLtTraceFile(filename, filter)
+	default:
+		err = fmt.Errorf("Unknown trace file format: %s", ext)
+	}
+	//
+	return nil, nil, err
+}
+
+// Read a given file, decompressing its contents if its magic bytes indicate it
+// is compressed.
+func readCompressedFile(filename string) ([]byte, error) {
+	file, err := os.Open(filename)
+	//
+	if err != nil {
+		return nil, err
+	}
+	//
+	bytes, err := readCompressed(file)
+	//
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	//
+	return bytes, err
+}
+
+// Read the contents of a given reader, decompressing them on the fly if their
+// magic bytes indicate they are compressed.
+func readCompressed(reader io.Reader) ([]byte, error) {
+	decompressor, err := util.DecompressReader(reader)
+	//
+	if err != nil {
+		return nil, err
+	}
+	//
+	bytes, err := io.ReadAll(decompressor)
+	//
+	if cerr := decompressor.Close(); err == nil {
+		err = cerr
+	}
+	//
+	return bytes, err
+}
+
+// Open an lt trace file.  Uncompressed files are read directly from disk, such
+// that only the data of columns accepted by the filter is read (and only when
+// it is first required).  Therefore, the file is held open until the returned
+// closer is closed.  Compressed files, on the other hand, are decompressed into
+// memory and read eagerly.
+func openLtTraceFile(filename string, filter lt.ColumnFilter) ([]trace.RawColumn, io.Closer, error) {
+	file, err := os.Open(filename)
+	// Check success
+	if err != nil {
+		return nil, nil, err
+	}
+	//
+	cols, lazy, err := readLtTraceData(file, filter)
+	// Uncompressed files remain open until their columns are decoded.
+	if err == nil && lazy {
+		return cols, file, nil
+	}
+	// Otherwise, the file is no longer required
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	//
+	return cols, nopCloser{}, err
+}
+
+// Read the columns of an lt trace file from an open file, indicating whether or
+// not their data is read lazily from the file.
+func readLtTraceData(file *os.File, filter lt.ColumnFilter) ([]trace.RawColumn, bool, error) {
+	var magic [4]byte
+	//
+	info, err := file.Stat()
+	//
+	if err != nil {
+		return nil, false, err
+	}
+	// Check whether file is compressed
+	n, err := file.ReadAt(magic[:], 0)
+	//
+	if err != nil && err != io.EOF {
+		return nil, false, err
+	} else if util.DetectCompression(magic[:n]) != util.COMPRESSION_NONE {
+		bytes, err := readCompressed(file)
+		//
+		if err != nil {
+			return nil, false, err
+		}
+		//
+		cols, err := lt.FromBytes(bytes)
+		//
+		return filterColumns(cols, filter), false, err
+	}
+	// Read column headers only, such that column data is read on demand.
+	cols, err := lt.FromReaderAt(file, info.Size(), filter)
+	//
+	return cols, true, err
+}
+
+// Remove any columns which are not accepted by the given filter (where nil
+// indicates all columns are accepted).
+func filterColumns(cols []trace.RawColumn, filter lt.ColumnFilter) []trace.RawColumn {
+	if filter == nil {
+		return cols
+	}
+	//
+	return util.RemoveMatching(cols, func(col trace.RawColumn) bool {
+		return !filter(col.Module, col.Name)
+	})
+}
+
+// nopCloser is used for trace files which are read eagerly, and hence have
+// nothing to close.
+type nopCloser struct{}
+
+func (p nopCloser) Close() error {
+	return nil
+}
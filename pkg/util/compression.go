@@ -0,0 +1,156 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression identifies a compression scheme which may have been applied to a
+// given file.
+type Compression uint8
+
+// COMPRESSION_NONE indicates a file is not compressed.
+const COMPRESSION_NONE Compression = 0
+
+// COMPRESSION_GZIP indicates a file is compressed using gzip.
+const COMPRESSION_GZIP Compression = 1
+
+// COMPRESSION_BZIP2 indicates a file is compressed using bzip2.
+const COMPRESSION_BZIP2 Compression = 2
+
+// COMPRESSION_ZSTD indicates a file is compressed using zstd.
+const COMPRESSION_ZSTD Compression = 3
+
+// Magic bytes identifying each compression scheme.
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	bzip2Magic = []byte{'B', 'Z', 'h'}
+	zstdMagic  = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+func (p Compression) String() string {
+	switch p {
+	case COMPRESSION_NONE:
+		return "none"
+	case COMPRESSION_GZIP:
+		return "gzip"
+	case COMPRESSION_BZIP2:
+		return "bzip2"
+	case COMPRESSION_ZSTD:
+		return "zstd"
+	}
+	//
+	return "unknown"
+}
+
+// SplitCompressionExt determines the compression scheme implied by a given
+// filename's extension (if any), and returns the filename with that extension
+// removed.  For example, "trace.lt.gz" gives "trace.lt" and COMPRESSION_GZIP.
+func SplitCompressionExt(filename string) (string, Compression) {
+	var compression Compression
+	//
+	switch path.Ext(filename) {
+	case ".gz":
+		compression = COMPRESSION_GZIP
+	case ".bz2":
+		compression = COMPRESSION_BZIP2
+	case ".zst":
+		compression = COMPRESSION_ZSTD
+	default:
+		return filename, COMPRESSION_NONE
+	}
+	//
+	return filename[:len(filename)-len(path.Ext(filename))], compression
+}
+
+// DetectCompression determines the compression scheme used for some data based
+// on its leading (i.e. magic) bytes.
+func DetectCompression(data []byte) Compression {
+	switch {
+	case bytes.HasPrefix(data, gzipMagic):
+		return COMPRESSION_GZIP
+	case bytes.HasPrefix(data, bzip2Magic):
+		return COMPRESSION_BZIP2
+	case bytes.HasPrefix(data, zstdMagic):
+		return COMPRESSION_ZSTD
+	}
+	//
+	return COMPRESSION_NONE
+}
+
+// ZSTD_MAX_WINDOW determines the largest window size permitted for zstd frames
+// being decompressed.  This bounds the memory required for decompression, such
+// that malformed (or malicious) data cannot exhaust the available memory.
+const ZSTD_MAX_WINDOW = 1 << 27
+
+// DecompressReader wraps a given reader such that data read from it is
+// decompressed on the fly, based on the compression scheme identified by its
+// magic bytes.  Data which is not compressed is passed through unchanged.  The
+// returned reader should be closed once it is no longer required, since this
+// releases any resources held by the decompressor.  Observe that this does not
+// close the underlying reader.
+func DecompressReader(reader io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(reader)
+	// Peek at magic bytes (which may not exist for very short inputs)
+	magic, err := buffered.Peek(len(zstdMagic))
+	//
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	//
+	switch c := DetectCompression(magic); c {
+	case COMPRESSION_NONE:
+		return io.NopCloser(buffered), nil
+	case COMPRESSION_GZIP:
+		decompressor, err := gzip.NewReader(buffered)
+		//
+		if err != nil {
+			return nil, err
+		}
+		//
+		return decompressor, nil
+	case COMPRESSION_BZIP2:
+		return io.NopCloser(bzip2.NewReader(buffered)), nil
+	case COMPRESSION_ZSTD:
+		decompressor, err := zstd.NewReader(buffered, zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxWindow(ZSTD_MAX_WINDOW), zstd.WithDecoderMaxMemory(ZSTD_MAX_WINDOW))
+		//
+		if err != nil {
+			return nil, err
+		}
+		//
+		return decompressor.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("%s decompression not supported", c)
+	}
+}
+
+// Compress some data using a given compression scheme.  Only gzip is supported
+// for compression.
+func Compress(data []byte, compression Compression) ([]byte, error) {
+	var buf bytes.Buffer
+	//
+	switch compression {
+	case COMPRESSION_NONE:
+		return data, nil
+	case COMPRESSION_GZIP:
+		writer := gzip.NewWriter(&buf)
+		//
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		} else if err := writer.Close(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%s compression not supported", compression)
+	}
+	//
+	return buf.Bytes(), nil
+}
@@ -15,6 +15,8 @@ type Parser struct {
 	index int
 	// Mapping from constructed S-Expressions to their spans in the original text.
 	srcmap *SourceMap[SExp]
+	// Spans of all comments encountered so far (in order of appearance).
+	comments []Span
 }
 
 // NewParser constructs a new instance of Parser
@@ -35,6 +37,14 @@ func (p *Parser) SourceMap() *SourceMap[SExp] {
 	return p.srcmap
 }
 
+// Comments returns the spans of all comments encountered during parsing, in the
+// order they appear.  Each span begins with the comment character, and excludes
+// the terminating newline (if any).  Since comments are otherwise discarded,
+// this is helpful for tools which must preserve them (e.g. formatters).
+func (p *Parser) Comments() []Span {
+	return p.comments
+}
+
 // Text returns the underlying text for this parser.
 func (p *Parser) Text() []rune {
 	return p.text
@@ -86,6 +96,20 @@ func (p *Parser) Parse() (SExp, *SyntaxError) {
 		}
 		// Done
 		term = &Array{elements}
+	} else if quote := quoteForm(token); quote != "" {
+		// Record span of quote itself
+		symbol := &Symbol{quote}
+		p.srcmap.Put(symbol, NewSpan(start, p.index))
+		// Parse quoted term
+		quoted, err := p.Parse()
+		// Check for error
+		if err != nil {
+			return nil, err
+		} else if quoted == nil {
+			return nil, p.error("unexpected end-of-file")
+		}
+		// Done
+		term = &List{[]SExp{symbol, quoted}}
 	} else {
 		// Must be a symbol
 		term = &Symbol{string(token)}
@@ -106,10 +130,20 @@ func (p *Parser) Next() []rune {
 	}
 	// Check what we have
 	switch p.text[p.index] {
-	case '(', ')', '{', '}', '[', ']':
-		// List/set begin / end
+	case '(', ')', '{', '}', '[', ']', '`':
+		// List/set begin / end (or quasiquote)
 		p.index = p.index + 1
 		return p.text[p.index-1 : p.index]
+	case ',':
+		// Unquote (or unquote-splicing)
+		if p.index+1 < len(p.text) && p.text[p.index+1] == '@' {
+			p.index = p.index + 2
+			return p.text[p.index-2 : p.index]
+		}
+		//
+		p.index = p.index + 1
+		//
+		return p.text[p.index-1 : p.index]
 	}
 	// Symbol
 	return p.parseSymbol()
@@ -121,14 +155,19 @@ func (p *Parser) SkipWhiteSpace() {
 		// Skip comment
 		if p.text[p.index] == ';' {
 			i := len(p.text)
+			end := i
 			//
 			for j := p.index; j < i; j++ {
 				c := p.text[j]
 				if c == '\n' {
-					i = j + 1
+					i, end = j+1, j
 					break
 				}
 			}
+			// Record comment (unless already seen)
+			if n := len(p.comments); n == 0 || p.comments[n-1].start < p.index {
+				p.comments = append(p.comments, NewSpan(p.index, end))
+			}
 			// Skip comment
 			p.index = i
 		} else {
@@ -196,6 +235,22 @@ func (p *Parser) parseSequence(terminator rune) ([]SExp, *SyntaxError) {
 	return elements, nil
 }
 
+// Determine the quotation form (if any) corresponding to a given token.  For
+// example, "`X" is shorthand for "(quasiquote X)", whilst ",X" is shorthand for
+// "(unquote X)" and ",@X" is shorthand for "(unquote-splicing X)".
+func quoteForm(token []rune) string {
+	switch string(token) {
+	case "`":
+		return "quasiquote"
+	case ",":
+		return "unquote"
+	case ",@":
+		return "unquote-splicing"
+	}
+	//
+	return ""
+}
+
 // Construct a parser error at the current position in the input stream.
 func (p *Parser) error(msg string) *SyntaxError {
 	span := NewSpan(p.index, p.index+1)
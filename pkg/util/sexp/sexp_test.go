@@ -112,6 +112,26 @@ func TestSexp_16(t *testing.T) {
 	CheckOk(t, &e4, "{hello {world}}")
 }
 
+func TestSexp_17(t *testing.T) {
+	e1 := Symbol{"quasiquote"}
+	e2 := Symbol{"hello"}
+	e3 := Symbol{"unquote"}
+	e4 := Symbol{"world"}
+	e5 := List{[]SExp{&e3, &e4}}
+	e6 := List{[]SExp{&e2, &e5}}
+	e7 := List{[]SExp{&e1, &e6}}
+	CheckOk(t, &e7, "`(hello ,world)")
+}
+
+func TestSexp_18(t *testing.T) {
+	e1 := Symbol{"hello"}
+	e2 := Symbol{"unquote-splicing"}
+	e3 := Symbol{"world"}
+	e4 := List{[]SExp{&e2, &e3}}
+	e5 := List{[]SExp{&e1, &e4}}
+	CheckOk(t, &e5, "(hello ,@world)")
+}
+
 // ============================================================================
 // Negative Tests
 // ============================================================================
@@ -136,6 +156,11 @@ func TestSexp_Err4(t *testing.T) {
 	CheckErr(t, "(another string))")
 }
 
+// missing quoted term
+func TestSexp_Err5(t *testing.T) {
+	CheckErr(t, "`")
+}
+
 // ============================================================================
 // Helpers
 // ============================================================================
@@ -79,6 +79,19 @@ func (p *SourceMaps[T]) SyntaxErrors(node T, msg string) []SyntaxError {
 	return []SyntaxError{*err}
 }
 
+// Lookup determines the source file and span associated with a given node, if
+// that node is contained within one of the source files managed by this set of
+// source maps.
+func (p *SourceMaps[T]) Lookup(node T) (*SourceFile, Span, bool) {
+	for i, m := range p.maps {
+		if m.Has(node) {
+			return &p.maps[i].srcfile, m.Get(node), true
+		}
+	}
+	//
+	return nil, Span{}, false
+}
+
 // Join a given source map into this set of source maps.  The effect of this is
 // that nodes recorded in the given source map can be accessed from this set.
 func (p *SourceMaps[T]) Join(srcmap *SourceMap[T]) {
@@ -0,0 +1,2 @@
+(defcolumns X Y)
+(defcomputedcolumn (Z :u32) (+ X Y))
@@ -0,0 +1,2 @@
+(defcolumns X)
+(defcomputedcolumn D (- (shift X 1) X))
@@ -0,0 +1,4 @@
+(module m1)
+(defcolumns (X :u8) (Y :u8))
+(defcomputedcolumn (Z :u16) (* X Y))
+(defcomputedcolumn (W :u16 :display :dec) (+ Z 1))
@@ -0,0 +1,2 @@
+(defcolumns (X :u8) (Y :u8))
+(defcomputedcolumn (Z :u8@prove) (+ X Y))
@@ -0,0 +1,6 @@
+(defpurefun ((vanishes! :@loob) x) x)
+
+(defcolumns X)
+(defcomputedcolumn D (* 2 X))
+;; user constraint sharing its name with the computed column
+(defconstraint D () (vanishes! (* X (- D 2))))
@@ -0,0 +1,3 @@
+;;error:3:34-35:computed column cannot depend upon itself
+(defcolumns X)
+(defcomputedcolumn Y (+ X (shift Y -1)))
@@ -0,0 +1,3 @@
+;;error:3:20-38:array type not permitted
+(defcolumns X)
+(defcomputedcolumn (Y :u8 :array [2]) X)
@@ -0,0 +1,3 @@
+;;error:3:25-26:unknown symbol
+(defcolumns X)
+(defcomputedcolumn Y (+ Z 1))
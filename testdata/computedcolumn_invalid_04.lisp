@@ -0,0 +1,3 @@
+;;error:3:22-23:unexpected loobean computation
+(defcolumns (X :@loob))
+(defcomputedcolumn Y X)
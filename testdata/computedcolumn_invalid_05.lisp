@@ -0,0 +1,6 @@
+;;error:6:22-26:qualified access not permitted here
+(module m1)
+(defcolumns X)
+
+(module m2)
+(defcomputedcolumn Y m1.X)
@@ -0,0 +1,3 @@
+;;error:3:20-21:symbol X already declared
+(defcolumns X)
+(defcomputedcolumn X 1)
@@ -0,0 +1,3 @@
+;;error:3:1-22:malformed declaration
+(defcolumns X)
+(defcomputedcolumn Y)
@@ -0,0 +1,4 @@
+;;error:4:27-28:conflicting context
+(defcolumns X Y)
+(definterleaved Z (X Y))
+(defcomputedcolumn W (+ X Z))
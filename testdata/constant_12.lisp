@@ -0,0 +1,23 @@
+(defpurefun ((vanishes! :@loob) x) x)
+
+(defconst
+    WORD  (/ 256 8)
+    REM   (% 70 WORD)
+    BIT   (shl 1 4)
+    HALF  (shr BIT 1)
+    MASK  (band 0xff 0x0f)
+    FLAGS (bor 1 2 4)
+    DIFF  (bxor FLAGS 5)
+)
+
+(defpurefun (scaled x n) (* x (shl 1 n)))
+
+(defcolumns X Y Z W)
+;; Y == 32 * X
+(defconstraint c1 () (vanishes! (- Y (* WORD X))))
+;; Z == 54 * X
+(defconstraint c2 () (vanishes! (- Z (* (+ REM BIT HALF MASK FLAGS DIFF) X))))
+;; W == 8 * X
+(defconstraint c3 () (vanishes! (- W (scaled X 3))))
+;; W == 8 * X
+(defconstraint c4 () (vanishes! (- W (* (reduce band (for i [0:2] (bor 8 i))) X))))
@@ -0,0 +1,8 @@
+;;error:6:36-37:expected constant argument
+;;error:7:41-42:expected constant argument
+;;error:8:38-45:expected constant argument
+(defpurefun ((vanishes! :@loob) x) x)
+(defcolumns X Y)
+(defconstraint c1 () (vanishes! (/ X 2)))
+(defconstraint c2 () (vanishes! (band 1 Y)))
+(defconstraint c3 () (vanishes! (shl (+ X 1) 2)))
@@ -0,0 +1,8 @@
+;;error:6:38-45:division by zero
+;;error:7:38-45:division by zero
+;;error:8:38-48:invalid shift amount
+(defpurefun ((vanishes! :@loob) x) x)
+(defcolumns X)
+(defconstraint c1 () (vanishes! (* X (/ 1 0))))
+(defconstraint c2 () (vanishes! (* X (% 1 0))))
+(defconstraint c3 () (vanishes! (* X (shl 1 -1))))
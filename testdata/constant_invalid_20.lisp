@@ -0,0 +1,5 @@
+;;error:3:25-26:expected constant argument
+(defpurefun ((vanishes! :@loob) x) x)
+(defpurefun (half x) (/ x 2))
+(defcolumns X)
+(defconstraint c1 () (vanishes! (half X)))
@@ -0,0 +1,3 @@
+;;error:3:15-25:division by zero
+(defconst ZERO 0)
+(defconst BAD (/ 1 ZERO))
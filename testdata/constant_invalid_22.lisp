@@ -0,0 +1,6 @@
+;;error:4:13-20:incorrect number of arguments
+;;error:5:13-22:incorrect number of arguments
+;;error:6:13-19:incorrect number of arguments
+(defconst A (shr 1))
+(defconst B (/ 1 2 3))
+(defconst C (band))
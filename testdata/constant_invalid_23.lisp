@@ -0,0 +1,3 @@
+;;error:3:17-18:cyclic declaration
+;;error:3:21-22:cyclic declaration
+(defconst A 1 B C C B)
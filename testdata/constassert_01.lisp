@@ -0,0 +1,20 @@
+(defpurefun ((vanishes! :@loob) x) x)
+(defpurefun ((distinct :@bool) x y) (~ (- x y)))
+
+(defconst
+    INST_ADD 0x01
+    INST_MUL 0x02
+    INST_SUB 0x03
+    WIDTH_LO 128
+    WIDTH_HI (- 256 WIDTH_LO)
+)
+
+;; instructions are distinct
+(defconstassert (distinct INST_ADD INST_MUL))
+(defconstassert (* (distinct INST_ADD INST_SUB) (distinct INST_MUL INST_SUB)))
+;; widths sum to 256
+(defconstassert (- 1 (~ (- (+ WIDTH_LO WIDTH_HI) 256))))
+
+(defcolumns X Y)
+;; Y == 3 * X
+(defconstraint c1 () (vanishes! (- Y (* X INST_SUB))))
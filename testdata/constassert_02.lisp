@@ -0,0 +1,16 @@
+(defconst
+    INST_ADD 0x01
+    INST_MUL 0x02
+    BYTE     8
+)
+
+(defconstassert (neq INST_ADD INST_MUL))
+(defconstassert (eq (* 32 BYTE) 256))
+(defconstassert (is-zero (% 256 BYTE)))
+
+(module m1)
+(defconst WORD (* 4 BYTE))
+(defconstassert (eq WORD 32))
+(defcolumns X Y)
+;; Y == 2 * X
+(defconstraint c1 () (eq! Y (* X INST_MUL)))
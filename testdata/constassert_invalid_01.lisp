@@ -0,0 +1,8 @@
+;;error:7:17-42:constant assertion failed
+;;error:8:17-34:constant assertion failed
+(defconst
+    INST_ADD 0x01
+    INST_MUL 0x01
+)
+(defconstassert (~ (- INST_ADD INST_MUL)))
+(defconstassert (- 256 (* 2 128)))
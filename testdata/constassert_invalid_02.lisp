@@ -0,0 +1,5 @@
+;;error:4:1-29:malformed declaration
+;;error:5:1-17:malformed declaration
+(defconst ONE 1)
+(defconstassert (- ONE 1) 1)
+(defconstassert)
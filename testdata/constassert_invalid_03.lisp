@@ -0,0 +1,6 @@
+;;error:5:17-18:not permitted in pure context
+;;error:6:24-25:not permitted in pure context
+(defcolumns X)
+(defconst ONE 1)
+(defconstassert X)
+(defconstassert (+ ONE X))
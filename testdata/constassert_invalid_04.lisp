@@ -0,0 +1,6 @@
+;;error:6:24-31:unknown symbol
+;;error:5:17-28:unexpected loobean assertion
+(defpurefun ((eq! :@loob) x y) (- x y))
+(defconst ONE 1)
+(defconstassert (eq! ONE 1))
+(defconstassert (+ ONE UNKNOWN))
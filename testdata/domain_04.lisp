@@ -0,0 +1,5 @@
+(defpurefun ((vanishes! :@loob) x) x)
+
+(defcolumns STAMP)
+;; STAMP[-2] == 0 && STAMP[-1] == 0
+(defconstraint c1 (:domain {-2 -1}) (vanishes! STAMP))
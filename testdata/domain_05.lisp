@@ -0,0 +1,5 @@
+(defpurefun ((vanishes! :@loob) x) x)
+
+(defcolumns STAMP)
+;; STAMP is binary on the last three rows
+(defconstraint c1 (:domain {-3..-1}) (vanishes! (* STAMP (- STAMP 1))))
@@ -0,0 +1,5 @@
+(defpurefun ((vanishes! :@loob) x) x)
+
+(defcolumns STAMP)
+;; STAMP[0] == 0 && STAMP[-3] == 0 && STAMP[-1] == 0
+(defconstraint c1 (:domain {0 -3 -1}) (vanishes! STAMP))
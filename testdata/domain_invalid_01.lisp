@@ -0,0 +1,3 @@
+;;error:3:29-34:domain range cannot mix positive and negative rows
+(defcolumns STAMP)
+(defconstraint c1 (:domain {-1..2}) STAMP)
@@ -0,0 +1,3 @@
+;;error:3:29-33:empty domain range
+(defcolumns STAMP)
+(defconstraint c1 (:domain {3..1}) STAMP)
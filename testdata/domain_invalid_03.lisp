@@ -0,0 +1,3 @@
+;;error:3:29-32:malformed domain range
+(defcolumns STAMP)
+(defconstraint c1 (:domain {0..}) STAMP)
@@ -0,0 +1,3 @@
+;;error:3:31-32:malformed domain element
+(defcolumns STAMP)
+(defconstraint c1 (:domain {0 x}) STAMP)
@@ -0,0 +1,3 @@
+;;error:3:28-30:empty domain
+(defcolumns STAMP)
+(defconstraint c1 (:domain {}) STAMP)
@@ -0,0 +1,3 @@
+;;error:3:31-44:domain too large (exceeds 1024 rows)
+(defcolumns STAMP)
+(defconstraint c1 (:domain {0 0..1000000000}) STAMP)
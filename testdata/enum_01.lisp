@@ -0,0 +1,7 @@
+(defpurefun ((vanishes! :@loob) x) x)
+;; Non-contiguous enumeration
+(defenum OPCODE :u8 (STOP 0x00) (ADD 0x01) (MUL 0x02) (PUSH1 0x60))
+(defcolumns (X :OPCODE) Y)
+(in-enum OPCODE X)
+;; Y can only be non-zero for ADD or PUSH1
+(defconstraint c1 () (vanishes! (* (- X ADD) (- X PUSH1) Y)))
@@ -0,0 +1,4 @@
+;; Contiguous enumeration starting from zero
+(defenum STATE (IDLE 0) (BUSY 1) (DONE 2))
+(defcolumns (ST :STATE))
+(in-enum STATE ST)
@@ -0,0 +1,4 @@
+;; Contiguous enumeration not starting from zero
+(defenum KIND :u4 (A 1) (B 2) (C 3))
+(defcolumns X)
+(in-enum KIND (+ X A))
@@ -0,0 +1,10 @@
+;; Non-contiguous enumeration used in several modules
+(defenum FLAG (LO 0) (MID 4) (HI 16))
+(module m1)
+(defcolumns X)
+(in-enum FLAG X)
+
+(module m2)
+(defcolumns Y Z)
+(in-enum FLAG Y)
+(in-enum FLAG (* 4 Z))
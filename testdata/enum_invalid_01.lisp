@@ -0,0 +1,15 @@
+;;error:8:1-13:malformed enumeration
+;;error:9:10-13:invalid enumeration name
+;;error:10:19-22:malformed enumeration member
+;;error:11:20-22:invalid enumeration member
+;;error:12:20-21:duplicate enumeration member
+;;error:13:22-23:duplicate enumeration value
+;;error:15:10-12:enumeration already defined
+(defenum E1)
+(defenum +E2 (A 1))
+(defenum E3 (A 1) (B))
+(defenum E4 (A 1) (+B 2))
+(defenum E5 (A 1) (A 2))
+(defenum E6 (A 1) (B 1))
+(defenum E7 (C 3))
+(defenum E7 (D 4))
@@ -0,0 +1,10 @@
+;;error:6:27-29:enumeration value out of range
+;;error:7:16-18:invalid enumeration value
+;;error:8:16-17:invalid enumeration value
+;;error:9:13-16:unknown type
+;;error:10:1-17:empty enumeration
+(defenum E1 :u4 (A 15) (B 16))
+(defenum E2 (A -1))
+(defenum E3 (A X))
+(defenum E4 :v8 (A 1))
+(defenum E5 :u8)
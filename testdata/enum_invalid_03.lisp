@@ -0,0 +1,7 @@
+;;error:4:18-21:unknown type
+;;error:5:10-12:unknown enumeration
+;;error:7:10-13:unknown enumeration
+(defcolumns X (Y :E1))
+(in-enum E1 X)
+(defenum E2 (A 1))
+(in-enum +E2 X)
@@ -0,0 +1,3 @@
+;; Columns declared in included file
+(include "include_01_lib.lisp")
+(defconstraint c2 () (vanishes! (- Y X)))
@@ -0,0 +1,3 @@
+(defpurefun ((vanishes! :@loob) x) x)
+(defcolumns X Y)
+(defconstraint c1 () (vanishes! X))
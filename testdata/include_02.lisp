@@ -0,0 +1,3 @@
+;; Diamond includes
+(include "include_02_a.lisp")
+(include "include_02_b.lisp")
@@ -0,0 +1,3 @@
+(include "include_02_c.lisp")
+(module m1)
+(defconstraint b () (vanishes! (- B C)))
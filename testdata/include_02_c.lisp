@@ -0,0 +1,3 @@
+(defpurefun ((vanishes! :@loob) x) x)
+(module m1)
+(defcolumns A B C)
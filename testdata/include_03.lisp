@@ -0,0 +1,7 @@
+;; Same file included via different paths
+(include "include_01_lib.lisp")
+(include "./include_01_lib.lisp")
+(include "../testdata/include_01_lib.lisp")
+(module m1)
+(defcolumns A)
+(defconstraint c2 () (vanishes! A))
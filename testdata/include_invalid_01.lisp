@@ -0,0 +1,2 @@
+;;error:2:1-40:unable to read included file "include_invalid_01_lib.lisp"
+(include "include_invalid_01_lib.lisp")
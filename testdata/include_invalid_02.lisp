@@ -0,0 +1,2 @@
+;;error:2:1-36:cyclic include of "include_invalid_02.lisp"
+(include "include_invalid_02.lisp")
@@ -0,0 +1,6 @@
+;;error:4:1-10:malformed include
+;;error:5:10-11:invalid include path
+;;error:6:1-34:malformed include
+(include)
+(include X)
+(include "lib.lisp" "other.lisp")
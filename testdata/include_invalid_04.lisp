@@ -0,0 +1,2 @@
+;;error:2:10-12:invalid include path
+(include "")
@@ -0,0 +1,3 @@
+;; Errors reported against included file
+(include "include_invalid_05_lib.lisp")
+(defcolumns X)
@@ -0,0 +1,3 @@
+;;error:3:22-23:unknown symbol
+(defpurefun ((vanishes! :@loob) x) x)
+(defconstraint c1 () Y)
@@ -0,0 +1,2 @@
+;; Cycle through included file
+(include "include_invalid_06_lib.lisp")
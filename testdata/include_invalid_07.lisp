@@ -0,0 +1,4 @@
+;;error:3:1-32:include not permitted after module declaration
+(module m1)
+(include "include_01_lib.lisp")
+(defcolumns X)
@@ -0,0 +1,2 @@
+(defcolumns S X Y)
+(deflookup test :guard S (Y) (X))
@@ -0,0 +1,6 @@
+(module m1)
+(defcolumns X)
+
+(module m2)
+(defcolumns T Y)
+(deflookup test :target-guard T (Y) (m1.X))
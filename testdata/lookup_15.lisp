@@ -0,0 +1,6 @@
+(module m1)
+(defcolumns S X Y)
+
+(module m2)
+(defcolumns T A B)
+(deflookup test :guard m1.S :target-guard T (A (+ B 1)) (m1.X m1.Y))
@@ -0,0 +1,3 @@
+;;error:3:24-25:unexpected loobean guard
+(defcolumns (S :@loob) X Y)
+(deflookup test :guard S (Y) (X))
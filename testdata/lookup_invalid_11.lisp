@@ -0,0 +1,3 @@
+;;error:3:17-21:unknown attribute
+(defcolumns S X Y)
+(deflookup test :foo S (Y) (X))
@@ -0,0 +1,7 @@
+;;error:7:24-28:conflicting context
+(module m1)
+(defcolumns X)
+
+(module m2)
+(defcolumns S Y)
+(deflookup test :guard m2.S (m2.Y) (m1.X))
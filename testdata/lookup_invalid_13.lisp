@@ -0,0 +1,3 @@
+;;error:3:17-23:incomplete attribute
+(defcolumns S X Y)
+(deflookup test :guard (Y) (X))
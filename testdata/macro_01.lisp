@@ -0,0 +1,8 @@
+(defpurefun ((vanishes! :@loob) x) x)
+;; Declaration-level macro
+(defmacro (defzero name col)
+  `(defconstraint ,name () (vanishes! ,col)))
+
+(defcolumns X Y)
+(defzero c1 X)
+(defzero c2 Y)
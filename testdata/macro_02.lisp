@@ -0,0 +1,9 @@
+(defpurefun ((vanishes! :@loob) x) x)
+;; Expression-level macros, and splicing of rest arguments
+(defmacro (eq! x y) `(vanishes! (- ,x ,y)))
+(defmacro (defcols &rest cols) `(defcolumns ,@cols))
+(defmacro (sum &rest xs) `(+ ,@xs))
+
+(defcols A B C)
+(defconstraint c1 () (eq! A B))
+(defconstraint c2 () (eq! (sum A B) (sum C C C)))
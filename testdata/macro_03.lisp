@@ -0,0 +1,13 @@
+(defpurefun ((vanishes! :@loob) x) x)
+;; Renaming: the temporary introduced by the macro cannot capture the column
+;; "tmp" at the invocation site.
+(defmacro (defsquare name x y)
+  `(defconstraint ,name () (let ((tmp# ,x)) (vanishes! (- ,y (* tmp# tmp#))))))
+;; Macros can generate multiple declarations
+(defmacro (defbinary name col)
+  `(defcolumns ,col)
+  `(defconstraint ,name () (vanishes! (* ,col (- 1 ,col)))))
+
+(defcolumns tmp Y)
+(defsquare c1 tmp Y)
+(defbinary c2 Z)
@@ -0,0 +1,6 @@
+;; Macros defined in an included file
+(include "macro_04_lib.lisp")
+(module m1)
+(defcolumns A B)
+(defincrementing c1 A)
+(defconstraint c2 () (vanishes! (- B (double A))))
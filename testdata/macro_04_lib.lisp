@@ -0,0 +1,4 @@
+(defpurefun ((vanishes! :@loob) x) x)
+(defmacro (double x) `(+ ,x ,x))
+(defmacro (defincrementing name col)
+  `(defconstraint ,name () (vanishes! (* ,col (- ,col (+ 1 (shift ,col -1)))))))
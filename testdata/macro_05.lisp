@@ -0,0 +1,16 @@
+(defpurefun ((vanishes! :@loob) x) x)
+;; Hygiene: variables bound by a template (e.g. by let or for) cannot capture
+;; symbols of the same name used in arguments at the invocation site.
+(defmacro (defsquare name x y)
+  `(defconstraint ,name () (let ((t ,x)) (vanishes! (- ,y (* t t))))))
+(defmacro (defzero name x)
+  `(defconstraint ,name () (for i [1:2] (vanishes! (* ,x (- ,x i))))))
+;; Likewise for function parameters
+(defmacro (defdouble name)
+  `(defpurefun (,name x) (+ x x)))
+
+(defcolumns t Y i)
+(defdouble double)
+(defsquare c1 Y t)
+(defzero c2 i)
+(defconstraint c3 () (vanishes! (- (double t) (+ t t))))
@@ -0,0 +1,8 @@
+;;error:5:1-11:malformed macro
+;;error:6:1-17:malformed macro
+;;error:7:12-14:invalid macro name
+;;error:8:16-18:invalid macro parameter
+(defmacro)
+(defmacro (f x))
+(defmacro (+1 x) `(+ ,x 1))
+(defmacro (f x &y) `(+ ,x 1))
@@ -0,0 +1,10 @@
+;;error:6:22-23:unknown macro parameter
+;;error:7:21-25:malformed unquote
+;;error:8:18-21:invalid splice
+;;error:9:17-18:malformed macro body
+;;error:10:21-24:nested quasiquote not supported
+(defmacro (f x) `(+ ,y 1))
+(defmacro (g x) `(+ ,(x) 1))
+(defmacro (h x) `,@x)
+(defmacro (i x) y)
+(defmacro (j x) `(+ ``x 1))
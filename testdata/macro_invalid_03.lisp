@@ -0,0 +1,11 @@
+;;error:7:16-17:duplicate macro parameter
+;;error:8:12-13:macro already defined
+;;error:10:22-31:incorrect number of arguments
+;;error:11:22-27:incorrect number of arguments
+(defpurefun ((vanishes! :@loob) x) x)
+(defmacro (f x y) `(+ ,x ,y))
+(defmacro (g x x) `(+ ,x 1))
+(defmacro (f x) `(+ ,x 1))
+(defcolumns X)
+(defconstraint c1 () (f X X X))
+(defconstraint c2 () (f X))
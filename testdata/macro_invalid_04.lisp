@@ -0,0 +1,5 @@
+;;error:5:22-27:macro expansion too deep
+(defpurefun ((vanishes! :@loob) x) x)
+(defmacro (f x) `(f ,x))
+(defcolumns X)
+(defconstraint c1 () (f X))
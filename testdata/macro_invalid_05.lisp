@@ -0,0 +1,9 @@
+;;error:8:1-14:unknown symbol
+;;error:9:12-13:unknown symbol
+;;error:9:1-14:unknown symbol
+(defpurefun ((vanishes! :@loob) x) x)
+(defmacro (defsum name x)
+  `(defconstraint ,name () (vanishes! (+ ,x W))))
+(defcolumns X)
+(defsum c1 X)
+(defsum c2 Y)
@@ -0,0 +1,7 @@
+;;error:6:22-29:cannot splice non-list argument X
+;;error:7:5-6:unexpected or malformed declaration
+(defmacro (sum xs) `(+ ,@xs))
+(defmacro (id x) x)
+(defcolumns X)
+(defconstraint c1 () (sum X))
+(id X)
@@ -0,0 +1,2 @@
+;;error:2:13-18:'#' only permitted in macro templates
+(defcolumns tmp#1)
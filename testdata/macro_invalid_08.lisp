@@ -0,0 +1,2 @@
+;;error:2:14-16:invalid macro parameter
+(defmacro (m x#) `(defcolumns X))
@@ -0,0 +1,5 @@
+;;error:5:99-108:macro expansion too large
+(defpurefun ((vanishes! :@loob) x) x)
+(defmacro (d x) `(+ ,x ,x))
+(defcolumns X)
+(defconstraint c1 () (vanishes! (d (d (d (d (d (d (d (d (d (d (d (d (d (d (d (d (d (d (d (d (d (d (d (d X))))))))))))))))))))))))))
@@ -0,0 +1,11 @@
+(defpurefun ((vanishes! :@loob) x) x)
+
+(defcolumns (X :i16) (Y :i16))
+(defconstraint c1 () (vanishes! (- Y (* 2 X))))
+(definrange X 16)
+
+(module m1)
+(defcolumns (A :i16) (B :i16))
+(defconstraint c1 () (vanishes! (- B A)))
+(deflookup l1 (X) (m1.A))
+(defproperty p1 (vanishes! (- A B)))
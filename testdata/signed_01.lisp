@@ -0,0 +1,4 @@
+(defpurefun ((vanishes! :@loob) x) x)
+(defcolumns (X :s8@prove) (Y :s16@prove))
+;; Y == -2 * X
+(defconstraint c1 () (vanishes! (+ Y (* 2 X))))
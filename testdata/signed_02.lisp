@@ -0,0 +1,7 @@
+(defpurefun ((vanishes! :@loob) x) x)
+(defcolumns (S :s1@prove) Z (A :s2@prove) (B :s2@prove))
+;; Z == norm(S)
+(defconstraint c1 () (vanishes! (- Z (~ S))))
+;; C interleaves A and B, which must be within -1..1
+(definterleaved C (A B))
+(defconstraint c2 () (vanishes! (* C (- C 1) (+ C 1))))
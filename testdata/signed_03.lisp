@@ -0,0 +1,4 @@
+(defcolumns (X :s8) (Y :u8) (Z :s16))
+;; mixing signed and unsigned operands gives s9
+(deflookup l1 (Z) ((+ X Y)))
+(deflookup l2 (Z) ((- Y X)))
@@ -0,0 +1,3 @@
+;;error:3:16-19:invalid signed bitwidth
+;;error:3:24-29:invalid signed bitwidth
+(defcolumns (A :s0) (B :s253))
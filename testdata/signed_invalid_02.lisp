@@ -0,0 +1,8 @@
+;;error:8:22-27:unsigned type required
+;;error:5:20-21:incompatible signed and unsigned types (s8 and u8)
+;;error:6:20-21:incompatible signed and unsigned types (u8 and s8)
+(defcolumns (X :s8) (Y :u8) (Z :s8))
+(deflookup l1 (Y) (X))
+(deflookup l2 (Z) (Y))
+(deflookup l3 (Z) (X))
+(defpermutation (W) ((+ X)))
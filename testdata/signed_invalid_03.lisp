@@ -0,0 +1,6 @@
+;;error:5:20-27:incompatible signed and unsigned types (s9 and u16)
+;;error:6:20-27:incompatible signed and unsigned types (s8 and u252)
+(defcolumns (X :s8) (Y :u8) (Z :u16) (W :u252))
+(deflookup l1 (Z) (Y))
+(deflookup l2 (Z) ((+ X Y)))
+(deflookup l3 (Z) ((* X W)))
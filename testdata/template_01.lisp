@@ -0,0 +1,8 @@
+(defpurefun ((vanishes! :@loob) x) x)
+;; Each instance restricts X to either 0 or N
+(defmodule-template checker (N)
+  (defcolumns X)
+  (defconstraint c () (vanishes! (* X (- X N)))))
+
+(module m1 checker 1)
+(module m2 checker 2)
@@ -0,0 +1,13 @@
+(defpurefun ((vanishes! :@loob) x) x)
+(defconst BASE 3)
+;; Parameters bound to constant expressions
+(defmodule-template adder (LHS RHS)
+  (defcolumns X Y)
+  (defconstraint c () (vanishes! (- Y (* X (+ LHS RHS))))))
+
+(module m1 adder BASE (+ BASE 1))
+;; Additional declarations following instance
+(defcolumns Z)
+(defconstraint d () (vanishes! (- Z (* RHS Y))))
+
+(module m2 adder 0 BASE)
@@ -0,0 +1,9 @@
+(defpurefun ((vanishes! :@loob) x) x)
+(defconst N 1 M 2)
+;; Parameters shadowing constants of the instantiating scope
+(defmodule-template t1 (N M)
+  (defcolumns X Y)
+  (defconstraint c () (vanishes! (- Y (* X (+ N (* 2 M)))))))
+
+(module m1 t1 N (+ N M))
+(module m2 t1 M N)
@@ -0,0 +1,11 @@
+;;error:6:1-23:malformed module template
+;;error:7:21-23:invalid module template name
+;;error:8:27-28:duplicate module template parameter
+;;error:9:27-38:nested module not permitted
+;;error:11:21-23:module template already defined
+(defmodule-template t)
+(defmodule-template +t ())
+(defmodule-template t1 (x x) (defcolumns A))
+(defmodule-template t2 () (module m1) (defcolumns A))
+(defmodule-template t3 () (defcolumns A))
+(defmodule-template t3 () (defcolumns A))
@@ -0,0 +1,4 @@
+;;error:4:12-14:unknown module template
+(defmodule-template t1 (x) (defcolumns A))
+(module m1 t1 1)
+(module m2 t2 1)
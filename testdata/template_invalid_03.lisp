@@ -0,0 +1,4 @@
+;;error:4:1-19:incorrect number of arguments
+(defmodule-template t1 (x) (defcolumns A))
+(module m1 t1 1)
+(module m2 t1 1 2)
@@ -0,0 +1,7 @@
+;;error:6:37-38:unknown symbol
+(defpurefun ((vanishes! :@loob) x) x)
+(defconst N 1)
+(defmodule-template t1 (x)
+  (defcolumns A)
+  (defconstraint c () (vanishes! (- B x))))
+(module m1 t1 N)
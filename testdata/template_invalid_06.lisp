@@ -0,0 +1,6 @@
+;;error:6:15-16:unknown symbol
+(defpurefun ((vanishes! :@loob) x) x)
+(defmodule-template t1 (x)
+  (defcolumns A)
+  (defconstraint c () (vanishes! (- A x))))
+(module m1 t1 A)
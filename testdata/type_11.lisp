@@ -0,0 +1,4 @@
+(defcolumns
+  (X1 :u1@prove)
+  (X4 :u4@prove)
+  (X8 :u8@prove))